@@ -29,9 +29,16 @@ type ApplicationTemplateSpec struct {
 
 	// StableTemplate is included ArgoCD Application manifest. (apiVersion, kind, metadata, spec, ...)
 	StableTemplate string `json:"stable,omitempty"`
+
+	// Variants is map of variant name to ArgoCD Application manifest.
+	// Variant is selected by spec.variantRules of ReviewAppManager.
+	// If the selected variant does not exist, templating fails.
+	// +optional
+	Variants map[string]string `json:"variants,omitempty"`
 }
 
 //+kubebuilder:object:root=true
+//+kubebuilder:storageversion
 //+kubebuilder:resource:shortName=at
 
 // ApplicationTemplate is the Schema for the applicationtemplates API
@@ -0,0 +1,9 @@
+package v1alpha1
+
+// v1alpha1 is the hub (and storage) version of conversion. Other versions are converted via v1alpha1.
+
+func (*ReviewAppManager) Hub()    {}
+func (*ReviewApp) Hub()           {}
+func (*ApplicationTemplate) Hub() {}
+func (*ManifestsTemplate) Hub()   {}
+func (*JobTemplate) Hub()         {}
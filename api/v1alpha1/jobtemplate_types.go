@@ -24,11 +24,26 @@ import (
 // JobTemplateSpec defines the desired state of JobTemplate
 type JobTemplateSpec struct {
 
+	// CandidateTemplate is included Job manifest. (apiVersion, kind, metadata, spec, ...)
+	CandidateTemplate string `json:"candidate,omitempty"`
+
+	// StableTemplate is included Job manifest. (apiVersion, kind, metadata, spec, ...)
+	StableTemplate string `json:"stable,omitempty"`
+
+	// Variants is map of variant name to Job manifest.
+	// Variant is selected by spec.variantRules of ReviewAppManager.
+	// If the selected variant does not exist, Template is used if specified. Otherwise templating fails.
+	// +optional
+	Variants map[string]string `json:"variants,omitempty"`
+
 	// Template is included Job manifest. (apiVersion, kind, metadata, spec, ...)
+	// Deprecated: use StableTemplate & CandidateTemplate. Template is used only if they are empty.
+	// +optional
 	Template string `json:"template,omitempty"`
 }
 
 //+kubebuilder:object:root=true
+//+kubebuilder:storageversion
 //+kubebuilder:resource:shortName=jt
 
 // JobTemplate is the Schema for the jobtemplates API
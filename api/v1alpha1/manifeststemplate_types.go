@@ -27,9 +27,75 @@ type ManifestsTemplateSpec struct {
 
 	// StableData is field that be given various resources' manifest.
 	StableData map[string]string `json:"stable,omitempty"`
+
+	// Variants is map of variant name to various resources' manifest.
+	// Variant is selected by spec.variantRules of ReviewAppManager.
+	// If the selected variant does not exist, templating fails unless this template has no data (e.g. only helm).
+	// +optional
+	Variants map[string]ManifestsTemplateData `json:"variants,omitempty"`
+
+	// Helm is field that be given reference to Helm chart and its values.
+	// Controller writes Chart.yaml depending on the chart and values.yaml to Dirpath,
+	// so Argo CD renders the chart. Keys of CandidateData, StableData & Variants must not be "Chart.yaml" or "values.yaml".
+	// +optional
+	Helm *ManifestsTemplateSpecHelm `json:"helm,omitempty"`
+
+	// MergeStrategy specifies how files of this template are merged into the same name files
+	// of templates listed before in spec.infraRepoConfig.manifests.templates of ReviewAppManager.
+	// +kubebuilder:default=Override
+	// +optional
+	MergeStrategy MergeStrategy `json:"mergeStrategy,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Override;ErrorOnConflict;StrategicMerge
+type MergeStrategy string
+
+const (
+	// MergeStrategyOverride overwrites the same name files
+	MergeStrategyOverride MergeStrategy = "Override"
+	// MergeStrategyErrorOnConflict fails to template if the same name files exist
+	MergeStrategyErrorOnConflict MergeStrategy = "ErrorOnConflict"
+	// MergeStrategyStrategicMerge merges resources of the same name files as YAML strategic merge.
+	// Resources are matched by apiVersion, kind & metadata.name, and unmatched ones are appended.
+	MergeStrategyStrategicMerge MergeStrategy = "StrategicMerge"
+)
+
+// ManifestsTemplateData is map of filename to resources' manifest
+type ManifestsTemplateData map[string]string
+
+type ManifestsTemplateSpecHelm struct {
+
+	// Chart is name of Helm chart
+	Chart string `json:"chart"`
+
+	// Repository is URL of chart repository that has packaged chart (e.g. https://charts.bitnami.com/bitnami).
+	// Either Repository or Path must be specified.
+	// +optional
+	Repository string `json:"repository,omitempty"`
+
+	// Version is version of packaged chart
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Path is path of local chart directory in Infra Repository (e.g. charts/myapp)
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// CandidateValues is values of the chart. It is templated as same as CandidateData.
+	// +optional
+	CandidateValues string `json:"candidateValues,omitempty"`
+
+	// StableValues is values of the chart. It is templated as same as StableData.
+	// +optional
+	StableValues string `json:"stableValues,omitempty"`
+
+	// VariantValues is map of variant name to values of the chart. It is templated as same as Variants.
+	// +optional
+	VariantValues map[string]string `json:"variantValues,omitempty"`
 }
 
 //+kubebuilder:object:root=true
+//+kubebuilder:storageversion
 //+kubebuilder:resource:shortName=mt
 
 // ManifestsTemplate is the Schema for the manifeststemplates API
@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
@@ -39,23 +40,122 @@ type ReviewAppSpec struct {
 	// PreStopJob is specified JobTemplate that executed at previous of stopped ReviewApp
 	PreStopJob NamespacedName `json:"preStopJob,omitempty"`
 
+	// PreStopJobPolicy is timeout & failure policy of the Job specified by PreStopJob
+	// +optional
+	PreStopJobPolicy PreStopJobPolicy `json:"preStopJobPolicy,omitempty"`
+
+	// PostDeployJobs is list of JobTemplates that are run after Argo CD Application has been synced to the commit pushed to Infra Repository.
+	// Results of the Jobs are reported to status and App Repository's PR.
+	// +optional
+	PostDeployJobs []HookJob `json:"postDeployJobs,omitempty"`
+
+	// PreDeployJobs is list of JobTemplates that are run before manifests are pushed to Infra Repository for each commit of PR.
+	// Manifests are pushed only after all of the Jobs succeeded.
+	// +optional
+	PreDeployJobs []HookJob `json:"preDeployJobs,omitempty"`
+
+	// JobLogs is how logs of failed preStop & hook Jobs are collected
+	// +optional
+	JobLogs JobLogsPolicy `json:"jobLogs,omitempty"`
+
+	// TeardownVerification is how controller confirms that environment of ReviewApp has been torn down
+	// before it removes finalizer of ReviewApp being deleted
+	// +optional
+	TeardownVerification TeardownVerification `json:"teardownVerification,omitempty"`
+
 	// Variables is available to use input of Application & Manifest Template
 	Variables []string `json:"variables,omitempty"`
 
+	// VariablesFrom is list of ConfigMaps or Secrets in the same namespace whose keys are available as Variables
+	// Note that values from Secrets are written as plaintext to files of Infra Repository when templates refer them,
+	// so don't refer Secrets whose values must not be committed to Infra Repository.
+	// +optional
+	VariablesFrom []corev1.EnvFromSource `json:"variablesFrom,omitempty"`
+
+	// VariantRules is list of rules to select variant of templates
+	// +optional
+	VariantRules []VariantRule `json:"variantRules,omitempty"`
+
+	// StrictTemplating is flag to fail templating if templates refer to missing key of Variables
+	// +optional
+	StrictTemplating bool `json:"strictTemplating,omitempty"`
+
 	// AppPrNum is watched PR's number by this RA
 	AppPrNum int `json:"appRepoPrNum"`
+
+	// Suspend is flag. Controller does not push to infra repo or comment to App Repository's PR while flag is true.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// DryRun is flag. Controller records rendered manifests & the diff against Infra Repository to status.dryRun
+	// instead of pushing to infra repo or commenting to App Repository's PR while flag is true.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
 }
 
 // ReviewAppStatus defines the observed state of ReviewApp
 type ReviewAppStatus struct {
 
+	// Suspended is true if controller suspends reconciliation of this ReviewApp
+	Suspended bool `json:"suspended,omitempty"`
+
 	// TODO
 	Sync SyncStatus `json:"sync,omitempty"`
 
 	// ManifestsCache is used in "confirm Templates Are Updated" for confirm templates updated
 	ManifestsCache ManifestsCache `json:"manifestsCache,omitempty"`
+
+	// ManifestsSources is map of filename to ManifestsTemplates ("namespace/name") that contributed the file
+	ManifestsSources map[string][]string `json:"manifestsSources,omitempty"`
+
+	// InfraRepoFiles is files written to Infra Repository at last. Files removed from templates are pruned by diffing against it.
+	// +optional
+	InfraRepoFiles InfraRepoFiles `json:"infraRepoFiles,omitempty"`
+
+	// ChatOps is state changed by commands written as comments to App Repository's PR
+	ChatOps ChatOpsStatus `json:"chatops,omitempty"`
+
+	// PreStop is state of the Job specified by spec.preStopJob. It is run when ReviewApp is being deleted.
+	// +optional
+	PreStop PreStopStatus `json:"preStop,omitempty"`
+
+	// PostDeployJobs is state of the Jobs specified by spec.postDeployJobs
+	// +optional
+	PostDeployJobs HookJobsStatus `json:"postDeployJobs,omitempty"`
+
+	// PreDeployJobs is state of the Jobs specified by spec.preDeployJobs
+	// +optional
+	PreDeployJobs HookJobsStatus `json:"preDeployJobs,omitempty"`
+
+	// Teardown is state of waiting for environment to be torn down. It is recorded when ReviewApp is being deleted.
+	// +optional
+	Teardown TeardownStatus `json:"teardown,omitempty"`
+
+	// DryRun is result of rendering in dry-run mode. Rendered Application & manifests are recorded to manifestsCache.
+	// +optional
+	DryRun DryRunStatus `json:"dryRun,omitempty"`
+
+	// Conditions represent the latest available observations of ReviewApp's state
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+const (
+	// ReviewAppConditionManifestsValid represents whether manifests templated from ManifestsTemplate
+	// were built & validated successfully before pushed to Infra Repository
+	ReviewAppConditionManifestsValid = "ManifestsValid"
+	// ReviewAppConditionTemplated represents whether ApplicationTemplate & ManifestsTemplate were templated successfully
+	ReviewAppConditionTemplated = "Templated"
+	// ReviewAppConditionPostDeployJobsSucceeded represents whether all Jobs specified by spec.postDeployJobs
+	// succeeded for the latest commit pushed to Infra Repository
+	ReviewAppConditionPostDeployJobsSucceeded = "PostDeployJobsSucceeded"
+	// ReviewAppConditionPreDeployJobsSucceeded represents whether all Jobs specified by spec.preDeployJobs
+	// succeeded for the latest commit of PR
+	ReviewAppConditionPreDeployJobsSucceeded = "PreDeployJobsSucceeded"
+)
+
 type SyncStatus struct {
 
 	// Status is the sync state of the comparison
@@ -72,6 +172,9 @@ type SyncStatus struct {
 
 	// AlreadySentMessage is used to decide sending message to AppRepo's PR when Spec.AppConfig.SendMessageOnlyFirstTime is true.
 	AlreadySentMessage bool `json:"alreadySentMessage,omitempty"`
+
+	// InfraRepoLatestCommitHash is hash of the commit that was pushed to infra repo at last
+	InfraRepoLatestCommitHash string `json:"infraRepoLatestCommitHash,omitempty"`
 }
 
 type ReviewAppStatusSyncedPullRequest struct {
@@ -79,6 +182,9 @@ type ReviewAppStatusSyncedPullRequest struct {
 	// TODO
 	Branch string `json:"branch,omitempty"`
 
+	// BaseBranch is branch name that PR will be merged into
+	BaseBranch string `json:"baseBranch,omitempty"`
+
 	// TODO
 	LatestCommitHash string `json:"latestCommitHash,omitempty"`
 
@@ -88,19 +194,204 @@ type ReviewAppStatusSyncedPullRequest struct {
 	// TODO
 	Labels []string `json:"labels,omitempty"`
 
+	// Author is login name of the user who opened PR
+	Author string `json:"author,omitempty"`
+
+	// URL is URL of PR
+	URL string `json:"url,omitempty"`
+
+	// CreatedAt is timestamp when PR was opened
+	CreatedAt string `json:"createdAt,omitempty"`
+
 	// TODO
 	SyncTimestamp string `json:"syncTimestamp,omitempty"`
 }
 
 type ManifestsCache struct {
 
-	// Application is manifest of ArgoCD Application resource
+	// Application is manifest of ArgoCD Application resource.
+	// Deprecated: ApplicationDigest is recorded instead not to store values from Secrets in status.
 	Application string `json:"application,omitempty"`
 
-	// Manifests is other manifests
+	// Manifests is other manifests.
+	// Deprecated: ManifestsDigests is recorded instead not to store values from Secrets in status.
 	Manifests map[string]string `json:"manifests,omitempty"`
+
+	// ApplicationDigest is digest of manifest of ArgoCD Application resource
+	ApplicationDigest string `json:"applicationDigest,omitempty"`
+
+	// ManifestsDigests is map of filename to digest of other manifests
+	ManifestsDigests map[string]string `json:"manifestsDigests,omitempty"`
+}
+
+type InfraRepoFiles struct {
+
+	// Paths is list of paths (relative to root of Infra Repository) of Application & other manifests
+	// +optional
+	Paths []string `json:"paths,omitempty"`
+
+	// CommitHash is commit hash of Infra Repository that the files were written by
+	// +optional
+	CommitHash string `json:"commitHash,omitempty"`
+}
+
+type ChatOpsStatus struct {
+
+	// Stopped is flag. ReviewApp's manifests are removed from infra repo while flag is true.
+	Stopped bool `json:"stopped,omitempty"`
+
+	// UseCandidate is flag. Candidate templates are used even if PR does not have candidate label while flag is true.
+	UseCandidate bool `json:"useCandidate,omitempty"`
+
+	// ExpiresAt is timestamp when ReviewApp is stopped automatically
+	ExpiresAt string `json:"expiresAt,omitempty"`
+
+	// LastHandledCommentID is ID of the latest comment that was handled as command
+	LastHandledCommentID int64 `json:"lastHandledCommentId,omitempty"`
+
+	// LastHandledCommentTimestamp is created timestamp of the latest comment that was handled as command
+	LastHandledCommentTimestamp string `json:"lastHandledCommentTimestamp,omitempty"`
+
+	// SyncTimestamp is timestamp when comments of App Repository's PR were read
+	SyncTimestamp string `json:"syncTimestamp,omitempty"`
+}
+
+// HookJobsStatus is state of hook Jobs run for a commit pushed to Infra Repository
+type HookJobsStatus struct {
+
+	// AppRepoCommitHash is hash of the commit of App Repository for which the Jobs were run
+	// +optional
+	AppRepoCommitHash string `json:"appRepoCommitHash,omitempty"`
+
+	// InfraRepoCommitHash is hash of the commit of Infra Repository for which the Jobs were run
+	// +optional
+	InfraRepoCommitHash string `json:"infraRepoCommitHash,omitempty"`
+
+	// Jobs is state of each Job
+	// +optional
+	Jobs []HookJobStatus `json:"jobs,omitempty"`
+
+	// Outputs is data of ConfigMaps written by the Jobs. It is available as Variables in templates.
+	// +optional
+	Outputs map[string]string `json:"outputs,omitempty"`
+}
+
+type HookJobStatus struct {
+
+	// JobTemplate is "namespace/name" of JobTemplate from which the Job was generated
+	JobTemplate string `json:"jobTemplate"`
+
+	// JobName is name of the Job
+	// +optional
+	JobName string `json:"jobName,omitempty"`
+
+	// JobNamespace is namespace of the Job
+	// +optional
+	JobNamespace string `json:"jobNamespace,omitempty"`
+
+	// Phase is phase of the Job
+	Phase HookJobPhase `json:"phase"`
+
+	// Message is human readable reason of Phase
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Logs is tail of logs of the Job when it failed or timed out
+	// +optional
+	Logs string `json:"logs,omitempty"`
+
+	// StartTimestamp is timestamp when the Job was created
+	// +optional
+	StartTimestamp string `json:"startTimestamp,omitempty"`
+
+	// DeadlineTimestamp is timestamp when the Job is regarded as timed out
+	// +optional
+	DeadlineTimestamp string `json:"deadlineTimestamp,omitempty"`
+}
+
+// HookJobPhase is phase of a hook Job
+// +kubebuilder:validation:Enum=Running;Succeeded;Failed;TimedOut
+type HookJobPhase string
+
+const (
+	// HookJobPhaseRunning indicates that the Job has been created and controller is waiting for it
+	HookJobPhaseRunning HookJobPhase = "Running"
+	// HookJobPhaseSucceeded indicates that the Job succeeded
+	HookJobPhaseSucceeded HookJobPhase = "Succeeded"
+	// HookJobPhaseFailed indicates that the Job failed or could not be created
+	HookJobPhaseFailed HookJobPhase = "Failed"
+	// HookJobPhaseTimedOut indicates that the Job did not finish within its timeoutSeconds
+	HookJobPhaseTimedOut HookJobPhase = "TimedOut"
+)
+
+type PreStopStatus struct {
+
+	// Phase is phase of the preStop Job
+	// +optional
+	Phase PreStopPhase `json:"phase,omitempty"`
+
+	// JobName is name of the preStop Job created at last
+	// +optional
+	JobName string `json:"jobName,omitempty"`
+
+	// JobNamespace is namespace of the preStop Job created at last
+	// +optional
+	JobNamespace string `json:"jobNamespace,omitempty"`
+
+	// StartTimestamp is timestamp when the preStop Job was created at last
+	// +optional
+	StartTimestamp string `json:"startTimestamp,omitempty"`
+
+	// Retries is number of the preStop Jobs created again by FailurePolicy "Retry"
+	// +optional
+	Retries int32 `json:"retries,omitempty"`
+
+	// Logs is tail of logs of the preStop Job when it failed or timed out
+	// +optional
+	Logs string `json:"logs,omitempty"`
 }
 
+type TeardownStatus struct {
+
+	// StartTimestamp is timestamp when manifests were deleted from Infra Repository and controller started waiting
+	// +optional
+	StartTimestamp string `json:"startTimestamp,omitempty"`
+
+	// Namespace is templated spec.teardownVerification.namespace that controller waits to be deleted
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+type DryRunStatus struct {
+
+	// RenderedTimestamp is timestamp when Application & manifests were rendered at last
+	// +optional
+	RenderedTimestamp string `json:"renderedTimestamp,omitempty"`
+
+	// InfraRepoCommitHash is commit hash of Infra Repository that Diff was taken against
+	// +optional
+	InfraRepoCommitHash string `json:"infraRepoCommitHash,omitempty"`
+
+	// Diff is unified diff of Infra Repository which would be pushed if dry-run mode were disabled
+	// +optional
+	Diff string `json:"diff,omitempty"`
+}
+
+// PreStopPhase is phase of the preStop Job
+// +kubebuilder:validation:Enum=Running;Succeeded;Failed;TimedOut
+type PreStopPhase string
+
+const (
+	// PreStopPhaseRunning indicates that the preStop Job has been created and controller is waiting for it
+	PreStopPhaseRunning PreStopPhase = "Running"
+	// PreStopPhaseSucceeded indicates that the preStop Job succeeded
+	PreStopPhaseSucceeded PreStopPhase = "Succeeded"
+	// PreStopPhaseFailed indicates that the preStop Job failed or was not found
+	PreStopPhaseFailed PreStopPhase = "Failed"
+	// PreStopPhaseTimedOut indicates that the preStop Job did not finish within spec.preStopJobPolicy.timeoutSeconds
+	PreStopPhaseTimedOut PreStopPhase = "TimedOut"
+)
+
 // SyncStatusCode is a type which represents possible comparison results
 type SyncStatusCode string
 
@@ -116,9 +407,12 @@ const (
 	SyncStatusCodeNeedToUpdateInfraRepo SyncStatusCode = "NeedToUpdateInfraRepo"
 	// SyncStatusCodeUpdatedInfraRepo indicates that ReviewApp manifests was deployed to infra repo. Operator is waiting ArgoCD Application updated
 	SyncStatusCodeUpdatedInfraRepo SyncStatusCode = "UpdatedInfraRepo"
+	// SyncStatusCodeStopped indicates that ReviewApp manifests was removed from infra repo by "/reviewapp stop" command or expiration.
+	SyncStatusCodeStopped SyncStatusCode = "Stopped"
 )
 
 //+kubebuilder:object:root=true
+//+kubebuilder:storageversion
 //+kubebuilder:resource:shortName=ra
 //+kubebuilder:subresource:status
 //+kubebuilder:printcolumn:name="app_organization",type="string",JSONPath=".spec.appRepoTarget.organization",description="Name of Application Repository's Organization"
@@ -126,6 +420,7 @@ const (
 //+kubebuilder:printcolumn:name="app_pr_num",type="integer",JSONPath=".spec.appRepoPrNum",description="Number of Application Repository's PullRequest"
 //+kubebuilder:printcolumn:name="infra_organization",type="string",JSONPath=".spec.infraRepoTarget.organization",description="Name of Infra Repository's Organization"
 //+kubebuilder:printcolumn:name="infra_repository",type="string",JSONPath=".spec.infraRepoTarget.repository",description="Name of Infra Repository"
+//+kubebuilder:printcolumn:name="suspended",type="boolean",JSONPath=".status.suspended",description="Whether reconciliation is suspended"
 
 // ReviewApp is the Schema for the reviewapp API
 type ReviewApp struct {
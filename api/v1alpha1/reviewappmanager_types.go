@@ -40,8 +40,235 @@ type ReviewAppManagerSpec struct {
 	// PreStopJob is specified JobTemplate that executed at previous of stopped ReviewApp
 	PreStopJob NamespacedName `json:"preStopJob,omitempty"`
 
+	// PreStopJobPolicy is timeout & failure policy of the Job specified by PreStopJob
+	// +optional
+	PreStopJobPolicy PreStopJobPolicy `json:"preStopJobPolicy,omitempty"`
+
+	// PostDeployJobs is list of JobTemplates that are run after Argo CD Application has been synced to the commit pushed to Infra Repository.
+	// Results of the Jobs are reported to status and App Repository's PR.
+	// +optional
+	PostDeployJobs []HookJob `json:"postDeployJobs,omitempty"`
+
+	// PreDeployJobs is list of JobTemplates that are run before manifests are pushed to Infra Repository for each commit of PR.
+	// Manifests are pushed only after all of the Jobs succeeded.
+	// Jobs that did not succeed are not run again for the same commit unless "/reviewapp retry" is commented to PR.
+	// +optional
+	PreDeployJobs []HookJob `json:"preDeployJobs,omitempty"`
+
+	// JobLogs is how logs of failed preStop & hook Jobs are collected
+	// +optional
+	JobLogs JobLogsPolicy `json:"jobLogs,omitempty"`
+
+	// TeardownVerification is how controller confirms that environment of ReviewApp has been torn down
+	// before it removes finalizer of ReviewApp being deleted
+	// +optional
+	TeardownVerification TeardownVerification `json:"teardownVerification,omitempty"`
+
 	// Variables is available to use input of Application & Manifest Template
 	Variables []string `json:"variables,omitempty"`
+
+	// VariablesFrom is list of ConfigMaps or Secrets in the same namespace whose keys are available as Variables.
+	// When a key exists in multiple sources, the value of the last source takes precedence,
+	// and values given by Variables take precedence over VariablesFrom.
+	// Note that values from Secrets are written as plaintext to files of Infra Repository when templates refer them,
+	// so don't refer Secrets whose values must not be committed to Infra Repository.
+	// +optional
+	VariablesFrom []corev1.EnvFromSource `json:"variablesFrom,omitempty"`
+
+	// VariantRules is list of rules to select variant of templates for each PR.
+	// Rules are evaluated in order and the first matched rule is used. If no rule matches, "stable" is used.
+	// If VariantRules is empty, "candidate" is used for PRs having "candidate-template" label.
+	// +optional
+	VariantRules []VariantRule `json:"variantRules,omitempty"`
+
+	// StrictTemplating is flag. Templating fails if templates refer to missing key of Variables (missingkey=error)
+	// instead of rendering "<no value>".
+	// +kubebuilder:default=false
+	// +optional
+	StrictTemplating bool `json:"strictTemplating,omitempty"`
+
+	// VariableOverrides allows each PR to override Variables by its labels or its description
+	// +optional
+	VariableOverrides ReviewAppManagerSpecVariableOverrides `json:"variableOverrides,omitempty"`
+
+	// Suspend is flag. Controller does not create or delete ReviewApps while flag is true.
+	// The flag is also propagated to existing ReviewApps.
+	// +kubebuilder:default=false
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// DryRun is flag. ReviewApps render Application & manifests and record them with the diff against Infra Repository to their status,
+	// instead of pushing to Infra Repository or commenting to App Repository's PR.
+	// The flag is also propagated to existing ReviewApps.
+	// +kubebuilder:default=false
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// OrphanSweeper periodically removes files left in Infra Repository by ReviewApps that no longer exist
+	// +optional
+	OrphanSweeper OrphanSweeper `json:"orphanSweeper,omitempty"`
+}
+
+type VariantRule struct {
+
+	// Variant is name of variant. "stable" & "candidate" refer to stable & candidate fields of templates,
+	// and others refer to variants field of templates. If templates do not have the variant, templating fails.
+	Variant string `json:"variant"`
+
+	// Labels is list of PR's labels. Rule matches if PR has any of them.
+	// +optional
+	Labels []string `json:"labels,omitempty"`
+
+	// BranchExp is regular expression. Rule matches if PR's branch matches it.
+	// +optional
+	BranchExp string `json:"branchExp,omitempty"`
+}
+
+// HookJob is JobTemplate run at a point of ReviewApp's lifecycle
+type HookJob struct {
+
+	// Namespace of JobTemplate
+	Namespace string `json:"namespace"`
+
+	// Name of JobTemplate
+	Name string `json:"name"`
+
+	// TimeoutSeconds is duration until the Job is regarded as failed
+	// +kubebuilder:default=300
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+
+	// OutputConfigMap is name of ConfigMap to which the Job writes outputs. It is templated in the same way as JobTemplate.
+	// The ConfigMap is read from the namespace of the Job after the Job succeeded, and its data is available as Variables in templates.
+	// Only Jobs specified by preDeployJobs can have outputs. Outputs are not available until the Jobs succeed for the first time,
+	// so templates referring to them should not be used with strictTemplating.
+	// +optional
+	OutputConfigMap string `json:"outputConfigMap,omitempty"`
+}
+
+// JobLogsPolicy is how logs of failed Jobs are collected into Events, status and App Repository's PR.
+// Logs are truncated to LimitBytes and values looking like credentials are redacted.
+type JobLogsPolicy struct {
+
+	// TailLines is number of lines read from the end of logs of each container
+	// +kubebuilder:default=20
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=500
+	// +optional
+	TailLines int32 `json:"tailLines,omitempty"`
+
+	// LimitBytes is max size of logs of each Job
+	// +kubebuilder:default=4096
+	// +kubebuilder:validation:Minimum=256
+	// +kubebuilder:validation:Maximum=32768
+	// +optional
+	LimitBytes int32 `json:"limitBytes,omitempty"`
+
+	// PostToPullRequest is flag to post logs to App Repository's PR as collapsible section
+	// +optional
+	PostToPullRequest bool `json:"postToPullRequest,omitempty"`
+}
+
+// TeardownVerification is configuration of waiting for Argo CD to tear down environment of ReviewApp being deleted.
+// Finalizer of ReviewApp is removed after the environment is gone or TimeoutSeconds has passed.
+type TeardownVerification struct {
+
+	// Enabled is flag. Controller waits for Argo CD Application of ReviewApp to be deleted
+	// after manifests are deleted from Infra Repository if flag is true.
+	// +kubebuilder:default=false
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Namespace is Namespace that controller also waits to be deleted. Go template is available (e.g. "demo-{{.AppRepo.PrNumber}}").
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// TimeoutSeconds is duration until controller gives up waiting and removes finalizer with Warning Event
+	// +kubebuilder:default=600
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// OrphanSweeper is configuration of sweeping files left in Infra Repository by ReviewApps deleted without finalizer (e.g. force-deleted).
+// Paths are found by matching infraRepoConfig.argocdApp.filepath & infraRepoConfig.manifests.dirpath including {{.AppRepo.PrNumber}},
+// in which {{.AppRepo.PrNumber}} matches only digits, and paths that are not written by any existing ReviewApp are regarded as orphans.
+type OrphanSweeper struct {
+
+	// Enabled is flag. Controller sweeps orphans periodically if flag is true.
+	// +kubebuilder:default=false
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// IntervalSeconds is interval of sweeping
+	// +kubebuilder:default=3600
+	// +kubebuilder:validation:Minimum=60
+	// +optional
+	IntervalSeconds int32 `json:"intervalSeconds,omitempty"`
+
+	// DryRun is flag. Controller only reports orphans to Event & status without removing them if flag is true.
+	// +kubebuilder:default=false
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+type PreStopJobPolicy struct {
+
+	// TimeoutSeconds is duration until the preStop Job is regarded as failed
+	// +kubebuilder:default=300
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+
+	// FailurePolicy is action when the preStop Job fails or times out.
+	// "Continue" deletes manifests from Infra Repository anyway, "Block" keeps ReviewApp until the policy is changed,
+	// and "Retry" creates the Job again up to MaxRetries times and then continues.
+	// +kubebuilder:default=Continue
+	// +optional
+	FailurePolicy PreStopJobFailurePolicy `json:"failurePolicy,omitempty"`
+
+	// MaxRetries is max number of retries when FailurePolicy is "Retry"
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRetries int32 `json:"maxRetries,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Continue;Block;Retry
+type PreStopJobFailurePolicy string
+
+const (
+	PreStopJobFailurePolicyContinue PreStopJobFailurePolicy = "Continue"
+	PreStopJobFailurePolicyBlock    PreStopJobFailurePolicy = "Block"
+	PreStopJobFailurePolicyRetry    PreStopJobFailurePolicy = "Retry"
+)
+
+type ReviewAppManagerSpecVariableOverrides struct {
+
+	// AllowedKeys is list of variable keys that are allowed to be overridden by PRs.
+	// Overrides of other keys are ignored.
+	// +optional
+	AllowedKeys []string `json:"allowedKeys,omitempty"`
+
+	// Labels is list of variable sets that are applied if PR has the label
+	// +optional
+	Labels []ReviewAppManagerSpecVariableOverridesLabel `json:"labels,omitempty"`
+
+	// PullRequestBody is flag. Controller reads fenced code block with "reviewapp" info string
+	// in the description of PR as YAML (e.g. "replicas: 3") and applies it if flag is true.
+	// +kubebuilder:default=false
+	// +optional
+	PullRequestBody bool `json:"pullRequestBody,omitempty"`
+}
+
+type ReviewAppManagerSpecVariableOverridesLabel struct {
+
+	// Name is name of PR's label
+	Name string `json:"name"`
+
+	// Variables is variable set (format is same as spec.variables) that is applied if PR has the label
+	Variables []string `json:"variables"`
 }
 
 type ReviewAppManagerSpecAppTarget struct {
@@ -75,6 +302,23 @@ type ReviewAppManagerSpecAppConfig struct {
 	// +kubebuilder:default=false
 	// +optional
 	SendMessageEveryTime bool `json:"sendMessageEveryTime,omitempty"`
+
+	// ChatOps is configuration of commands (e.g. "/reviewapp stop") that are written as comments to App Repository's PR
+	// +optional
+	ChatOps ReviewAppManagerSpecAppConfigChatOps `json:"chatops,omitempty"`
+}
+
+type ReviewAppManagerSpecAppConfigChatOps struct {
+
+	// Enabled is flag. Controller reads comments of App Repository's PR and runs commands if flag is true.
+	// +kubebuilder:default=false
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Lifetime is duration (e.g. "72h", "3d") until ReviewApp is stopped automatically.
+	// It can be extended by "/reviewapp extend" command. ReviewApp is not stopped automatically if this field is empty.
+	// +optional
+	Lifetime string `json:"lifetime,omitempty"`
 }
 
 type ReviewAppManagerSpecInfraTarget struct {
@@ -126,8 +370,30 @@ type ReviewAppManagerSpecInfraArgoCDApp struct {
 // ReviewAppManagerStatus defines the observed state of ReviewAppManager
 type ReviewAppManagerStatus struct {
 
+	// Suspended is true if controller suspends reconciliation of this ReviewAppManager
+	Suspended bool `json:"suspended,omitempty"`
+
 	// TODO
 	SyncedPullRequests []ReviewAppManagerStatusSyncedPullRequests `json:"syncedPullRequests,omitempty"`
+
+	// OrphanSweep is result of the last sweep by spec.orphanSweeper
+	// +optional
+	OrphanSweep OrphanSweepStatus `json:"orphanSweep,omitempty"`
+}
+
+type OrphanSweepStatus struct {
+
+	// LastSweepTimestamp is timestamp when controller swept orphans at last
+	// +optional
+	LastSweepTimestamp string `json:"lastSweepTimestamp,omitempty"`
+
+	// Orphans is list of orphaned paths found in Infra Repository at last
+	// +optional
+	Orphans []string `json:"orphans,omitempty"`
+
+	// InfraRepoCommitHash is commit hash of Infra Repository that removed Orphans. It is empty in dry-run mode.
+	// +optional
+	InfraRepoCommitHash string `json:"infraRepoCommitHash,omitempty"`
 }
 
 type ReviewAppManagerStatusSyncedPullRequests struct {
@@ -146,12 +412,14 @@ type ReviewAppManagerStatusSyncedPullRequests struct {
 }
 
 //+kubebuilder:object:root=true
+//+kubebuilder:storageversion
 //+kubebuilder:resource:shortName=ram
 //+kubebuilder:subresource:status
 //+kubebuilder:printcolumn:name="app_organization",type="string",JSONPath=".spec.appRepoTarget.organization",description="Name of Application Repository's Organization"
 //+kubebuilder:printcolumn:name="app_repository",type="string",JSONPath=".spec.appRepoTarget.repository",description="Name of Application Repository"
 //+kubebuilder:printcolumn:name="infra_organization",type="string",JSONPath=".spec.infraRepoTarget.organization",description="Name of Infra Repository's Organization"
 //+kubebuilder:printcolumn:name="infra_repository",type="string",JSONPath=".spec.infraRepoTarget.repository",description="Name of Infra Repository"
+//+kubebuilder:printcolumn:name="suspended",type="boolean",JSONPath=".status.suspended",description="Whether reconciliation is suspended"
 
 // ReviewAppManager is the Schema for the reviewappmanagers API
 type ReviewAppManager struct {
@@ -23,6 +23,7 @@ package v1alpha1
 
 import (
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -31,7 +32,7 @@ func (in *ApplicationTemplate) DeepCopyInto(out *ApplicationTemplate) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationTemplate.
@@ -87,6 +88,13 @@ func (in *ApplicationTemplateList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApplicationTemplateSpec) DeepCopyInto(out *ApplicationTemplateSpec) {
 	*out = *in
+	if in.Variants != nil {
+		in, out := &in.Variants, &out.Variants
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationTemplateSpec.
@@ -99,12 +107,134 @@ func (in *ApplicationTemplateSpec) DeepCopy() *ApplicationTemplateSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChatOpsStatus) DeepCopyInto(out *ChatOpsStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChatOpsStatus.
+func (in *ChatOpsStatus) DeepCopy() *ChatOpsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ChatOpsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DryRunStatus) DeepCopyInto(out *DryRunStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DryRunStatus.
+func (in *DryRunStatus) DeepCopy() *DryRunStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DryRunStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HookJob) DeepCopyInto(out *HookJob) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HookJob.
+func (in *HookJob) DeepCopy() *HookJob {
+	if in == nil {
+		return nil
+	}
+	out := new(HookJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HookJobStatus) DeepCopyInto(out *HookJobStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HookJobStatus.
+func (in *HookJobStatus) DeepCopy() *HookJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HookJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HookJobsStatus) DeepCopyInto(out *HookJobsStatus) {
+	*out = *in
+	if in.Jobs != nil {
+		in, out := &in.Jobs, &out.Jobs
+		*out = make([]HookJobStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HookJobsStatus.
+func (in *HookJobsStatus) DeepCopy() *HookJobsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HookJobsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfraRepoFiles) DeepCopyInto(out *InfraRepoFiles) {
+	*out = *in
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfraRepoFiles.
+func (in *InfraRepoFiles) DeepCopy() *InfraRepoFiles {
+	if in == nil {
+		return nil
+	}
+	out := new(InfraRepoFiles)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobLogsPolicy) DeepCopyInto(out *JobLogsPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobLogsPolicy.
+func (in *JobLogsPolicy) DeepCopy() *JobLogsPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(JobLogsPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JobTemplate) DeepCopyInto(out *JobTemplate) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobTemplate.
@@ -160,6 +290,13 @@ func (in *JobTemplateList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JobTemplateSpec) DeepCopyInto(out *JobTemplateSpec) {
 	*out = *in
+	if in.Variants != nil {
+		in, out := &in.Variants, &out.Variants
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobTemplateSpec.
@@ -182,6 +319,13 @@ func (in *ManifestsCache) DeepCopyInto(out *ManifestsCache) {
 			(*out)[key] = val
 		}
 	}
+	if in.ManifestsDigests != nil {
+		in, out := &in.ManifestsDigests, &out.ManifestsDigests
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestsCache.
@@ -220,6 +364,27 @@ func (in *ManifestsTemplate) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in ManifestsTemplateData) DeepCopyInto(out *ManifestsTemplateData) {
+	{
+		in := &in
+		*out = make(ManifestsTemplateData, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestsTemplateData.
+func (in ManifestsTemplateData) DeepCopy() ManifestsTemplateData {
+	if in == nil {
+		return nil
+	}
+	out := new(ManifestsTemplateData)
+	in.DeepCopyInto(out)
+	return *out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManifestsTemplateList) DeepCopyInto(out *ManifestsTemplateList) {
 	*out = *in
@@ -269,6 +434,28 @@ func (in *ManifestsTemplateSpec) DeepCopyInto(out *ManifestsTemplateSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Variants != nil {
+		in, out := &in.Variants, &out.Variants
+		*out = make(map[string]ManifestsTemplateData, len(*in))
+		for key, val := range *in {
+			var outVal map[string]string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make(ManifestsTemplateData, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Helm != nil {
+		in, out := &in.Helm, &out.Helm
+		*out = new(ManifestsTemplateSpecHelm)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestsTemplateSpec.
@@ -281,6 +468,28 @@ func (in *ManifestsTemplateSpec) DeepCopy() *ManifestsTemplateSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManifestsTemplateSpecHelm) DeepCopyInto(out *ManifestsTemplateSpecHelm) {
+	*out = *in
+	if in.VariantValues != nil {
+		in, out := &in.VariantValues, &out.VariantValues
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestsTemplateSpecHelm.
+func (in *ManifestsTemplateSpecHelm) DeepCopy() *ManifestsTemplateSpecHelm {
+	if in == nil {
+		return nil
+	}
+	out := new(ManifestsTemplateSpecHelm)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NamespacedName) DeepCopyInto(out *NamespacedName) {
 	*out = *in
@@ -296,6 +505,71 @@ func (in *NamespacedName) DeepCopy() *NamespacedName {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrphanSweepStatus) DeepCopyInto(out *OrphanSweepStatus) {
+	*out = *in
+	if in.Orphans != nil {
+		in, out := &in.Orphans, &out.Orphans
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrphanSweepStatus.
+func (in *OrphanSweepStatus) DeepCopy() *OrphanSweepStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OrphanSweepStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrphanSweeper) DeepCopyInto(out *OrphanSweeper) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrphanSweeper.
+func (in *OrphanSweeper) DeepCopy() *OrphanSweeper {
+	if in == nil {
+		return nil
+	}
+	out := new(OrphanSweeper)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreStopJobPolicy) DeepCopyInto(out *PreStopJobPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreStopJobPolicy.
+func (in *PreStopJobPolicy) DeepCopy() *PreStopJobPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PreStopJobPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreStopStatus) DeepCopyInto(out *PreStopStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreStopStatus.
+func (in *PreStopStatus) DeepCopy() *PreStopStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PreStopStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReviewApp) DeepCopyInto(out *ReviewApp) {
 	*out = *in
@@ -422,11 +696,40 @@ func (in *ReviewAppManagerSpec) DeepCopyInto(out *ReviewAppManagerSpec) {
 	in.InfraTarget.DeepCopyInto(&out.InfraTarget)
 	in.InfraConfig.DeepCopyInto(&out.InfraConfig)
 	out.PreStopJob = in.PreStopJob
+	out.PreStopJobPolicy = in.PreStopJobPolicy
+	if in.PostDeployJobs != nil {
+		in, out := &in.PostDeployJobs, &out.PostDeployJobs
+		*out = make([]HookJob, len(*in))
+		copy(*out, *in)
+	}
+	if in.PreDeployJobs != nil {
+		in, out := &in.PreDeployJobs, &out.PreDeployJobs
+		*out = make([]HookJob, len(*in))
+		copy(*out, *in)
+	}
+	out.JobLogs = in.JobLogs
+	out.TeardownVerification = in.TeardownVerification
 	if in.Variables != nil {
 		in, out := &in.Variables, &out.Variables
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.VariablesFrom != nil {
+		in, out := &in.VariablesFrom, &out.VariablesFrom
+		*out = make([]v1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VariantRules != nil {
+		in, out := &in.VariantRules, &out.VariantRules
+		*out = make([]VariantRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.VariableOverrides.DeepCopyInto(&out.VariableOverrides)
+	out.OrphanSweeper = in.OrphanSweeper
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReviewAppManagerSpec.
@@ -442,6 +745,7 @@ func (in *ReviewAppManagerSpec) DeepCopy() *ReviewAppManagerSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReviewAppManagerSpecAppConfig) DeepCopyInto(out *ReviewAppManagerSpecAppConfig) {
 	*out = *in
+	out.ChatOps = in.ChatOps
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReviewAppManagerSpecAppConfig.
@@ -454,6 +758,21 @@ func (in *ReviewAppManagerSpecAppConfig) DeepCopy() *ReviewAppManagerSpecAppConf
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReviewAppManagerSpecAppConfigChatOps) DeepCopyInto(out *ReviewAppManagerSpecAppConfigChatOps) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReviewAppManagerSpecAppConfigChatOps.
+func (in *ReviewAppManagerSpecAppConfigChatOps) DeepCopy() *ReviewAppManagerSpecAppConfigChatOps {
+	if in == nil {
+		return nil
+	}
+	out := new(ReviewAppManagerSpecAppConfigChatOps)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReviewAppManagerSpecAppTarget) DeepCopyInto(out *ReviewAppManagerSpecAppTarget) {
 	*out = *in
@@ -552,6 +871,53 @@ func (in *ReviewAppManagerSpecInfraTarget) DeepCopy() *ReviewAppManagerSpecInfra
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReviewAppManagerSpecVariableOverrides) DeepCopyInto(out *ReviewAppManagerSpecVariableOverrides) {
+	*out = *in
+	if in.AllowedKeys != nil {
+		in, out := &in.AllowedKeys, &out.AllowedKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]ReviewAppManagerSpecVariableOverridesLabel, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReviewAppManagerSpecVariableOverrides.
+func (in *ReviewAppManagerSpecVariableOverrides) DeepCopy() *ReviewAppManagerSpecVariableOverrides {
+	if in == nil {
+		return nil
+	}
+	out := new(ReviewAppManagerSpecVariableOverrides)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReviewAppManagerSpecVariableOverridesLabel) DeepCopyInto(out *ReviewAppManagerSpecVariableOverridesLabel) {
+	*out = *in
+	if in.Variables != nil {
+		in, out := &in.Variables, &out.Variables
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReviewAppManagerSpecVariableOverridesLabel.
+func (in *ReviewAppManagerSpecVariableOverridesLabel) DeepCopy() *ReviewAppManagerSpecVariableOverridesLabel {
+	if in == nil {
+		return nil
+	}
+	out := new(ReviewAppManagerSpecVariableOverridesLabel)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReviewAppManagerStatus) DeepCopyInto(out *ReviewAppManagerStatus) {
 	*out = *in
@@ -560,6 +926,7 @@ func (in *ReviewAppManagerStatus) DeepCopyInto(out *ReviewAppManagerStatus) {
 		*out = make([]ReviewAppManagerStatusSyncedPullRequests, len(*in))
 		copy(*out, *in)
 	}
+	in.OrphanSweep.DeepCopyInto(&out.OrphanSweep)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReviewAppManagerStatus.
@@ -595,11 +962,38 @@ func (in *ReviewAppSpec) DeepCopyInto(out *ReviewAppSpec) {
 	in.InfraTarget.DeepCopyInto(&out.InfraTarget)
 	in.InfraConfig.DeepCopyInto(&out.InfraConfig)
 	out.PreStopJob = in.PreStopJob
+	out.PreStopJobPolicy = in.PreStopJobPolicy
+	if in.PostDeployJobs != nil {
+		in, out := &in.PostDeployJobs, &out.PostDeployJobs
+		*out = make([]HookJob, len(*in))
+		copy(*out, *in)
+	}
+	if in.PreDeployJobs != nil {
+		in, out := &in.PreDeployJobs, &out.PreDeployJobs
+		*out = make([]HookJob, len(*in))
+		copy(*out, *in)
+	}
+	out.JobLogs = in.JobLogs
+	out.TeardownVerification = in.TeardownVerification
 	if in.Variables != nil {
 		in, out := &in.Variables, &out.Variables
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.VariablesFrom != nil {
+		in, out := &in.VariablesFrom, &out.VariablesFrom
+		*out = make([]v1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VariantRules != nil {
+		in, out := &in.VariantRules, &out.VariantRules
+		*out = make([]VariantRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReviewAppSpec.
@@ -617,6 +1011,35 @@ func (in *ReviewAppStatus) DeepCopyInto(out *ReviewAppStatus) {
 	*out = *in
 	in.Sync.DeepCopyInto(&out.Sync)
 	in.ManifestsCache.DeepCopyInto(&out.ManifestsCache)
+	if in.ManifestsSources != nil {
+		in, out := &in.ManifestsSources, &out.ManifestsSources
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	in.InfraRepoFiles.DeepCopyInto(&out.InfraRepoFiles)
+	out.ChatOps = in.ChatOps
+	out.PreStop = in.PreStop
+	in.PostDeployJobs.DeepCopyInto(&out.PostDeployJobs)
+	in.PreDeployJobs.DeepCopyInto(&out.PreDeployJobs)
+	out.Teardown = in.Teardown
+	out.DryRun = in.DryRun
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReviewAppStatus.
@@ -664,3 +1087,53 @@ func (in *SyncStatus) DeepCopy() *SyncStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeardownStatus) DeepCopyInto(out *TeardownStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeardownStatus.
+func (in *TeardownStatus) DeepCopy() *TeardownStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TeardownStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeardownVerification) DeepCopyInto(out *TeardownVerification) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeardownVerification.
+func (in *TeardownVerification) DeepCopy() *TeardownVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(TeardownVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VariantRule) DeepCopyInto(out *VariantRule) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VariantRule.
+func (in *VariantRule) DeepCopy() *VariantRule {
+	if in == nil {
+		return nil
+	}
+	out := new(VariantRule)
+	in.DeepCopyInto(out)
+	return out
+}
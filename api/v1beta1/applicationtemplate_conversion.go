@@ -0,0 +1,23 @@
+package v1beta1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
+)
+
+// ConvertTo converts this ApplicationTemplate to the Hub version (v1alpha1)
+func (src *ApplicationTemplate) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.ApplicationTemplate)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = v1alpha1.ApplicationTemplateSpec(src.Spec)
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version
+func (dst *ApplicationTemplate) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.ApplicationTemplate)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = ApplicationTemplateSpec(src.Spec)
+	return nil
+}
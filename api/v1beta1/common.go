@@ -0,0 +1,11 @@
+package v1beta1
+
+// NamespacedName refers to the object in the namespace
+type NamespacedName struct {
+
+	// Namespace is namespace of the object
+	Namespace string `json:"namespace"`
+
+	// Name is name of the object
+	Name string `json:"name"`
+}
@@ -0,0 +1,306 @@
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
+)
+
+// v1alpha1 stores timestamps as RFC3339 string.
+
+func timeFromString(s string) *metav1.Time {
+	if s == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil
+	}
+	return &metav1.Time{Time: t}
+}
+
+func timeToString(t *metav1.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// rawTimestampsAnnotation is annotation that keeps timestamps of v1alpha1 that cannot be parsed as RFC3339,
+// so that they are not erased by round trip conversion (v1alpha1 -> v1beta1 -> v1alpha1).
+const rawTimestampsAnnotation = "dreamkast.cloudnativedays.jp/v1alpha1-raw-timestamps"
+
+// rawTimestamps is map from field path of v1alpha1 to the raw timestamp
+type rawTimestamps map[string]string
+
+// rawTimestampsFromAnnotation pops rawTimestampsAnnotation from meta
+func rawTimestampsFromAnnotation(meta *metav1.ObjectMeta) rawTimestamps {
+	r := rawTimestamps{}
+	value, ok := meta.Annotations[rawTimestampsAnnotation]
+	if !ok {
+		return r
+	}
+	_ = json.Unmarshal([]byte(value), &r)
+	annotations := make(map[string]string, len(meta.Annotations))
+	for k, v := range meta.Annotations {
+		if k != rawTimestampsAnnotation {
+			annotations[k] = v
+		}
+	}
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	meta.Annotations = annotations
+	return r
+}
+
+// annotate sets rawTimestampsAnnotation to meta if some timestamps cannot be parsed
+func (r rawTimestamps) annotate(meta *metav1.ObjectMeta) {
+	if len(r) == 0 {
+		return
+	}
+	value, err := json.Marshal(r)
+	if err != nil {
+		return
+	}
+	annotations := make(map[string]string, len(meta.Annotations)+1)
+	for k, v := range meta.Annotations {
+		annotations[k] = v
+	}
+	annotations[rawTimestampsAnnotation] = string(value)
+	meta.Annotations = annotations
+}
+
+func (r rawTimestamps) timeFromString(path, s string) *metav1.Time {
+	t := timeFromString(s)
+	if t == nil && s != "" {
+		r[path] = s
+	}
+	return t
+}
+
+func (r rawTimestamps) timeToString(path string, t *metav1.Time) string {
+	if t == nil {
+		return r[path]
+	}
+	return timeToString(t)
+}
+
+// v1alpha1 represents unspecified NamespacedName as zero value
+
+func namespacedNameFromV1alpha1(nn v1alpha1.NamespacedName) *NamespacedName {
+	if nn == (v1alpha1.NamespacedName{}) {
+		return nil
+	}
+	return &NamespacedName{Namespace: nn.Namespace, Name: nn.Name}
+}
+
+func namespacedNameToV1alpha1(nn *NamespacedName) v1alpha1.NamespacedName {
+	if nn == nil {
+		return v1alpha1.NamespacedName{}
+	}
+	return v1alpha1.NamespacedName(*nn)
+}
+
+func namespacedNamesFromV1alpha1(nns []v1alpha1.NamespacedName) []NamespacedName {
+	if nns == nil {
+		return nil
+	}
+	out := make([]NamespacedName, 0, len(nns))
+	for _, nn := range nns {
+		out = append(out, NamespacedName(nn))
+	}
+	return out
+}
+
+func namespacedNamesToV1alpha1(nns []NamespacedName) []v1alpha1.NamespacedName {
+	if nns == nil {
+		return nil
+	}
+	out := make([]v1alpha1.NamespacedName, 0, len(nns))
+	for _, nn := range nns {
+		out = append(out, v1alpha1.NamespacedName(nn))
+	}
+	return out
+}
+
+func variantRulesFromV1alpha1(rules []v1alpha1.VariantRule) []VariantRule {
+	if rules == nil {
+		return nil
+	}
+	out := make([]VariantRule, 0, len(rules))
+	for _, rule := range rules {
+		out = append(out, VariantRule(rule))
+	}
+	return out
+}
+
+func variantRulesToV1alpha1(rules []VariantRule) []v1alpha1.VariantRule {
+	if rules == nil {
+		return nil
+	}
+	out := make([]v1alpha1.VariantRule, 0, len(rules))
+	for _, rule := range rules {
+		out = append(out, v1alpha1.VariantRule(rule))
+	}
+	return out
+}
+
+func hookJobsFromV1alpha1(jobs []v1alpha1.HookJob) []HookJob {
+	if jobs == nil {
+		return nil
+	}
+	out := make([]HookJob, 0, len(jobs))
+	for _, job := range jobs {
+		out = append(out, HookJob(job))
+	}
+	return out
+}
+
+func hookJobsToV1alpha1(jobs []HookJob) []v1alpha1.HookJob {
+	if jobs == nil {
+		return nil
+	}
+	out := make([]v1alpha1.HookJob, 0, len(jobs))
+	for _, job := range jobs {
+		out = append(out, v1alpha1.HookJob(job))
+	}
+	return out
+}
+
+func hookJobsStatusFromV1alpha1(status v1alpha1.HookJobsStatus, path string, raw rawTimestamps) HookJobsStatus {
+	out := HookJobsStatus{
+		AppRepoCommitHash:   status.AppRepoCommitHash,
+		InfraRepoCommitHash: status.InfraRepoCommitHash,
+		Outputs:             status.Outputs,
+	}
+	for i, job := range status.Jobs {
+		jobPath := fmt.Sprintf("%s.jobs[%d]", path, i)
+		out.Jobs = append(out.Jobs, HookJobStatus{
+			JobTemplate:  job.JobTemplate,
+			JobName:      job.JobName,
+			JobNamespace: job.JobNamespace,
+			Phase:        HookJobPhase(job.Phase),
+			Message:      job.Message,
+			Logs:         job.Logs,
+			StartTime:    raw.timeFromString(jobPath+".startTimestamp", job.StartTimestamp),
+			Deadline:     raw.timeFromString(jobPath+".deadlineTimestamp", job.DeadlineTimestamp),
+		})
+	}
+	return out
+}
+
+func hookJobsStatusToV1alpha1(status HookJobsStatus, path string, raw rawTimestamps) v1alpha1.HookJobsStatus {
+	out := v1alpha1.HookJobsStatus{
+		AppRepoCommitHash:   status.AppRepoCommitHash,
+		InfraRepoCommitHash: status.InfraRepoCommitHash,
+		Outputs:             status.Outputs,
+	}
+	for i, job := range status.Jobs {
+		jobPath := fmt.Sprintf("%s.jobs[%d]", path, i)
+		out.Jobs = append(out.Jobs, v1alpha1.HookJobStatus{
+			JobTemplate:       job.JobTemplate,
+			JobName:           job.JobName,
+			JobNamespace:      job.JobNamespace,
+			Phase:             v1alpha1.HookJobPhase(job.Phase),
+			Message:           job.Message,
+			Logs:              job.Logs,
+			StartTimestamp:    raw.timeToString(jobPath+".startTimestamp", job.StartTime),
+			DeadlineTimestamp: raw.timeToString(jobPath+".deadlineTimestamp", job.Deadline),
+		})
+	}
+	return out
+}
+
+func teardownVerificationFromV1alpha1(verification v1alpha1.TeardownVerification) TeardownVerification {
+	return TeardownVerification(verification)
+}
+
+func (spec ReviewAppCommonSpec) teardownVerificationToV1alpha1() v1alpha1.TeardownVerification {
+	return v1alpha1.TeardownVerification(spec.TeardownVerification)
+}
+
+func jobLogsPolicyFromV1alpha1(policy v1alpha1.JobLogsPolicy) JobLogsPolicy {
+	return JobLogsPolicy(policy)
+}
+
+func (spec ReviewAppCommonSpec) jobLogsPolicyToV1alpha1() v1alpha1.JobLogsPolicy {
+	return v1alpha1.JobLogsPolicy(spec.JobLogs)
+}
+
+// commonSpecFromV1alpha1 converts fields shared by ReviewAppManagerSpec & ReviewAppSpec of v1alpha1
+func commonSpecFromV1alpha1(
+	appTarget v1alpha1.ReviewAppManagerSpecAppTarget,
+	appConfig v1alpha1.ReviewAppManagerSpecAppConfig,
+	infraTarget v1alpha1.ReviewAppManagerSpecInfraTarget,
+	infraConfig v1alpha1.ReviewAppManagerSpecInfraConfig,
+) ReviewAppCommonSpec {
+	return ReviewAppCommonSpec{
+		AppRepoTarget: AppRepoTarget(appTarget),
+		AppRepoConfig: AppRepoConfig{
+			Message:              appConfig.Message,
+			SendMessageEveryTime: appConfig.SendMessageEveryTime,
+			ChatOps:              ChatOpsConfig(appConfig.ChatOps),
+		},
+		InfraRepoTarget: InfraRepoTarget(infraTarget),
+		InfraRepoConfig: InfraRepoConfig{
+			Manifests: InfraRepoManifests{
+				Templates: namespacedNamesFromV1alpha1(infraConfig.Manifests.Templates),
+				Dirpath:   infraConfig.Manifests.Dirpath,
+			},
+			ArgoCDApp: InfraRepoArgoCDApp{
+				Template: NamespacedName(infraConfig.ArgoCDApp.Template),
+				Filepath: infraConfig.ArgoCDApp.Filepath,
+			},
+		},
+	}
+}
+
+func preStopJobPolicyFromV1alpha1(policy v1alpha1.PreStopJobPolicy) PreStopJobPolicy {
+	return PreStopJobPolicy{
+		TimeoutSeconds: policy.TimeoutSeconds,
+		FailurePolicy:  PreStopJobFailurePolicy(policy.FailurePolicy),
+		MaxRetries:     policy.MaxRetries,
+	}
+}
+
+func (spec ReviewAppCommonSpec) preStopJobPolicyToV1alpha1() v1alpha1.PreStopJobPolicy {
+	return v1alpha1.PreStopJobPolicy{
+		TimeoutSeconds: spec.PreStopJobPolicy.TimeoutSeconds,
+		FailurePolicy:  v1alpha1.PreStopJobFailurePolicy(spec.PreStopJobPolicy.FailurePolicy),
+		MaxRetries:     spec.PreStopJobPolicy.MaxRetries,
+	}
+}
+
+func (spec ReviewAppCommonSpec) appTargetToV1alpha1() v1alpha1.ReviewAppManagerSpecAppTarget {
+	return v1alpha1.ReviewAppManagerSpecAppTarget(spec.AppRepoTarget)
+}
+
+func (spec ReviewAppCommonSpec) appConfigToV1alpha1() v1alpha1.ReviewAppManagerSpecAppConfig {
+	return v1alpha1.ReviewAppManagerSpecAppConfig{
+		Message:              spec.AppRepoConfig.Message,
+		SendMessageEveryTime: spec.AppRepoConfig.SendMessageEveryTime,
+		ChatOps:              v1alpha1.ReviewAppManagerSpecAppConfigChatOps(spec.AppRepoConfig.ChatOps),
+	}
+}
+
+func (spec ReviewAppCommonSpec) infraTargetToV1alpha1() v1alpha1.ReviewAppManagerSpecInfraTarget {
+	return v1alpha1.ReviewAppManagerSpecInfraTarget(spec.InfraRepoTarget)
+}
+
+func (spec ReviewAppCommonSpec) infraConfigToV1alpha1() v1alpha1.ReviewAppManagerSpecInfraConfig {
+	return v1alpha1.ReviewAppManagerSpecInfraConfig{
+		Manifests: v1alpha1.ReviewAppManagerSpecInfraManifests{
+			Templates: namespacedNamesToV1alpha1(spec.InfraRepoConfig.Manifests.Templates),
+			Dirpath:   spec.InfraRepoConfig.Manifests.Dirpath,
+		},
+		ArgoCDApp: v1alpha1.ReviewAppManagerSpecInfraArgoCDApp{
+			Template: v1alpha1.NamespacedName(spec.InfraRepoConfig.ArgoCDApp.Template),
+			Filepath: spec.InfraRepoConfig.ArgoCDApp.Filepath,
+		},
+	}
+}
@@ -0,0 +1,279 @@
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+	webhookconversion "sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+
+	"github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
+)
+
+func TestConvertible(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	for _, obj := range []runtime.Object{
+		&v1alpha1.ReviewAppManager{},
+		&v1alpha1.ReviewApp{},
+		&v1alpha1.ApplicationTemplate{},
+		&v1alpha1.ManifestsTemplate{},
+		&v1alpha1.JobTemplate{},
+	} {
+		ok, err := webhookconversion.IsConvertible(scheme, obj)
+		if err != nil || !ok {
+			t.Errorf("%T is not convertible: %v", obj, err)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	meta := metav1.ObjectMeta{Name: "test", Namespace: "default", Annotations: map[string]string{"a": "b"}}
+	appTarget := v1alpha1.ReviewAppManagerSpecAppTarget{
+		Organization: "org", Repository: "app", Username: "user",
+		GitSecretRef:   &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "git"}, Key: "token"},
+		IgnoreLabels:   []string{"wip"},
+		IgnoreTitleExp: "^WIP",
+	}
+	appConfig := v1alpha1.ReviewAppManagerSpecAppConfig{
+		Message: "message", SendMessageEveryTime: true,
+		ChatOps: v1alpha1.ReviewAppManagerSpecAppConfigChatOps{Enabled: true, Lifetime: "3d"},
+	}
+	infraTarget := v1alpha1.ReviewAppManagerSpecInfraTarget{
+		Organization: "org", Repository: "infra", Username: "user", Branch: "main",
+	}
+	infraConfig := v1alpha1.ReviewAppManagerSpecInfraConfig{
+		Manifests: v1alpha1.ReviewAppManagerSpecInfraManifests{
+			Templates: []v1alpha1.NamespacedName{{Namespace: "default", Name: "mt"}},
+			Dirpath:   "overlays/{{.AppRepo.PrNumber}}",
+		},
+		ArgoCDApp: v1alpha1.ReviewAppManagerSpecInfraArgoCDApp{
+			Template: v1alpha1.NamespacedName{Namespace: "default", Name: "at"},
+			Filepath: "apps/{{.AppRepo.PrNumber}}.yaml",
+		},
+	}
+	variablesFrom := []corev1.EnvFromSource{{Prefix: "P_", ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "cm"}}}}
+	variantRules := []v1alpha1.VariantRule{{Variant: "candidate", Labels: []string{"candidate-template"}, BranchExp: "^feature/"}}
+
+	tests := []struct {
+		name  string
+		hub   conversion.Hub
+		spoke conversion.Convertible
+		empty conversion.Hub
+	}{
+		{
+			name: "ReviewAppManager",
+			hub: &v1alpha1.ReviewAppManager{
+				ObjectMeta: meta,
+				Spec: v1alpha1.ReviewAppManagerSpec{
+					AppTarget: appTarget, AppConfig: appConfig, InfraTarget: infraTarget, InfraConfig: infraConfig,
+					PreStopJob:           v1alpha1.NamespacedName{Namespace: "default", Name: "jt"},
+					PreStopJobPolicy:     v1alpha1.PreStopJobPolicy{TimeoutSeconds: 60, FailurePolicy: v1alpha1.PreStopJobFailurePolicyRetry, MaxRetries: 2},
+					PostDeployJobs:       []v1alpha1.HookJob{{Namespace: "default", Name: "smoke-test", TimeoutSeconds: 120}},
+					PreDeployJobs:        []v1alpha1.HookJob{{Namespace: "default", Name: "create-db", OutputConfigMap: "db-{{.AppRepo.PrNumber}}"}},
+					JobLogs:              v1alpha1.JobLogsPolicy{TailLines: 50, LimitBytes: 8192, PostToPullRequest: true},
+					TeardownVerification: v1alpha1.TeardownVerification{Enabled: true, Namespace: "demo-{{.AppRepo.PrNumber}}", TimeoutSeconds: 300},
+					Variables:            []string{"KEY=value"},
+					VariablesFrom:        variablesFrom,
+					VariantRules:         variantRules,
+					StrictTemplating:     true,
+					VariableOverrides: v1alpha1.ReviewAppManagerSpecVariableOverrides{
+						AllowedKeys:     []string{"KEY"},
+						Labels:          []v1alpha1.ReviewAppManagerSpecVariableOverridesLabel{{Name: "large", Variables: []string{"KEY=large"}}},
+						PullRequestBody: true,
+					},
+					Suspend:       true,
+					DryRun:        true,
+					OrphanSweeper: v1alpha1.OrphanSweeper{Enabled: true, IntervalSeconds: 600, DryRun: true},
+				},
+				Status: v1alpha1.ReviewAppManagerStatus{
+					Suspended:          true,
+					SyncedPullRequests: []v1alpha1.ReviewAppManagerStatusSyncedPullRequests{{Organization: "org", Repository: "app", Number: 1, ReviewAppName: "test-1"}},
+					OrphanSweep: v1alpha1.OrphanSweepStatus{
+						LastSweepTimestamp: "2022-01-07T00:00:00Z", Orphans: []string{"apps/2.yaml", "manifests/2"}, InfraRepoCommitHash: "ghi",
+					},
+				},
+			},
+			spoke: &ReviewAppManager{},
+			empty: &v1alpha1.ReviewAppManager{},
+		},
+		{
+			name: "ReviewApp",
+			hub: &v1alpha1.ReviewApp{
+				ObjectMeta: meta,
+				Spec: v1alpha1.ReviewAppSpec{
+					AppTarget: appTarget, AppConfig: appConfig, InfraTarget: infraTarget, InfraConfig: infraConfig,
+					Variables:            []string{"KEY=value"},
+					VariablesFrom:        variablesFrom,
+					VariantRules:         variantRules,
+					AppPrNum:             1,
+					TeardownVerification: v1alpha1.TeardownVerification{Enabled: true, TimeoutSeconds: 300},
+					DryRun:               true,
+				},
+				Status: v1alpha1.ReviewAppStatus{
+					Sync: v1alpha1.SyncStatus{
+						Status: v1alpha1.SyncStatusCodeUpdatedInfraRepo,
+						SyncedPullRequest: v1alpha1.ReviewAppStatusSyncedPullRequest{
+							Branch: "feature", BaseBranch: "main", LatestCommitHash: "abc", Title: "title",
+							Labels: []string{"candidate-template"}, Author: "user", URL: "https://example.com/pull/1",
+							CreatedAt: "2022-01-01T00:00:00Z", SyncTimestamp: "2022-01-02T00:00:00+09:00",
+						},
+						ApplicationName:           "app",
+						ApplicationNamespace:      "argocd",
+						AlreadySentMessage:        true,
+						InfraRepoLatestCommitHash: "def",
+					},
+					ManifestsCache:   v1alpha1.ManifestsCache{Application: "app", Manifests: map[string]string{"a.yaml": "a"}},
+					ManifestsSources: map[string][]string{"a.yaml": {"default/mt"}},
+					InfraRepoFiles:   v1alpha1.InfraRepoFiles{Paths: []string{"apps/1.yaml", "manifests/1/a.yaml"}, CommitHash: "def"},
+					ChatOps: v1alpha1.ChatOpsStatus{
+						Stopped: true, UseCandidate: true, ExpiresAt: "2022-01-04T00:00:00Z",
+						LastHandledCommentID: 10, LastHandledCommentTimestamp: "2022-01-03T00:00:00Z", SyncTimestamp: "2022-01-03T00:00:00Z",
+					},
+					PreStop: v1alpha1.PreStopStatus{
+						Phase: v1alpha1.PreStopPhaseFailed, JobName: "jt-abcde", JobNamespace: "default",
+						StartTimestamp: "2022-01-05T00:00:00Z", Retries: 1, Logs: "error: connection refused",
+					},
+					PostDeployJobs: v1alpha1.HookJobsStatus{
+						InfraRepoCommitHash: "def",
+						Jobs: []v1alpha1.HookJobStatus{{
+							JobTemplate: "default/smoke-test", JobName: "smoke-test-abcde", JobNamespace: "default",
+							Phase: v1alpha1.HookJobPhaseFailed, Message: "Job has reached the specified backoff limit", Logs: "FAIL: smoke test",
+							StartTimestamp: "2022-01-02T00:00:00Z", DeadlineTimestamp: "2022-01-02T00:05:00Z",
+						}},
+					},
+					PreDeployJobs: v1alpha1.HookJobsStatus{
+						AppRepoCommitHash: "abc",
+						Jobs: []v1alpha1.HookJobStatus{{
+							JobTemplate: "default/create-db", JobName: "create-db-abcde", JobNamespace: "default",
+							Phase: v1alpha1.HookJobPhaseSucceeded, StartTimestamp: "2022-01-02T00:00:00Z", DeadlineTimestamp: "2022-01-02T00:05:00Z",
+						}},
+						Outputs: map[string]string{"DB_NAME": "db_1"},
+					},
+					Teardown: v1alpha1.TeardownStatus{StartTimestamp: "2022-01-06T00:00:00Z", Namespace: "demo-1"},
+					DryRun: v1alpha1.DryRunStatus{
+						RenderedTimestamp: "2022-01-08T00:00:00Z", InfraRepoCommitHash: "jkl", Diff: "--- a/apps/1.yaml\n+++ b/apps/1.yaml\n",
+					},
+					Conditions: []metav1.Condition{{Type: v1alpha1.ReviewAppConditionTemplated, Status: metav1.ConditionTrue, Reason: "Templated"}},
+				},
+			},
+			spoke: &ReviewApp{},
+			empty: &v1alpha1.ReviewApp{},
+		},
+		{
+			name: "ApplicationTemplate",
+			hub: &v1alpha1.ApplicationTemplate{
+				ObjectMeta: meta,
+				Spec:       v1alpha1.ApplicationTemplateSpec{StableTemplate: "stable", CandidateTemplate: "candidate", Variants: map[string]string{"v": "v"}},
+			},
+			spoke: &ApplicationTemplate{},
+			empty: &v1alpha1.ApplicationTemplate{},
+		},
+		{
+			name: "ManifestsTemplate",
+			hub: &v1alpha1.ManifestsTemplate{
+				ObjectMeta: meta,
+				Spec: v1alpha1.ManifestsTemplateSpec{
+					StableData: map[string]string{"a.yaml": "a"}, CandidateData: map[string]string{"b.yaml": "b"},
+					Variants:      map[string]v1alpha1.ManifestsTemplateData{"v": {"c.yaml": "c"}},
+					Helm:          &v1alpha1.ManifestsTemplateSpecHelm{Chart: "chart", Repository: "https://example.com", Version: "1.0.0", StableValues: "a: b", VariantValues: map[string]string{"next": "a: c"}},
+					MergeStrategy: v1alpha1.MergeStrategyStrategicMerge,
+				},
+			},
+			spoke: &ManifestsTemplate{},
+			empty: &v1alpha1.ManifestsTemplate{},
+		},
+		{
+			name: "JobTemplate",
+			hub: &v1alpha1.JobTemplate{
+				ObjectMeta: meta,
+				Spec:       v1alpha1.JobTemplateSpec{StableTemplate: "stable", CandidateTemplate: "candidate", Variants: map[string]string{"v": "v"}, Template: "template"},
+			},
+			spoke: &JobTemplate{},
+			empty: &v1alpha1.JobTemplate{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.spoke.ConvertFrom(tt.hub); err != nil {
+				t.Fatal(err)
+			}
+			got := tt.empty
+			if err := tt.spoke.ConvertTo(got); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.hub, got); diff != "" {
+				t.Errorf("round trip mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRoundTrip_UnparseableTimestamps(t *testing.T) {
+	tests := []struct {
+		name  string
+		hub   conversion.Hub
+		spoke conversion.Convertible
+		empty conversion.Hub
+	}{
+		{
+			name: "ReviewAppManager",
+			hub: &v1alpha1.ReviewAppManager{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Status: v1alpha1.ReviewAppManagerStatus{
+					OrphanSweep: v1alpha1.OrphanSweepStatus{LastSweepTimestamp: "2022/01/07 00:00:00"},
+				},
+			},
+			spoke: &ReviewAppManager{},
+			empty: &v1alpha1.ReviewAppManager{},
+		},
+		{
+			name: "ReviewApp",
+			hub: &v1alpha1.ReviewApp{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", Annotations: map[string]string{"a": "b"}},
+				Status: v1alpha1.ReviewAppStatus{
+					Sync: v1alpha1.SyncStatus{
+						SyncedPullRequest: v1alpha1.ReviewAppStatusSyncedPullRequest{
+							CreatedAt: "2022-01-01 00:00:00 +0000 UTC", SyncTimestamp: "2022-01-02T00:00:00Z",
+						},
+					},
+					PreDeployJobs: v1alpha1.HookJobsStatus{
+						Jobs: []v1alpha1.HookJobStatus{
+							{JobTemplate: "default/a", StartTimestamp: "2022-01-02T00:00:00Z", DeadlineTimestamp: "2022-01-02T00:05:00Z"},
+							{JobTemplate: "default/b", StartTimestamp: "2022-01-02T00:00:00Z", DeadlineTimestamp: "unknown"},
+						},
+					},
+					Teardown: v1alpha1.TeardownStatus{StartTimestamp: "1641427200"},
+				},
+			},
+			spoke: &ReviewApp{},
+			empty: &v1alpha1.ReviewApp{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.spoke.ConvertFrom(tt.hub); err != nil {
+				t.Fatal(err)
+			}
+			meta := tt.spoke.(metav1.Object)
+			if _, ok := meta.GetAnnotations()[rawTimestampsAnnotation]; !ok {
+				t.Errorf("annotation %s is not set to converted object", rawTimestampsAnnotation)
+			}
+			got := tt.empty
+			if err := tt.spoke.ConvertTo(got); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.hub, got); diff != "" {
+				t.Errorf("round trip mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
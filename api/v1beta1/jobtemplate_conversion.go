@@ -0,0 +1,23 @@
+package v1beta1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
+)
+
+// ConvertTo converts this JobTemplate to the Hub version (v1alpha1)
+func (src *JobTemplate) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.JobTemplate)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = v1alpha1.JobTemplateSpec(src.Spec)
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version
+func (dst *JobTemplate) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.JobTemplate)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = JobTemplateSpec(src.Spec)
+	return nil
+}
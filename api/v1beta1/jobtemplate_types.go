@@ -0,0 +1,75 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// JobTemplateSpec defines the desired state of JobTemplate
+type JobTemplateSpec struct {
+
+	// CandidateTemplate is included Job manifest. (apiVersion, kind, metadata, spec, ...)
+	CandidateTemplate string `json:"candidate,omitempty"`
+
+	// StableTemplate is included Job manifest. (apiVersion, kind, metadata, spec, ...)
+	StableTemplate string `json:"stable,omitempty"`
+
+	// Variants is map of variant name to Job manifest.
+	// Variant is selected by spec.variantRules of ReviewAppManager.
+	// If the selected variant does not exist, Template is used if specified. Otherwise templating fails.
+	// +optional
+	Variants map[string]string `json:"variants,omitempty"`
+
+	// Template is included Job manifest. (apiVersion, kind, metadata, spec, ...)
+	// Deprecated: use StableTemplate & CandidateTemplate. Template is used only if they are empty.
+	// +optional
+	Template string `json:"template,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:shortName=jt
+
+// JobTemplate is the Schema for the jobtemplates API
+type JobTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec JobTemplateSpec `json:"spec,omitempty"`
+}
+
+func (JobTemplate) GVK() schema.GroupVersionKind {
+	return schema.GroupVersionKind{
+		Group:   GroupVersion.Group,
+		Version: GroupVersion.Version,
+		Kind:    "JobTemplate",
+	}
+}
+
+//+kubebuilder:object:root=true
+
+// JobTemplateList contains a list of JobTemplate
+type JobTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []JobTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&JobTemplate{}, &JobTemplateList{})
+}
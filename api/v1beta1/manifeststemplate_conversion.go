@@ -0,0 +1,51 @@
+package v1beta1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
+)
+
+// ConvertTo converts this ManifestsTemplate to the Hub version (v1alpha1)
+func (src *ManifestsTemplate) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.ManifestsTemplate)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = v1alpha1.ManifestsTemplateSpec{
+		CandidateData: src.Spec.CandidateData,
+		StableData:    src.Spec.StableData,
+		MergeStrategy: v1alpha1.MergeStrategy(src.Spec.MergeStrategy),
+	}
+	if src.Spec.Variants != nil {
+		dst.Spec.Variants = make(map[string]v1alpha1.ManifestsTemplateData)
+		for variant, data := range src.Spec.Variants {
+			dst.Spec.Variants[variant] = v1alpha1.ManifestsTemplateData(data)
+		}
+	}
+	if src.Spec.Helm != nil {
+		helm := v1alpha1.ManifestsTemplateSpecHelm(*src.Spec.Helm)
+		dst.Spec.Helm = &helm
+	}
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version
+func (dst *ManifestsTemplate) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.ManifestsTemplate)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = ManifestsTemplateSpec{
+		CandidateData: src.Spec.CandidateData,
+		StableData:    src.Spec.StableData,
+		MergeStrategy: MergeStrategy(src.Spec.MergeStrategy),
+	}
+	if src.Spec.Variants != nil {
+		dst.Spec.Variants = make(map[string]ManifestsTemplateData)
+		for variant, data := range src.Spec.Variants {
+			dst.Spec.Variants[variant] = ManifestsTemplateData(data)
+		}
+	}
+	if src.Spec.Helm != nil {
+		helm := ManifestsTemplateSpecHelm(*src.Spec.Helm)
+		dst.Spec.Helm = &helm
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ManifestsTemplateSpec defines the desired state of ManifestsTemplate
+type ManifestsTemplateSpec struct {
+	// CandidateData is field that be given various resources' manifest.
+	CandidateData map[string]string `json:"candidate,omitempty"`
+
+	// StableData is field that be given various resources' manifest.
+	StableData map[string]string `json:"stable,omitempty"`
+
+	// Variants is map of variant name to various resources' manifest.
+	// Variant is selected by spec.variantRules of ReviewAppManager.
+	// If the selected variant does not exist, templating fails unless this template has no data (e.g. only helm).
+	// +optional
+	Variants map[string]ManifestsTemplateData `json:"variants,omitempty"`
+
+	// Helm is field that be given reference to Helm chart and its values.
+	// Controller writes Chart.yaml depending on the chart and values.yaml to Dirpath,
+	// so Argo CD renders the chart. Keys of CandidateData, StableData & Variants must not be "Chart.yaml" or "values.yaml".
+	// +optional
+	Helm *ManifestsTemplateSpecHelm `json:"helm,omitempty"`
+
+	// MergeStrategy specifies how files of this template are merged into the same name files
+	// of templates listed before in spec.infraRepoConfig.manifests.templates of ReviewAppManager.
+	// +kubebuilder:default=Override
+	// +optional
+	MergeStrategy MergeStrategy `json:"mergeStrategy,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Override;ErrorOnConflict;StrategicMerge
+type MergeStrategy string
+
+const (
+	// MergeStrategyOverride overwrites the same name files
+	MergeStrategyOverride MergeStrategy = "Override"
+	// MergeStrategyErrorOnConflict fails to template if the same name files exist
+	MergeStrategyErrorOnConflict MergeStrategy = "ErrorOnConflict"
+	// MergeStrategyStrategicMerge merges resources of the same name files as YAML strategic merge.
+	// Resources are matched by apiVersion, kind & metadata.name, and unmatched ones are appended.
+	MergeStrategyStrategicMerge MergeStrategy = "StrategicMerge"
+)
+
+// ManifestsTemplateData is map of filename to resources' manifest
+type ManifestsTemplateData map[string]string
+
+type ManifestsTemplateSpecHelm struct {
+
+	// Chart is name of Helm chart
+	Chart string `json:"chart"`
+
+	// Repository is URL of chart repository that has packaged chart (e.g. https://charts.bitnami.com/bitnami).
+	// Either Repository or Path must be specified.
+	// +optional
+	Repository string `json:"repository,omitempty"`
+
+	// Version is version of packaged chart
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Path is path of local chart directory in Infra Repository (e.g. charts/myapp)
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// CandidateValues is values of the chart. It is templated as same as CandidateData.
+	// +optional
+	CandidateValues string `json:"candidateValues,omitempty"`
+
+	// StableValues is values of the chart. It is templated as same as StableData.
+	// +optional
+	StableValues string `json:"stableValues,omitempty"`
+
+	// VariantValues is map of variant name to values of the chart. It is templated as same as Variants.
+	// +optional
+	VariantValues map[string]string `json:"variantValues,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:shortName=mt
+
+// ManifestsTemplate is the Schema for the manifeststemplates API
+type ManifestsTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ManifestsTemplateSpec `json:"spec"`
+}
+
+func (ManifestsTemplate) GVK() schema.GroupVersionKind {
+	return schema.GroupVersionKind{
+		Group:   GroupVersion.Group,
+		Version: GroupVersion.Version,
+		Kind:    "ManifestsTemplate",
+	}
+}
+
+//+kubebuilder:object:root=true
+
+// ManifestsTemplateList contains a list of ManifestsTemplate
+type ManifestsTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ManifestsTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ManifestsTemplate{}, &ManifestsTemplateList{})
+}
@@ -0,0 +1,173 @@
+package v1beta1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
+)
+
+// ConvertTo converts this ReviewApp to the Hub version (v1alpha1)
+func (src *ReviewApp) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.ReviewApp)
+	dst.ObjectMeta = src.ObjectMeta
+	raw := rawTimestampsFromAnnotation(&dst.ObjectMeta)
+
+	spec := src.Spec
+	dst.Spec = v1alpha1.ReviewAppSpec{
+		AppTarget:            spec.appTargetToV1alpha1(),
+		AppConfig:            spec.appConfigToV1alpha1(),
+		InfraTarget:          spec.infraTargetToV1alpha1(),
+		InfraConfig:          spec.infraConfigToV1alpha1(),
+		PreStopJob:           namespacedNameToV1alpha1(spec.PreStopJob),
+		PreStopJobPolicy:     spec.preStopJobPolicyToV1alpha1(),
+		PostDeployJobs:       hookJobsToV1alpha1(spec.PostDeployJobs),
+		PreDeployJobs:        hookJobsToV1alpha1(spec.PreDeployJobs),
+		JobLogs:              spec.jobLogsPolicyToV1alpha1(),
+		TeardownVerification: spec.teardownVerificationToV1alpha1(),
+		Variables:            spec.Variables,
+		VariablesFrom:        spec.VariablesFrom,
+		VariantRules:         variantRulesToV1alpha1(spec.VariantRules),
+		StrictTemplating:     spec.StrictTemplating,
+		AppPrNum:             spec.AppRepoPrNum,
+		Suspend:              spec.Suspend,
+		DryRun:               spec.DryRun,
+	}
+
+	status := src.Status
+	pr := status.Sync.SyncedPullRequest
+	dst.Status = v1alpha1.ReviewAppStatus{
+		Suspended: status.Suspended,
+		Sync: v1alpha1.SyncStatus{
+			Status: v1alpha1.SyncStatusCode(status.Sync.Status),
+			SyncedPullRequest: v1alpha1.ReviewAppStatusSyncedPullRequest{
+				Branch:           pr.Branch,
+				BaseBranch:       pr.BaseBranch,
+				LatestCommitHash: pr.LatestCommitHash,
+				Title:            pr.Title,
+				Labels:           pr.Labels,
+				Author:           pr.Author,
+				URL:              pr.URL,
+				CreatedAt:        raw.timeToString("status.sync.syncedPullRequest.createdAt", pr.CreatedAt),
+				SyncTimestamp:    raw.timeToString("status.sync.syncedPullRequest.syncTimestamp", pr.SyncTimestamp),
+			},
+			ApplicationName:           status.Sync.ApplicationName,
+			ApplicationNamespace:      status.Sync.ApplicationNamespace,
+			AlreadySentMessage:        status.Sync.AlreadySentMessage,
+			InfraRepoLatestCommitHash: status.Sync.InfraRepoLatestCommitHash,
+		},
+		ManifestsCache:   v1alpha1.ManifestsCache(status.ManifestsCache),
+		ManifestsSources: status.ManifestsSources,
+		InfraRepoFiles:   v1alpha1.InfraRepoFiles(status.InfraRepoFiles),
+		ChatOps: v1alpha1.ChatOpsStatus{
+			Stopped:                     status.ChatOps.Stopped,
+			UseCandidate:                status.ChatOps.UseCandidate,
+			ExpiresAt:                   raw.timeToString("status.chatops.expiresAt", status.ChatOps.ExpiresAt),
+			LastHandledCommentID:        status.ChatOps.LastHandledCommentID,
+			LastHandledCommentTimestamp: raw.timeToString("status.chatops.lastHandledCommentTimestamp", status.ChatOps.LastHandledCommentTimestamp),
+			SyncTimestamp:               raw.timeToString("status.chatops.syncTimestamp", status.ChatOps.SyncTimestamp),
+		},
+		PreStop: v1alpha1.PreStopStatus{
+			Phase:          v1alpha1.PreStopPhase(status.PreStop.Phase),
+			JobName:        status.PreStop.JobName,
+			JobNamespace:   status.PreStop.JobNamespace,
+			StartTimestamp: raw.timeToString("status.preStop.startTimestamp", status.PreStop.StartTime),
+			Retries:        status.PreStop.Retries,
+			Logs:           status.PreStop.Logs,
+		},
+		PostDeployJobs: hookJobsStatusToV1alpha1(status.PostDeployJobs, "status.postDeployJobs", raw),
+		PreDeployJobs:  hookJobsStatusToV1alpha1(status.PreDeployJobs, "status.preDeployJobs", raw),
+		Teardown: v1alpha1.TeardownStatus{
+			StartTimestamp: raw.timeToString("status.teardown.startTimestamp", status.Teardown.StartTime),
+			Namespace:      status.Teardown.Namespace,
+		},
+		DryRun: v1alpha1.DryRunStatus{
+			RenderedTimestamp:   raw.timeToString("status.dryRun.renderedTimestamp", status.DryRun.RenderTime),
+			InfraRepoCommitHash: status.DryRun.InfraRepoCommitHash,
+			Diff:                status.DryRun.Diff,
+		},
+		Conditions: status.Conditions,
+	}
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version
+func (dst *ReviewApp) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.ReviewApp)
+	dst.ObjectMeta = src.ObjectMeta
+	raw := rawTimestamps{}
+
+	spec := src.Spec
+	dst.Spec = ReviewAppSpec{
+		ReviewAppCommonSpec: commonSpecFromV1alpha1(spec.AppTarget, spec.AppConfig, spec.InfraTarget, spec.InfraConfig),
+		AppRepoPrNum:        spec.AppPrNum,
+	}
+	dst.Spec.PreStopJob = namespacedNameFromV1alpha1(spec.PreStopJob)
+	dst.Spec.PreStopJobPolicy = preStopJobPolicyFromV1alpha1(spec.PreStopJobPolicy)
+	dst.Spec.PostDeployJobs = hookJobsFromV1alpha1(spec.PostDeployJobs)
+	dst.Spec.PreDeployJobs = hookJobsFromV1alpha1(spec.PreDeployJobs)
+	dst.Spec.JobLogs = jobLogsPolicyFromV1alpha1(spec.JobLogs)
+	dst.Spec.TeardownVerification = teardownVerificationFromV1alpha1(spec.TeardownVerification)
+	dst.Spec.Variables = spec.Variables
+	dst.Spec.VariablesFrom = spec.VariablesFrom
+	dst.Spec.VariantRules = variantRulesFromV1alpha1(spec.VariantRules)
+	dst.Spec.StrictTemplating = spec.StrictTemplating
+	dst.Spec.Suspend = spec.Suspend
+	dst.Spec.DryRun = spec.DryRun
+
+	status := src.Status
+	pr := status.Sync.SyncedPullRequest
+	dst.Status = ReviewAppStatus{
+		Suspended: status.Suspended,
+		Sync: SyncStatus{
+			Status: SyncStatusCode(status.Sync.Status),
+			SyncedPullRequest: ReviewAppStatusSyncedPullRequest{
+				Branch:           pr.Branch,
+				BaseBranch:       pr.BaseBranch,
+				LatestCommitHash: pr.LatestCommitHash,
+				Title:            pr.Title,
+				Labels:           pr.Labels,
+				Author:           pr.Author,
+				URL:              pr.URL,
+				CreatedAt:        raw.timeFromString("status.sync.syncedPullRequest.createdAt", pr.CreatedAt),
+				SyncTimestamp:    raw.timeFromString("status.sync.syncedPullRequest.syncTimestamp", pr.SyncTimestamp),
+			},
+			ApplicationName:           status.Sync.ApplicationName,
+			ApplicationNamespace:      status.Sync.ApplicationNamespace,
+			AlreadySentMessage:        status.Sync.AlreadySentMessage,
+			InfraRepoLatestCommitHash: status.Sync.InfraRepoLatestCommitHash,
+		},
+		ManifestsCache:   ManifestsCache(status.ManifestsCache),
+		ManifestsSources: status.ManifestsSources,
+		InfraRepoFiles:   InfraRepoFiles(status.InfraRepoFiles),
+		ChatOps: ChatOpsStatus{
+			Stopped:                     status.ChatOps.Stopped,
+			UseCandidate:                status.ChatOps.UseCandidate,
+			ExpiresAt:                   raw.timeFromString("status.chatops.expiresAt", status.ChatOps.ExpiresAt),
+			LastHandledCommentID:        status.ChatOps.LastHandledCommentID,
+			LastHandledCommentTimestamp: raw.timeFromString("status.chatops.lastHandledCommentTimestamp", status.ChatOps.LastHandledCommentTimestamp),
+			SyncTimestamp:               raw.timeFromString("status.chatops.syncTimestamp", status.ChatOps.SyncTimestamp),
+		},
+		PreStop: PreStopStatus{
+			Phase:        PreStopPhase(status.PreStop.Phase),
+			JobName:      status.PreStop.JobName,
+			JobNamespace: status.PreStop.JobNamespace,
+			StartTime:    raw.timeFromString("status.preStop.startTimestamp", status.PreStop.StartTimestamp),
+			Retries:      status.PreStop.Retries,
+			Logs:         status.PreStop.Logs,
+		},
+		PostDeployJobs: hookJobsStatusFromV1alpha1(status.PostDeployJobs, "status.postDeployJobs", raw),
+		PreDeployJobs:  hookJobsStatusFromV1alpha1(status.PreDeployJobs, "status.preDeployJobs", raw),
+		Teardown: TeardownStatus{
+			StartTime: raw.timeFromString("status.teardown.startTimestamp", status.Teardown.StartTimestamp),
+			Namespace: status.Teardown.Namespace,
+		},
+		DryRun: DryRunStatus{
+			RenderTime:          raw.timeFromString("status.dryRun.renderedTimestamp", status.DryRun.RenderedTimestamp),
+			InfraRepoCommitHash: status.DryRun.InfraRepoCommitHash,
+			Diff:                status.DryRun.Diff,
+		},
+		Conditions: status.Conditions,
+	}
+	raw.annotate(&dst.ObjectMeta)
+	return nil
+}
@@ -0,0 +1,419 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ReviewAppSpec defines the desired state of ReviewApp
+type ReviewAppSpec struct {
+	ReviewAppCommonSpec `json:",inline"`
+
+	// AppRepoPrNum is number of PR that this ReviewApp is created for
+	AppRepoPrNum int `json:"appRepoPrNum"`
+}
+
+// ReviewAppStatus defines the observed state of ReviewApp
+type ReviewAppStatus struct {
+
+	// Suspended is true if controller suspends reconciliation of this ReviewApp
+	// +optional
+	Suspended bool `json:"suspended,omitempty"`
+
+	// Sync is state of synchronization from PR to Infra Repository
+	// +optional
+	Sync SyncStatus `json:"sync,omitempty"`
+
+	// ManifestsCache is digests of manifests templated at last. It is used to detect updates of templates.
+	// +optional
+	ManifestsCache ManifestsCache `json:"manifestsCache,omitempty"`
+
+	// ManifestsSources is map of filename to ManifestsTemplates ("namespace/name") that contributed the file
+	// +optional
+	ManifestsSources map[string][]string `json:"manifestsSources,omitempty"`
+
+	// InfraRepoFiles is files written to Infra Repository at last. Files removed from templates are pruned by diffing against it.
+	// +optional
+	InfraRepoFiles InfraRepoFiles `json:"infraRepoFiles,omitempty"`
+
+	// ChatOps is state changed by commands written as comments to PR
+	// +optional
+	ChatOps ChatOpsStatus `json:"chatops,omitempty"`
+
+	// PreStop is state of the Job specified by spec.preStopJob. It is run when ReviewApp is being deleted.
+	// +optional
+	PreStop PreStopStatus `json:"preStop,omitempty"`
+
+	// PostDeployJobs is state of the Jobs specified by spec.postDeployJobs
+	// +optional
+	PostDeployJobs HookJobsStatus `json:"postDeployJobs,omitempty"`
+
+	// PreDeployJobs is state of the Jobs specified by spec.preDeployJobs
+	// +optional
+	PreDeployJobs HookJobsStatus `json:"preDeployJobs,omitempty"`
+
+	// Teardown is state of waiting for environment to be torn down. It is recorded when ReviewApp is being deleted.
+	// +optional
+	Teardown TeardownStatus `json:"teardown,omitempty"`
+
+	// DryRun is result of rendering in dry-run mode. Rendered Application & manifests are recorded to manifestsCache.
+	// +optional
+	DryRun DryRunStatus `json:"dryRun,omitempty"`
+
+	// Conditions represent the latest available observations of ReviewApp's state
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+const (
+	// ReviewAppConditionManifestsValid represents whether manifests templated from ManifestsTemplate
+	// were built & validated successfully before pushed to Infra Repository
+	ReviewAppConditionManifestsValid = "ManifestsValid"
+	// ReviewAppConditionTemplated represents whether ApplicationTemplate & ManifestsTemplate were templated successfully
+	ReviewAppConditionTemplated = "Templated"
+	// ReviewAppConditionPostDeployJobsSucceeded represents whether all Jobs specified by spec.postDeployJobs
+	// succeeded for the latest commit pushed to Infra Repository
+	ReviewAppConditionPostDeployJobsSucceeded = "PostDeployJobsSucceeded"
+	// ReviewAppConditionPreDeployJobsSucceeded represents whether all Jobs specified by spec.preDeployJobs
+	// succeeded for the latest commit of PR
+	ReviewAppConditionPreDeployJobsSucceeded = "PreDeployJobsSucceeded"
+)
+
+type SyncStatus struct {
+
+	// Status is phase of synchronization
+	// +optional
+	Status SyncStatusCode `json:"status,omitempty"`
+
+	// SyncedPullRequest is PR's state at the last synchronization
+	// +optional
+	SyncedPullRequest ReviewAppStatusSyncedPullRequest `json:"syncedPullRequest,omitempty"`
+
+	// ApplicationName is name of Argo CD Application pushed to Infra Repository
+	// +optional
+	ApplicationName string `json:"applicationName,omitempty"`
+
+	// ApplicationNamespace is namespace of Argo CD Application pushed to Infra Repository
+	// +optional
+	ApplicationNamespace string `json:"applicationNamespace,omitempty"`
+
+	// AlreadySentMessage is true if Message was commented to PR for the latest commit
+	// +optional
+	AlreadySentMessage bool `json:"alreadySentMessage,omitempty"`
+
+	// InfraRepoLatestCommitHash is hash of the commit that was pushed to Infra Repository at last
+	// +optional
+	InfraRepoLatestCommitHash string `json:"infraRepoLatestCommitHash,omitempty"`
+}
+
+type ReviewAppStatusSyncedPullRequest struct {
+
+	// Branch is branch name of PR
+	// +optional
+	Branch string `json:"branch,omitempty"`
+
+	// BaseBranch is branch name that PR will be merged into
+	// +optional
+	BaseBranch string `json:"baseBranch,omitempty"`
+
+	// LatestCommitHash is hash of the latest commit of PR
+	// +optional
+	LatestCommitHash string `json:"latestCommitHash,omitempty"`
+
+	// Title is title of PR
+	// +optional
+	Title string `json:"title,omitempty"`
+
+	// Labels is list of PR's labels
+	// +optional
+	Labels []string `json:"labels,omitempty"`
+
+	// Author is login name of the user who opened PR
+	// +optional
+	Author string `json:"author,omitempty"`
+
+	// URL is URL of PR
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// CreatedAt is timestamp when PR was opened
+	// +optional
+	CreatedAt *metav1.Time `json:"createdAt,omitempty"`
+
+	// SyncTimestamp is timestamp when PR was read at last
+	// +optional
+	SyncTimestamp *metav1.Time `json:"syncTimestamp,omitempty"`
+}
+
+type ManifestsCache struct {
+
+	// Application is manifest of Argo CD Application
+	// Deprecated: ApplicationDigest is recorded instead not to store values from Secrets in status.
+	// +optional
+	Application string `json:"application,omitempty"`
+
+	// Manifests is map of filename to manifests
+	// Deprecated: ManifestsDigests is recorded instead not to store values from Secrets in status.
+	// +optional
+	Manifests map[string]string `json:"manifests,omitempty"`
+
+	// ApplicationDigest is digest of manifest of Argo CD Application
+	// +optional
+	ApplicationDigest string `json:"applicationDigest,omitempty"`
+
+	// ManifestsDigests is map of filename to digest of manifests
+	// +optional
+	ManifestsDigests map[string]string `json:"manifestsDigests,omitempty"`
+}
+
+type InfraRepoFiles struct {
+
+	// Paths is list of paths (relative to root of Infra Repository) of Application & other manifests
+	// +optional
+	Paths []string `json:"paths,omitempty"`
+
+	// CommitHash is commit hash of Infra Repository that the files were written by
+	// +optional
+	CommitHash string `json:"commitHash,omitempty"`
+}
+
+type ChatOpsStatus struct {
+
+	// Stopped is flag. ReviewApp's manifests are removed from Infra Repository while flag is true.
+	// +optional
+	Stopped bool `json:"stopped,omitempty"`
+
+	// UseCandidate is flag. Candidate templates are used even if PR does not have candidate label while flag is true.
+	// +optional
+	UseCandidate bool `json:"useCandidate,omitempty"`
+
+	// ExpiresAt is timestamp when ReviewApp is stopped automatically
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+
+	// LastHandledCommentID is ID of the latest comment that was handled as command
+	// +optional
+	LastHandledCommentID int64 `json:"lastHandledCommentId,omitempty"`
+
+	// LastHandledCommentTimestamp is created timestamp of the latest comment that was handled as command
+	// +optional
+	LastHandledCommentTimestamp *metav1.Time `json:"lastHandledCommentTimestamp,omitempty"`
+
+	// SyncTimestamp is timestamp when comments of PR were read
+	// +optional
+	SyncTimestamp *metav1.Time `json:"syncTimestamp,omitempty"`
+}
+
+// HookJobsStatus is state of hook Jobs run for a commit pushed to Infra Repository
+type HookJobsStatus struct {
+
+	// AppRepoCommitHash is hash of the commit of App Repository for which the Jobs were run
+	// +optional
+	AppRepoCommitHash string `json:"appRepoCommitHash,omitempty"`
+
+	// InfraRepoCommitHash is hash of the commit of Infra Repository for which the Jobs were run
+	// +optional
+	InfraRepoCommitHash string `json:"infraRepoCommitHash,omitempty"`
+
+	// Jobs is state of each Job
+	// +optional
+	Jobs []HookJobStatus `json:"jobs,omitempty"`
+
+	// Outputs is data of ConfigMaps written by the Jobs. It is available as Variables in templates.
+	// +optional
+	Outputs map[string]string `json:"outputs,omitempty"`
+}
+
+type HookJobStatus struct {
+
+	// JobTemplate is "namespace/name" of JobTemplate from which the Job was generated
+	JobTemplate string `json:"jobTemplate"`
+
+	// JobName is name of the Job
+	// +optional
+	JobName string `json:"jobName,omitempty"`
+
+	// JobNamespace is namespace of the Job
+	// +optional
+	JobNamespace string `json:"jobNamespace,omitempty"`
+
+	// Phase is phase of the Job
+	Phase HookJobPhase `json:"phase"`
+
+	// Message is human readable reason of Phase
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Logs is tail of logs of the Job when it failed or timed out
+	// +optional
+	Logs string `json:"logs,omitempty"`
+
+	// StartTime is time when the Job was created
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// Deadline is time when the Job is regarded as timed out
+	// +optional
+	Deadline *metav1.Time `json:"deadline,omitempty"`
+}
+
+// HookJobPhase is phase of a hook Job
+// +kubebuilder:validation:Enum=Running;Succeeded;Failed;TimedOut
+type HookJobPhase string
+
+const (
+	// HookJobPhaseRunning indicates that the Job has been created and controller is waiting for it
+	HookJobPhaseRunning HookJobPhase = "Running"
+	// HookJobPhaseSucceeded indicates that the Job succeeded
+	HookJobPhaseSucceeded HookJobPhase = "Succeeded"
+	// HookJobPhaseFailed indicates that the Job failed or could not be created
+	HookJobPhaseFailed HookJobPhase = "Failed"
+	// HookJobPhaseTimedOut indicates that the Job did not finish within its timeoutSeconds
+	HookJobPhaseTimedOut HookJobPhase = "TimedOut"
+)
+
+type PreStopStatus struct {
+
+	// Phase is phase of the preStop Job
+	// +optional
+	Phase PreStopPhase `json:"phase,omitempty"`
+
+	// JobName is name of the preStop Job created at last
+	// +optional
+	JobName string `json:"jobName,omitempty"`
+
+	// JobNamespace is namespace of the preStop Job created at last
+	// +optional
+	JobNamespace string `json:"jobNamespace,omitempty"`
+
+	// StartTime is time when the preStop Job was created at last
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// Retries is number of the preStop Jobs created again by FailurePolicy "Retry"
+	// +optional
+	Retries int32 `json:"retries,omitempty"`
+
+	// Logs is tail of logs of the preStop Job when it failed or timed out
+	// +optional
+	Logs string `json:"logs,omitempty"`
+}
+
+type TeardownStatus struct {
+
+	// StartTime is time when manifests were deleted from Infra Repository and controller started waiting
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// Namespace is templated spec.teardownVerification.namespace that controller waits to be deleted
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+type DryRunStatus struct {
+
+	// RenderTime is time when Application & manifests were rendered at last
+	// +optional
+	RenderTime *metav1.Time `json:"renderTime,omitempty"`
+
+	// InfraRepoCommitHash is commit hash of Infra Repository that Diff was taken against
+	// +optional
+	InfraRepoCommitHash string `json:"infraRepoCommitHash,omitempty"`
+
+	// Diff is unified diff of Infra Repository which would be pushed if dry-run mode were disabled
+	// +optional
+	Diff string `json:"diff,omitempty"`
+}
+
+// PreStopPhase is phase of the preStop Job
+// +kubebuilder:validation:Enum=Running;Succeeded;Failed;TimedOut
+type PreStopPhase string
+
+const (
+	// PreStopPhaseRunning indicates that the preStop Job has been created and controller is waiting for it
+	PreStopPhaseRunning PreStopPhase = "Running"
+	// PreStopPhaseSucceeded indicates that the preStop Job succeeded
+	PreStopPhaseSucceeded PreStopPhase = "Succeeded"
+	// PreStopPhaseFailed indicates that the preStop Job failed or was not found
+	PreStopPhaseFailed PreStopPhase = "Failed"
+	// PreStopPhaseTimedOut indicates that the preStop Job did not finish within spec.preStopJobPolicy.timeoutSeconds
+	PreStopPhaseTimedOut PreStopPhase = "TimedOut"
+)
+
+// SyncStatusCode is phase of synchronization from PR to Infra Repository
+// +kubebuilder:validation:Enum=Unknown;Initialize;WatchingAppRepoAndTemplates;NeedToUpdateInfraRepo;UpdatedInfraRepo;Stopped
+type SyncStatusCode string
+
+const (
+	// SyncStatusCodeUnknown indicates that the status of a sync could not be reliably determined
+	SyncStatusCodeUnknown SyncStatusCode = "Unknown"
+	// SyncStatusCodeInitialize indicates that ReviewApp is created now
+	SyncStatusCodeInitialize SyncStatusCode = "Initialize"
+	// SyncStatusCodeWatchingAppRepoAndTemplates indicates that neither PR nor templates are updated
+	SyncStatusCodeWatchingAppRepoAndTemplates SyncStatusCode = "WatchingAppRepoAndTemplates"
+	// SyncStatusCodeNeedToUpdateInfraRepo indicates that PR or templates were updated. Controller will push manifests to Infra Repository.
+	SyncStatusCodeNeedToUpdateInfraRepo SyncStatusCode = "NeedToUpdateInfraRepo"
+	// SyncStatusCodeUpdatedInfraRepo indicates that manifests were pushed to Infra Repository. Controller is waiting Argo CD Application updated.
+	SyncStatusCodeUpdatedInfraRepo SyncStatusCode = "UpdatedInfraRepo"
+	// SyncStatusCodeStopped indicates that manifests were removed from Infra Repository by "/reviewapp stop" command or expiration
+	SyncStatusCodeStopped SyncStatusCode = "Stopped"
+)
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:shortName=ra
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="app_organization",type="string",JSONPath=".spec.appRepoTarget.organization",description="Name of Application Repository's Organization"
+//+kubebuilder:printcolumn:name="app_repository",type="string",JSONPath=".spec.appRepoTarget.repository",description="Name of Application Repository"
+//+kubebuilder:printcolumn:name="app_pr_num",type="integer",JSONPath=".spec.appRepoPrNum",description="Number of Application Repository's PullRequest"
+//+kubebuilder:printcolumn:name="infra_organization",type="string",JSONPath=".spec.infraRepoTarget.organization",description="Name of Infra Repository's Organization"
+//+kubebuilder:printcolumn:name="infra_repository",type="string",JSONPath=".spec.infraRepoTarget.repository",description="Name of Infra Repository"
+//+kubebuilder:printcolumn:name="status",type="string",JSONPath=".status.sync.status",description="Phase of synchronization"
+//+kubebuilder:printcolumn:name="suspended",type="boolean",JSONPath=".status.suspended",description="Whether reconciliation is suspended"
+
+// ReviewApp is the Schema for the reviewapp API
+type ReviewApp struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ReviewAppSpec   `json:"spec,omitempty"`
+	Status ReviewAppStatus `json:"status,omitempty"`
+}
+
+func (ReviewApp) GVK() schema.GroupVersionKind {
+	return schema.GroupVersionKind{
+		Group:   GroupVersion.Group,
+		Version: GroupVersion.Version,
+		Kind:    "ReviewApp",
+	}
+}
+
+//+kubebuilder:object:root=true
+
+// ReviewAppList contains a list of ReviewApp
+type ReviewAppList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ReviewApp `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ReviewApp{}, &ReviewAppList{})
+}
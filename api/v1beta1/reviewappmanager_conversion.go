@@ -0,0 +1,115 @@
+package v1beta1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
+)
+
+// ConvertTo converts this ReviewAppManager to the Hub version (v1alpha1)
+func (src *ReviewAppManager) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.ReviewAppManager)
+	dst.ObjectMeta = src.ObjectMeta
+	raw := rawTimestampsFromAnnotation(&dst.ObjectMeta)
+
+	spec := src.Spec
+	dst.Spec = v1alpha1.ReviewAppManagerSpec{
+		AppTarget:            spec.appTargetToV1alpha1(),
+		AppConfig:            spec.appConfigToV1alpha1(),
+		InfraTarget:          spec.infraTargetToV1alpha1(),
+		InfraConfig:          spec.infraConfigToV1alpha1(),
+		PreStopJob:           namespacedNameToV1alpha1(spec.PreStopJob),
+		PreStopJobPolicy:     spec.preStopJobPolicyToV1alpha1(),
+		PostDeployJobs:       hookJobsToV1alpha1(spec.PostDeployJobs),
+		PreDeployJobs:        hookJobsToV1alpha1(spec.PreDeployJobs),
+		JobLogs:              spec.jobLogsPolicyToV1alpha1(),
+		TeardownVerification: spec.teardownVerificationToV1alpha1(),
+		Variables:            spec.Variables,
+		VariablesFrom:        spec.VariablesFrom,
+		VariantRules:         variantRulesToV1alpha1(spec.VariantRules),
+		StrictTemplating:     spec.StrictTemplating,
+		Suspend:              spec.Suspend,
+		DryRun:               spec.DryRun,
+		OrphanSweeper:        v1alpha1.OrphanSweeper(spec.OrphanSweeper),
+		VariableOverrides: v1alpha1.ReviewAppManagerSpecVariableOverrides{
+			AllowedKeys:     spec.VariableOverrides.AllowedKeys,
+			PullRequestBody: spec.VariableOverrides.PullRequestBody,
+		},
+	}
+	if spec.VariableOverrides.Labels != nil {
+		dst.Spec.VariableOverrides.Labels = []v1alpha1.ReviewAppManagerSpecVariableOverridesLabel{}
+		for _, label := range spec.VariableOverrides.Labels {
+			dst.Spec.VariableOverrides.Labels = append(dst.Spec.VariableOverrides.Labels,
+				v1alpha1.ReviewAppManagerSpecVariableOverridesLabel(label))
+		}
+	}
+
+	dst.Status = v1alpha1.ReviewAppManagerStatus{
+		Suspended: src.Status.Suspended,
+		OrphanSweep: v1alpha1.OrphanSweepStatus{
+			LastSweepTimestamp:  raw.timeToString("status.orphanSweep.lastSweepTimestamp", src.Status.OrphanSweep.LastSweepTime),
+			Orphans:             src.Status.OrphanSweep.Orphans,
+			InfraRepoCommitHash: src.Status.OrphanSweep.InfraRepoCommitHash,
+		},
+	}
+	if src.Status.SyncedPullRequests != nil {
+		dst.Status.SyncedPullRequests = []v1alpha1.ReviewAppManagerStatusSyncedPullRequests{}
+		for _, pr := range src.Status.SyncedPullRequests {
+			dst.Status.SyncedPullRequests = append(dst.Status.SyncedPullRequests,
+				v1alpha1.ReviewAppManagerStatusSyncedPullRequests(pr))
+		}
+	}
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version
+func (dst *ReviewAppManager) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.ReviewAppManager)
+	dst.ObjectMeta = src.ObjectMeta
+	raw := rawTimestamps{}
+
+	spec := src.Spec
+	dst.Spec = ReviewAppManagerSpec{
+		ReviewAppCommonSpec: commonSpecFromV1alpha1(spec.AppTarget, spec.AppConfig, spec.InfraTarget, spec.InfraConfig),
+		VariableOverrides: VariableOverrides{
+			AllowedKeys:     spec.VariableOverrides.AllowedKeys,
+			PullRequestBody: spec.VariableOverrides.PullRequestBody,
+		},
+		OrphanSweeper: OrphanSweeper(spec.OrphanSweeper),
+	}
+	dst.Spec.PreStopJob = namespacedNameFromV1alpha1(spec.PreStopJob)
+	dst.Spec.PreStopJobPolicy = preStopJobPolicyFromV1alpha1(spec.PreStopJobPolicy)
+	dst.Spec.PostDeployJobs = hookJobsFromV1alpha1(spec.PostDeployJobs)
+	dst.Spec.PreDeployJobs = hookJobsFromV1alpha1(spec.PreDeployJobs)
+	dst.Spec.JobLogs = jobLogsPolicyFromV1alpha1(spec.JobLogs)
+	dst.Spec.TeardownVerification = teardownVerificationFromV1alpha1(spec.TeardownVerification)
+	dst.Spec.Variables = spec.Variables
+	dst.Spec.VariablesFrom = spec.VariablesFrom
+	dst.Spec.VariantRules = variantRulesFromV1alpha1(spec.VariantRules)
+	dst.Spec.StrictTemplating = spec.StrictTemplating
+	dst.Spec.Suspend = spec.Suspend
+	dst.Spec.DryRun = spec.DryRun
+	if spec.VariableOverrides.Labels != nil {
+		dst.Spec.VariableOverrides.Labels = []VariableOverridesLabel{}
+		for _, label := range spec.VariableOverrides.Labels {
+			dst.Spec.VariableOverrides.Labels = append(dst.Spec.VariableOverrides.Labels, VariableOverridesLabel(label))
+		}
+	}
+
+	dst.Status = ReviewAppManagerStatus{
+		Suspended: src.Status.Suspended,
+		OrphanSweep: OrphanSweepStatus{
+			LastSweepTime:       raw.timeFromString("status.orphanSweep.lastSweepTimestamp", src.Status.OrphanSweep.LastSweepTimestamp),
+			Orphans:             src.Status.OrphanSweep.Orphans,
+			InfraRepoCommitHash: src.Status.OrphanSweep.InfraRepoCommitHash,
+		},
+	}
+	if src.Status.SyncedPullRequests != nil {
+		dst.Status.SyncedPullRequests = []SyncedPullRequest{}
+		for _, pr := range src.Status.SyncedPullRequests {
+			dst.Status.SyncedPullRequests = append(dst.Status.SyncedPullRequests, SyncedPullRequest(pr))
+		}
+	}
+	raw.annotate(&dst.ObjectMeta)
+	return nil
+}
@@ -0,0 +1,1169 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppRepoConfig) DeepCopyInto(out *AppRepoConfig) {
+	*out = *in
+	out.ChatOps = in.ChatOps
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppRepoConfig.
+func (in *AppRepoConfig) DeepCopy() *AppRepoConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AppRepoConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppRepoTarget) DeepCopyInto(out *AppRepoTarget) {
+	*out = *in
+	if in.GitSecretRef != nil {
+		in, out := &in.GitSecretRef, &out.GitSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IgnoreLabels != nil {
+		in, out := &in.IgnoreLabels, &out.IgnoreLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppRepoTarget.
+func (in *AppRepoTarget) DeepCopy() *AppRepoTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(AppRepoTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationTemplate) DeepCopyInto(out *ApplicationTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationTemplate.
+func (in *ApplicationTemplate) DeepCopy() *ApplicationTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ApplicationTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationTemplateList) DeepCopyInto(out *ApplicationTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ApplicationTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationTemplateList.
+func (in *ApplicationTemplateList) DeepCopy() *ApplicationTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ApplicationTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationTemplateSpec) DeepCopyInto(out *ApplicationTemplateSpec) {
+	*out = *in
+	if in.Variants != nil {
+		in, out := &in.Variants, &out.Variants
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationTemplateSpec.
+func (in *ApplicationTemplateSpec) DeepCopy() *ApplicationTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChatOpsConfig) DeepCopyInto(out *ChatOpsConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChatOpsConfig.
+func (in *ChatOpsConfig) DeepCopy() *ChatOpsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ChatOpsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChatOpsStatus) DeepCopyInto(out *ChatOpsStatus) {
+	*out = *in
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	if in.LastHandledCommentTimestamp != nil {
+		in, out := &in.LastHandledCommentTimestamp, &out.LastHandledCommentTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.SyncTimestamp != nil {
+		in, out := &in.SyncTimestamp, &out.SyncTimestamp
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChatOpsStatus.
+func (in *ChatOpsStatus) DeepCopy() *ChatOpsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ChatOpsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DryRunStatus) DeepCopyInto(out *DryRunStatus) {
+	*out = *in
+	if in.RenderTime != nil {
+		in, out := &in.RenderTime, &out.RenderTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DryRunStatus.
+func (in *DryRunStatus) DeepCopy() *DryRunStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DryRunStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HookJob) DeepCopyInto(out *HookJob) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HookJob.
+func (in *HookJob) DeepCopy() *HookJob {
+	if in == nil {
+		return nil
+	}
+	out := new(HookJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HookJobStatus) DeepCopyInto(out *HookJobStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Deadline != nil {
+		in, out := &in.Deadline, &out.Deadline
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HookJobStatus.
+func (in *HookJobStatus) DeepCopy() *HookJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HookJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HookJobsStatus) DeepCopyInto(out *HookJobsStatus) {
+	*out = *in
+	if in.Jobs != nil {
+		in, out := &in.Jobs, &out.Jobs
+		*out = make([]HookJobStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HookJobsStatus.
+func (in *HookJobsStatus) DeepCopy() *HookJobsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HookJobsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfraRepoArgoCDApp) DeepCopyInto(out *InfraRepoArgoCDApp) {
+	*out = *in
+	out.Template = in.Template
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfraRepoArgoCDApp.
+func (in *InfraRepoArgoCDApp) DeepCopy() *InfraRepoArgoCDApp {
+	if in == nil {
+		return nil
+	}
+	out := new(InfraRepoArgoCDApp)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfraRepoConfig) DeepCopyInto(out *InfraRepoConfig) {
+	*out = *in
+	in.Manifests.DeepCopyInto(&out.Manifests)
+	out.ArgoCDApp = in.ArgoCDApp
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfraRepoConfig.
+func (in *InfraRepoConfig) DeepCopy() *InfraRepoConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(InfraRepoConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfraRepoFiles) DeepCopyInto(out *InfraRepoFiles) {
+	*out = *in
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfraRepoFiles.
+func (in *InfraRepoFiles) DeepCopy() *InfraRepoFiles {
+	if in == nil {
+		return nil
+	}
+	out := new(InfraRepoFiles)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfraRepoManifests) DeepCopyInto(out *InfraRepoManifests) {
+	*out = *in
+	if in.Templates != nil {
+		in, out := &in.Templates, &out.Templates
+		*out = make([]NamespacedName, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfraRepoManifests.
+func (in *InfraRepoManifests) DeepCopy() *InfraRepoManifests {
+	if in == nil {
+		return nil
+	}
+	out := new(InfraRepoManifests)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InfraRepoTarget) DeepCopyInto(out *InfraRepoTarget) {
+	*out = *in
+	if in.GitSecretRef != nil {
+		in, out := &in.GitSecretRef, &out.GitSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InfraRepoTarget.
+func (in *InfraRepoTarget) DeepCopy() *InfraRepoTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(InfraRepoTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobLogsPolicy) DeepCopyInto(out *JobLogsPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobLogsPolicy.
+func (in *JobLogsPolicy) DeepCopy() *JobLogsPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(JobLogsPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobTemplate) DeepCopyInto(out *JobTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobTemplate.
+func (in *JobTemplate) DeepCopy() *JobTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(JobTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JobTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobTemplateList) DeepCopyInto(out *JobTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]JobTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobTemplateList.
+func (in *JobTemplateList) DeepCopy() *JobTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(JobTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JobTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobTemplateSpec) DeepCopyInto(out *JobTemplateSpec) {
+	*out = *in
+	if in.Variants != nil {
+		in, out := &in.Variants, &out.Variants
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobTemplateSpec.
+func (in *JobTemplateSpec) DeepCopy() *JobTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(JobTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManifestsCache) DeepCopyInto(out *ManifestsCache) {
+	*out = *in
+	if in.Manifests != nil {
+		in, out := &in.Manifests, &out.Manifests
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ManifestsDigests != nil {
+		in, out := &in.ManifestsDigests, &out.ManifestsDigests
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestsCache.
+func (in *ManifestsCache) DeepCopy() *ManifestsCache {
+	if in == nil {
+		return nil
+	}
+	out := new(ManifestsCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManifestsTemplate) DeepCopyInto(out *ManifestsTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestsTemplate.
+func (in *ManifestsTemplate) DeepCopy() *ManifestsTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ManifestsTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ManifestsTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in ManifestsTemplateData) DeepCopyInto(out *ManifestsTemplateData) {
+	{
+		in := &in
+		*out = make(ManifestsTemplateData, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestsTemplateData.
+func (in ManifestsTemplateData) DeepCopy() ManifestsTemplateData {
+	if in == nil {
+		return nil
+	}
+	out := new(ManifestsTemplateData)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManifestsTemplateList) DeepCopyInto(out *ManifestsTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ManifestsTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestsTemplateList.
+func (in *ManifestsTemplateList) DeepCopy() *ManifestsTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(ManifestsTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ManifestsTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManifestsTemplateSpec) DeepCopyInto(out *ManifestsTemplateSpec) {
+	*out = *in
+	if in.CandidateData != nil {
+		in, out := &in.CandidateData, &out.CandidateData
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.StableData != nil {
+		in, out := &in.StableData, &out.StableData
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Variants != nil {
+		in, out := &in.Variants, &out.Variants
+		*out = make(map[string]ManifestsTemplateData, len(*in))
+		for key, val := range *in {
+			var outVal map[string]string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make(ManifestsTemplateData, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Helm != nil {
+		in, out := &in.Helm, &out.Helm
+		*out = new(ManifestsTemplateSpecHelm)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestsTemplateSpec.
+func (in *ManifestsTemplateSpec) DeepCopy() *ManifestsTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ManifestsTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManifestsTemplateSpecHelm) DeepCopyInto(out *ManifestsTemplateSpecHelm) {
+	*out = *in
+	if in.VariantValues != nil {
+		in, out := &in.VariantValues, &out.VariantValues
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestsTemplateSpecHelm.
+func (in *ManifestsTemplateSpecHelm) DeepCopy() *ManifestsTemplateSpecHelm {
+	if in == nil {
+		return nil
+	}
+	out := new(ManifestsTemplateSpecHelm)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespacedName) DeepCopyInto(out *NamespacedName) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacedName.
+func (in *NamespacedName) DeepCopy() *NamespacedName {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacedName)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrphanSweepStatus) DeepCopyInto(out *OrphanSweepStatus) {
+	*out = *in
+	if in.LastSweepTime != nil {
+		in, out := &in.LastSweepTime, &out.LastSweepTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Orphans != nil {
+		in, out := &in.Orphans, &out.Orphans
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrphanSweepStatus.
+func (in *OrphanSweepStatus) DeepCopy() *OrphanSweepStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OrphanSweepStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrphanSweeper) DeepCopyInto(out *OrphanSweeper) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrphanSweeper.
+func (in *OrphanSweeper) DeepCopy() *OrphanSweeper {
+	if in == nil {
+		return nil
+	}
+	out := new(OrphanSweeper)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreStopJobPolicy) DeepCopyInto(out *PreStopJobPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreStopJobPolicy.
+func (in *PreStopJobPolicy) DeepCopy() *PreStopJobPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PreStopJobPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreStopStatus) DeepCopyInto(out *PreStopStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreStopStatus.
+func (in *PreStopStatus) DeepCopy() *PreStopStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PreStopStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReviewApp) DeepCopyInto(out *ReviewApp) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReviewApp.
+func (in *ReviewApp) DeepCopy() *ReviewApp {
+	if in == nil {
+		return nil
+	}
+	out := new(ReviewApp)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ReviewApp) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReviewAppCommonSpec) DeepCopyInto(out *ReviewAppCommonSpec) {
+	*out = *in
+	in.AppRepoTarget.DeepCopyInto(&out.AppRepoTarget)
+	out.AppRepoConfig = in.AppRepoConfig
+	in.InfraRepoTarget.DeepCopyInto(&out.InfraRepoTarget)
+	in.InfraRepoConfig.DeepCopyInto(&out.InfraRepoConfig)
+	if in.PreStopJob != nil {
+		in, out := &in.PreStopJob, &out.PreStopJob
+		*out = new(NamespacedName)
+		**out = **in
+	}
+	out.PreStopJobPolicy = in.PreStopJobPolicy
+	if in.PostDeployJobs != nil {
+		in, out := &in.PostDeployJobs, &out.PostDeployJobs
+		*out = make([]HookJob, len(*in))
+		copy(*out, *in)
+	}
+	if in.PreDeployJobs != nil {
+		in, out := &in.PreDeployJobs, &out.PreDeployJobs
+		*out = make([]HookJob, len(*in))
+		copy(*out, *in)
+	}
+	out.JobLogs = in.JobLogs
+	out.TeardownVerification = in.TeardownVerification
+	if in.Variables != nil {
+		in, out := &in.Variables, &out.Variables
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.VariablesFrom != nil {
+		in, out := &in.VariablesFrom, &out.VariablesFrom
+		*out = make([]corev1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VariantRules != nil {
+		in, out := &in.VariantRules, &out.VariantRules
+		*out = make([]VariantRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReviewAppCommonSpec.
+func (in *ReviewAppCommonSpec) DeepCopy() *ReviewAppCommonSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReviewAppCommonSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReviewAppList) DeepCopyInto(out *ReviewAppList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ReviewApp, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReviewAppList.
+func (in *ReviewAppList) DeepCopy() *ReviewAppList {
+	if in == nil {
+		return nil
+	}
+	out := new(ReviewAppList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ReviewAppList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReviewAppManager) DeepCopyInto(out *ReviewAppManager) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReviewAppManager.
+func (in *ReviewAppManager) DeepCopy() *ReviewAppManager {
+	if in == nil {
+		return nil
+	}
+	out := new(ReviewAppManager)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ReviewAppManager) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReviewAppManagerList) DeepCopyInto(out *ReviewAppManagerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ReviewAppManager, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReviewAppManagerList.
+func (in *ReviewAppManagerList) DeepCopy() *ReviewAppManagerList {
+	if in == nil {
+		return nil
+	}
+	out := new(ReviewAppManagerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ReviewAppManagerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReviewAppManagerSpec) DeepCopyInto(out *ReviewAppManagerSpec) {
+	*out = *in
+	in.ReviewAppCommonSpec.DeepCopyInto(&out.ReviewAppCommonSpec)
+	in.VariableOverrides.DeepCopyInto(&out.VariableOverrides)
+	out.OrphanSweeper = in.OrphanSweeper
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReviewAppManagerSpec.
+func (in *ReviewAppManagerSpec) DeepCopy() *ReviewAppManagerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReviewAppManagerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReviewAppManagerStatus) DeepCopyInto(out *ReviewAppManagerStatus) {
+	*out = *in
+	if in.SyncedPullRequests != nil {
+		in, out := &in.SyncedPullRequests, &out.SyncedPullRequests
+		*out = make([]SyncedPullRequest, len(*in))
+		copy(*out, *in)
+	}
+	in.OrphanSweep.DeepCopyInto(&out.OrphanSweep)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReviewAppManagerStatus.
+func (in *ReviewAppManagerStatus) DeepCopy() *ReviewAppManagerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReviewAppManagerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReviewAppSpec) DeepCopyInto(out *ReviewAppSpec) {
+	*out = *in
+	in.ReviewAppCommonSpec.DeepCopyInto(&out.ReviewAppCommonSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReviewAppSpec.
+func (in *ReviewAppSpec) DeepCopy() *ReviewAppSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReviewAppSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReviewAppStatus) DeepCopyInto(out *ReviewAppStatus) {
+	*out = *in
+	in.Sync.DeepCopyInto(&out.Sync)
+	in.ManifestsCache.DeepCopyInto(&out.ManifestsCache)
+	if in.ManifestsSources != nil {
+		in, out := &in.ManifestsSources, &out.ManifestsSources
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	in.InfraRepoFiles.DeepCopyInto(&out.InfraRepoFiles)
+	in.ChatOps.DeepCopyInto(&out.ChatOps)
+	in.PreStop.DeepCopyInto(&out.PreStop)
+	in.PostDeployJobs.DeepCopyInto(&out.PostDeployJobs)
+	in.PreDeployJobs.DeepCopyInto(&out.PreDeployJobs)
+	in.Teardown.DeepCopyInto(&out.Teardown)
+	in.DryRun.DeepCopyInto(&out.DryRun)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReviewAppStatus.
+func (in *ReviewAppStatus) DeepCopy() *ReviewAppStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReviewAppStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReviewAppStatusSyncedPullRequest) DeepCopyInto(out *ReviewAppStatusSyncedPullRequest) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CreatedAt != nil {
+		in, out := &in.CreatedAt, &out.CreatedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.SyncTimestamp != nil {
+		in, out := &in.SyncTimestamp, &out.SyncTimestamp
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReviewAppStatusSyncedPullRequest.
+func (in *ReviewAppStatusSyncedPullRequest) DeepCopy() *ReviewAppStatusSyncedPullRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(ReviewAppStatusSyncedPullRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncStatus) DeepCopyInto(out *SyncStatus) {
+	*out = *in
+	in.SyncedPullRequest.DeepCopyInto(&out.SyncedPullRequest)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncStatus.
+func (in *SyncStatus) DeepCopy() *SyncStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncedPullRequest) DeepCopyInto(out *SyncedPullRequest) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncedPullRequest.
+func (in *SyncedPullRequest) DeepCopy() *SyncedPullRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncedPullRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeardownStatus) DeepCopyInto(out *TeardownStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeardownStatus.
+func (in *TeardownStatus) DeepCopy() *TeardownStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TeardownStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeardownVerification) DeepCopyInto(out *TeardownVerification) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeardownVerification.
+func (in *TeardownVerification) DeepCopy() *TeardownVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(TeardownVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VariableOverrides) DeepCopyInto(out *VariableOverrides) {
+	*out = *in
+	if in.AllowedKeys != nil {
+		in, out := &in.AllowedKeys, &out.AllowedKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]VariableOverridesLabel, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VariableOverrides.
+func (in *VariableOverrides) DeepCopy() *VariableOverrides {
+	if in == nil {
+		return nil
+	}
+	out := new(VariableOverrides)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VariableOverridesLabel) DeepCopyInto(out *VariableOverridesLabel) {
+	*out = *in
+	if in.Variables != nil {
+		in, out := &in.Variables, &out.Variables
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VariableOverridesLabel.
+func (in *VariableOverridesLabel) DeepCopy() *VariableOverridesLabel {
+	if in == nil {
+		return nil
+	}
+	out := new(VariableOverridesLabel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VariantRule) DeepCopyInto(out *VariantRule) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VariantRule.
+func (in *VariantRule) DeepCopy() *VariantRule {
+	if in == nil {
+		return nil
+	}
+	out := new(VariantRule)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/resource"
+	"sigs.k8s.io/yaml"
+
+	dreamkastv1alpha1 "github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
+	"github.com/cloudnativedaysjp/reviewapp-operator/cmd/reviewappctl/pkg/utils"
+)
+
+type jobTemplatingOptions struct {
+	name        string
+	namespace   string
+	basefile    string
+	validate    bool
+	isStable    bool
+	isCandidate bool
+	variant     string
+}
+
+var jto = &jobTemplatingOptions{}
+
+var jobTemplatingCmd = &cobra.Command{
+	Use: "job-templating",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runJobTemplating(cmd, args)
+	},
+}
+
+func init() {
+	jobTemplatingCmd.Flags().StringVarP(&jto.name, "name", "", "",
+		"name of JobTemplate")
+	jobTemplatingCmd.Flags().StringVarP(&jto.namespace, "namespace", "", "default",
+		"namespace of JobTemplate")
+	jobTemplatingCmd.Flags().StringVarP(&jto.basefile, "load", "f", "job_template.yaml",
+		"filename of manifest based on JobTemplate")
+	jobTemplatingCmd.Flags().BoolVarP(&jto.validate, "validate", "", true,
+		"validate manifest if this flag is true")
+	jobTemplatingCmd.Flags().BoolVarP(&jto.isStable, "is-stable", "", false,
+		"using Stable template if this flag is true")
+	jobTemplatingCmd.Flags().BoolVarP(&jto.isCandidate, "is-candidate", "", false,
+		"using Candidate template if this flag is true")
+	jobTemplatingCmd.Flags().StringVarP(&jto.variant, "variant", "", "",
+		"using named variant template if this flag is specified")
+
+	RootCmd.AddCommand(jobTemplatingCmd)
+}
+
+func runJobTemplating(cmd *cobra.Command, files []string) error {
+	// validate
+	if len(files) != 1 {
+		return fmt.Errorf("required only one filename")
+	}
+	file := files[0]
+	if !jto.isStable && !jto.isCandidate && jto.variant == "" {
+		return fmt.Errorf("required either --is-stable, --is-candidate or --variant options")
+	}
+	if jto.validate {
+		if err := initValidator(); err != nil {
+			return err
+		}
+	}
+	if err := utils.ValidateFile(file); err != nil {
+		return err
+	}
+
+	// declear JobTemplate
+	var jt dreamkastv1alpha1.JobTemplate
+	if err := utils.ValidateFile(jto.basefile); err == nil {
+		fmt.Println("load basefile...")
+		b, err := ioutil.ReadFile(jto.basefile)
+		if err != nil {
+			return err
+		}
+		if err := yaml.Unmarshal(b, &jt); err != nil {
+			return err
+		}
+	}
+	if jt.Name == "" {
+		if jto.name == "" {
+			return fmt.Errorf("required --name option")
+		}
+		fmt.Println("new struct of JobTemplate...")
+		jt = dreamkastv1alpha1.JobTemplate{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "JobTemplate",
+				APIVersion: "dreamkast.cloudnativedays.jp/v1alpha1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      jto.name,
+				Namespace: jto.namespace,
+			},
+		}
+	}
+
+	// validate schema of manifest
+	if jto.validate {
+		if _, err := builder.
+			Unstructured().
+			Schema(validator).
+			ContinueOnError().
+			FilenameParam(false, &resource.FilenameOptions{Filenames: []string{file}}).
+			Flatten().
+			Do().Infos(); err != nil {
+			return err
+		}
+	}
+
+	// load manifest & construct JobTemplate
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	if jto.variant != "" {
+		if jt.Spec.Variants == nil {
+			jt.Spec.Variants = make(map[string]string)
+		}
+		jt.Spec.Variants[jto.variant] = string(b)
+	} else if jto.isStable {
+		jt.Spec.StableTemplate = string(b)
+	} else if jto.isCandidate {
+		jt.Spec.CandidateTemplate = string(b)
+	}
+
+	// write to output manifest
+	data, err := yaml.Marshal(jt)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(jto.basefile, data, 0644); err != nil {
+		return err
+	}
+	fmt.Printf("output to %s\n", jto.basefile)
+	return nil
+}
@@ -22,6 +22,7 @@ type manifestsTemplatingOptions struct {
 	validate    bool
 	isStable    bool
 	isCandidate bool
+	variant     string
 }
 
 var mto = &manifestsTemplatingOptions{}
@@ -46,6 +47,8 @@ func init() {
 		"using Stable template if this flag is true")
 	manifestsTemplatingCmd.Flags().BoolVarP(&mto.isCandidate, "is-candidate", "", false,
 		"using Candidate template if this flag is true")
+	manifestsTemplatingCmd.Flags().StringVarP(&mto.variant, "variant", "", "",
+		"using named variant template if this flag is specified")
 
 	RootCmd.AddCommand(manifestsTemplatingCmd)
 }
@@ -55,8 +58,13 @@ func runManifestsTemplating(cmd *cobra.Command, files []string) error {
 	if len(files) < 1 {
 		return fmt.Errorf("required one or more filenames")
 	}
-	if !mto.isStable && !mto.isCandidate {
-		return fmt.Errorf("required either --is-stable or --is-candidate options")
+	if !mto.isStable && !mto.isCandidate && mto.variant == "" {
+		return fmt.Errorf("required either --is-stable, --is-candidate or --variant options")
+	}
+	if mto.validate {
+		if err := initValidator(); err != nil {
+			return err
+		}
 	}
 
 	// declear ManifestsTemplate
@@ -93,6 +101,14 @@ func runManifestsTemplating(cmd *cobra.Command, files []string) error {
 	if mt.Spec.CandidateData == nil {
 		mt.Spec.CandidateData = make(map[string]string)
 	}
+	if mto.variant != "" {
+		if mt.Spec.Variants == nil {
+			mt.Spec.Variants = make(map[string]dreamkastv1alpha1.ManifestsTemplateData)
+		}
+		if mt.Spec.Variants[mto.variant] == nil {
+			mt.Spec.Variants[mto.variant] = make(dreamkastv1alpha1.ManifestsTemplateData)
+		}
+	}
 
 	// load manifests & construct ManifestsTemplate
 	for _, file := range files {
@@ -126,7 +142,9 @@ func runManifestsTemplating(cmd *cobra.Command, files []string) error {
 			return err
 		}
 		filename := filepath.Base(file)
-		if mto.isStable {
+		if mto.variant != "" {
+			mt.Spec.Variants[mto.variant][filename] = string(b)
+		} else if mto.isStable {
 			mt.Spec.StableData[filename] = string(b)
 		} else if mto.isCandidate {
 			mt.Spec.CandidateData[filename] = string(b)
@@ -0,0 +1,425 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	dreamkastv1alpha1 "github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
+	"github.com/cloudnativedaysjp/reviewapp-operator/cmd/reviewappctl/pkg/utils"
+	"github.com/cloudnativedaysjp/reviewapp-operator/domain/models"
+	operatorutils "github.com/cloudnativedaysjp/reviewapp-operator/utils"
+)
+
+type renderOptions struct {
+	name         string
+	namespace    string
+	prFile       string
+	outputDir    string
+	useCandidate bool
+	pr           renderPullRequest
+}
+
+// renderPullRequest is fake PR data given by --pr-file or flags
+type renderPullRequest struct {
+	Number           int      `json:"number"`
+	Branch           string   `json:"branch"`
+	BaseBranch       string   `json:"baseBranch"`
+	LatestCommitHash string   `json:"sha"`
+	Title            string   `json:"title"`
+	Labels           []string `json:"labels"`
+	Author           string   `json:"author"`
+	URL              string   `json:"url"`
+	CreatedAt        string   `json:"createdAt"`
+	Body             string   `json:"body"`
+}
+
+var ro = &renderOptions{}
+
+var renderCmd = &cobra.Command{
+	Use:   "render [flags] FILE...",
+	Short: "render files that reviewapp-operator would push to Infra Repository without cluster or GitHub access",
+	Long: `render files that reviewapp-operator would push to Infra Repository without cluster or GitHub access.
+
+FILEs are YAML (multiple documents are allowed) of ReviewAppManager, ApplicationTemplate, ManifestsTemplate,
+and ConfigMaps or Secrets referred by spec.variablesFrom of ReviewAppManager.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRender(cmd, args)
+	},
+}
+
+func init() {
+	renderCmd.Flags().StringVarP(&ro.name, "name", "", "",
+		"name of ReviewAppManager (required if FILEs contain multiple ReviewAppManagers)")
+	renderCmd.Flags().StringVarP(&ro.namespace, "namespace", "n", metav1.NamespaceDefault,
+		"namespace of objects in FILEs whose metadata.namespace is empty")
+	renderCmd.Flags().StringVarP(&ro.prFile, "pr-file", "", "",
+		"filename of YAML of fake PR data (keys are same as flags of PR, e.g. number, branch, sha, labels). flags take precedence over it")
+	renderCmd.Flags().StringVarP(&ro.outputDir, "output-dir", "o", "",
+		"write files under the directory as root of Infra Repository instead of printing them to stdout")
+	renderCmd.Flags().BoolVarP(&ro.useCandidate, "use-candidate", "", false,
+		"using Candidate template as if \"/reviewapp use-candidate\" was commented to PR")
+	renderCmd.Flags().IntVarP(&ro.pr.Number, "number", "", 0,
+		"number of PR")
+	renderCmd.Flags().StringVarP(&ro.pr.Branch, "branch", "", "",
+		"branch name of PR")
+	renderCmd.Flags().StringVarP(&ro.pr.BaseBranch, "base-branch", "", "main",
+		"branch name that PR will be merged into")
+	renderCmd.Flags().StringVarP(&ro.pr.LatestCommitHash, "sha", "", "",
+		"hash of the latest commit of PR")
+	renderCmd.Flags().StringVarP(&ro.pr.Title, "title", "", "",
+		"title of PR")
+	renderCmd.Flags().StringSliceVarP(&ro.pr.Labels, "labels", "", nil,
+		"labels of PR")
+	renderCmd.Flags().StringVarP(&ro.pr.Author, "author", "", "",
+		"author of PR")
+	renderCmd.Flags().StringVarP(&ro.pr.Body, "body", "", "",
+		"description of PR")
+
+	RootCmd.AddCommand(renderCmd)
+}
+
+func runRender(cmd *cobra.Command, files []string) error {
+	// validate
+	if len(files) < 1 {
+		return fmt.Errorf("required one or more filenames")
+	}
+
+	// load objects
+	objs := newRenderObjects()
+	for _, file := range files {
+		if err := utils.ValidateFile(file); err != nil {
+			return err
+		}
+		if err := objs.load(file); err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+	}
+	ram, err := objs.getReviewAppManager(ro.name)
+	if err != nil {
+		return err
+	}
+
+	// load fake PR data
+	pr, err := loadRenderPullRequest(cmd, ram)
+	if err != nil {
+		return err
+	}
+	if prs, err := (models.PullRequests{pr}).ExcludeSpecificPR(ram); err != nil {
+		return err
+	} else if len(prs) == 0 {
+		return fmt.Errorf("PR #%d is excluded by spec.appRepoTarget of ReviewAppManager %s/%s", pr.Number, ram.Namespace, ram.Name)
+	}
+
+	// generate ReviewApp in the same way as ReviewAppManager controller
+	overrides, err := ram.VariableOverrides(pr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", err)
+	}
+	ramForPR := ram.WithVariableOverrides(overrides)
+	v, err := objs.templator(ramForPR, pr)
+	if err != nil {
+		return err
+	}
+	ra, err := ramForPR.GenerateReviewApp(pr, v, operatorutils.NewDatetimeFactory())
+	if err != nil {
+		return err
+	}
+
+	// generate Application & manifests in the same way as ReviewApp controller
+	pr.UseCandidate = ro.useCandidate
+	pr, err = pr.WithVariant(ra.VariantRules())
+	if err != nil {
+		return err
+	}
+	v, err = objs.templator(ra, pr)
+	if err != nil {
+		return err
+	}
+	at, err := objs.getApplicationTemplate(ra)
+	if err != nil {
+		return err
+	}
+	application, err := at.GenerateApplication(pr, v)
+	if err != nil {
+		return err
+	}
+	application, err = application.SetSomeAnnotations(ra)
+	if err != nil {
+		return err
+	}
+	mts, err := objs.getManifestsTemplates(ra)
+	if err != nil {
+		return err
+	}
+	manifests, _, err := models.GenerateMergedManifests(mts, pr, v, ra.MtDirpath())
+	if err != nil {
+		return err
+	}
+
+	// print or write files
+	localDir := models.NewInfraRepoLocal(ro.outputDir)
+	result := append([]models.File{}, models.NewFileFromApplication(ra, application, pr, localDir))
+	result = append(result, models.NewFilesFromManifests(ra, manifests, pr, localDir)...)
+	sort.Slice(result, func(i, j int) bool { return result[i].Filepath < result[j].Filepath })
+	if ro.outputDir == "" {
+		return printFiles(cmd.OutOrStdout(), result)
+	}
+	return writeFiles(cmd.OutOrStdout(), result)
+}
+
+func loadRenderPullRequest(cmd *cobra.Command, ram models.ReviewAppManager) (models.PullRequest, error) {
+	var p renderPullRequest
+	if ro.prFile != "" {
+		if err := utils.ValidateFile(ro.prFile); err != nil {
+			return models.PullRequest{}, err
+		}
+		b, err := ioutil.ReadFile(ro.prFile)
+		if err != nil {
+			return models.PullRequest{}, err
+		}
+		if err := yaml.Unmarshal(b, &p); err != nil {
+			return models.PullRequest{}, fmt.Errorf("%s: %w", ro.prFile, err)
+		}
+	}
+	// flags take precedence over --pr-file
+	flags := cmd.Flags()
+	if flags.Changed("number") {
+		p.Number = ro.pr.Number
+	}
+	if flags.Changed("branch") {
+		p.Branch = ro.pr.Branch
+	}
+	if flags.Changed("base-branch") || p.BaseBranch == "" {
+		p.BaseBranch = ro.pr.BaseBranch
+	}
+	if flags.Changed("sha") {
+		p.LatestCommitHash = ro.pr.LatestCommitHash
+	}
+	if flags.Changed("title") {
+		p.Title = ro.pr.Title
+	}
+	if flags.Changed("labels") {
+		p.Labels = ro.pr.Labels
+	}
+	if flags.Changed("author") {
+		p.Author = ro.pr.Author
+	}
+	if flags.Changed("body") {
+		p.Body = ro.pr.Body
+	}
+	if p.Number == 0 {
+		return models.PullRequest{}, fmt.Errorf("required --number option or number in --pr-file")
+	}
+
+	appTarget := ram.AppRepoTarget()
+	if p.URL == "" {
+		p.URL = fmt.Sprintf("https://github.com/%s/%s/pull/%d", appTarget.Organization, appTarget.Repository, p.Number)
+	}
+	return models.PullRequest{
+		Organization:     appTarget.Organization,
+		Repository:       appTarget.Repository,
+		Branch:           p.Branch,
+		BaseBranch:       p.BaseBranch,
+		Number:           p.Number,
+		LatestCommitHash: p.LatestCommitHash,
+		Title:            p.Title,
+		Labels:           p.Labels,
+		Author:           p.Author,
+		URL:              p.URL,
+		CreatedAt:        p.CreatedAt,
+		Body:             p.Body,
+	}, nil
+}
+
+func printFiles(w io.Writer, files []models.File) error {
+	for _, f := range files {
+		if _, err := fmt.Fprintf(w, "---\n# Source: %s\n%s", f.Filepath, f.Content); err != nil {
+			return err
+		}
+		if !bytes.HasSuffix(f.Content, []byte("\n")) {
+			fmt.Fprintln(w)
+		}
+	}
+	return nil
+}
+
+func writeFiles(w io.Writer, files []models.File) error {
+	for _, f := range files {
+		fpath := filepath.Join(f.BaseDir, f.Filepath)
+		if err := os.MkdirAll(filepath.Dir(fpath), 0755); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(fpath, f.Content, 0644); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "output to %s\n", fpath)
+	}
+	return nil
+}
+
+// renderObjects is objects loaded from files instead of Kubernetes cluster
+type renderObjects struct {
+	reviewAppManagers    []dreamkastv1alpha1.ReviewAppManager
+	applicationTemplates map[types.NamespacedName]dreamkastv1alpha1.ApplicationTemplate
+	manifestsTemplates   map[types.NamespacedName]dreamkastv1alpha1.ManifestsTemplate
+	configMaps           map[types.NamespacedName]corev1.ConfigMap
+	secrets              map[types.NamespacedName]corev1.Secret
+}
+
+func newRenderObjects() *renderObjects {
+	return &renderObjects{
+		applicationTemplates: make(map[types.NamespacedName]dreamkastv1alpha1.ApplicationTemplate),
+		manifestsTemplates:   make(map[types.NamespacedName]dreamkastv1alpha1.ManifestsTemplate),
+		configMaps:           make(map[types.NamespacedName]corev1.ConfigMap),
+		secrets:              make(map[types.NamespacedName]corev1.Secret),
+	}
+}
+
+func (o *renderObjects) load(file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(f))
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		var typeMeta metav1.TypeMeta
+		if err := yaml.Unmarshal(doc, &typeMeta); err != nil {
+			return err
+		}
+		switch typeMeta.Kind {
+		case "ReviewAppManager":
+			var obj dreamkastv1alpha1.ReviewAppManager
+			if err := yaml.Unmarshal(doc, &obj); err != nil {
+				return err
+			}
+			o.reviewAppManagers = append(o.reviewAppManagers, obj)
+		case "ApplicationTemplate":
+			var obj dreamkastv1alpha1.ApplicationTemplate
+			if err := yaml.Unmarshal(doc, &obj); err != nil {
+				return err
+			}
+			o.applicationTemplates[namespacedName(obj.ObjectMeta)] = obj
+		case "ManifestsTemplate":
+			var obj dreamkastv1alpha1.ManifestsTemplate
+			if err := yaml.Unmarshal(doc, &obj); err != nil {
+				return err
+			}
+			o.manifestsTemplates[namespacedName(obj.ObjectMeta)] = obj
+		case "ConfigMap":
+			var obj corev1.ConfigMap
+			if err := yaml.Unmarshal(doc, &obj); err != nil {
+				return err
+			}
+			o.configMaps[namespacedName(obj.ObjectMeta)] = obj
+		case "Secret":
+			var obj corev1.Secret
+			if err := yaml.Unmarshal(doc, &obj); err != nil {
+				return err
+			}
+			// stringData is merged into data in the same way as kube-apiserver
+			if obj.Data == nil {
+				obj.Data = make(map[string][]byte)
+			}
+			for key, val := range obj.StringData {
+				obj.Data[key] = []byte(val)
+			}
+			o.secrets[namespacedName(obj.ObjectMeta)] = obj
+		}
+	}
+}
+
+func (o *renderObjects) getReviewAppManager(name string) (models.ReviewAppManager, error) {
+	var candidates []dreamkastv1alpha1.ReviewAppManager
+	for _, ram := range o.reviewAppManagers {
+		if name == "" || ram.Name == name {
+			candidates = append(candidates, ram)
+		}
+	}
+	switch {
+	case len(candidates) == 0 && name == "":
+		return models.ReviewAppManager{}, fmt.Errorf("ReviewAppManager is not found in files")
+	case len(candidates) == 0:
+		return models.ReviewAppManager{}, fmt.Errorf("ReviewAppManager %s is not found in files", name)
+	case len(candidates) > 1:
+		return models.ReviewAppManager{}, fmt.Errorf("files contain multiple ReviewAppManagers, required --name option")
+	}
+	ram := candidates[0]
+	ram.Namespace = namespacedName(ram.ObjectMeta).Namespace
+	return models.ReviewAppManager(ram), nil
+}
+
+func (o *renderObjects) getApplicationTemplate(ra models.ReviewApp) (models.ApplicationTemplate, error) {
+	nn := types.NamespacedName(ra.InfraRepoConfig().ArgoCDApp.Template)
+	at, ok := o.applicationTemplates[nn]
+	if !ok {
+		return models.ApplicationTemplate{}, fmt.Errorf("ApplicationTemplate %s is not found in files", nn)
+	}
+	return models.ApplicationTemplate(at), nil
+}
+
+func (o *renderObjects) getManifestsTemplates(ra models.ReviewApp) ([]models.ManifestsTemplate, error) {
+	var mts []models.ManifestsTemplate
+	for _, tmp := range ra.InfraRepoConfig().Manifests.Templates {
+		nn := types.NamespacedName(tmp)
+		mt, ok := o.manifestsTemplates[nn]
+		if !ok {
+			return nil, fmt.Errorf("ManifestsTemplate %s is not found in files", nn)
+		}
+		mts = append(mts, models.ManifestsTemplate(mt))
+	}
+	return mts, nil
+}
+
+// templator initializes Templator in the same way as controllers, with ConfigMaps & Secrets loaded from files
+func (o *renderObjects) templator(m models.ReviewAppOrReviewAppManager, pr models.PullRequest) (models.Templator, error) {
+	v := models.NewTemplator(m, pr)
+	if len(m.VariablesFrom()) == 0 {
+		return v, nil
+	}
+	namespace := m.NamespaceName().Namespace
+	configMaps := make(map[string]corev1.ConfigMap)
+	for nn, cm := range o.configMaps {
+		if nn.Namespace == namespace {
+			configMaps[nn.Name] = cm
+		}
+	}
+	secrets := make(map[string]corev1.Secret)
+	for nn, secret := range o.secrets {
+		if nn.Namespace == namespace {
+			secrets[nn.Name] = secret
+		}
+	}
+	vars, err := models.NewVariablesFrom(m, configMaps, secrets)
+	if err != nil {
+		return models.Templator{}, fmt.Errorf("%w in files", err)
+	}
+	return *v.WithVariablesFrom(vars), nil
+}
+
+func namespacedName(m metav1.ObjectMeta) types.NamespacedName {
+	if m.Namespace == "" {
+		return types.NamespacedName{Namespace: ro.namespace, Name: m.Name}
+	}
+	return types.NamespacedName{Namespace: m.Namespace, Name: m.Name}
+}
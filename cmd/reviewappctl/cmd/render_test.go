@@ -0,0 +1,228 @@
+//go:build !integration_test
+// +build !integration_test
+
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/pflag"
+)
+
+const testRenderReviewAppManager = `apiVersion: dreamkast.cloudnativedays.jp/v1alpha1
+kind: ReviewAppManager
+metadata:
+  name: test-ram
+spec:
+  appRepoTarget:
+    username: test-user
+    organization: test-org
+    repository: test-app
+  infraRepoTarget:
+    username: test-user
+    organization: test-org
+    repository: test-infra
+    branch: main
+  infraRepoConfig:
+    manifests:
+      templates:
+        - namespace: test-ns
+          name: test-mt
+      dirpath: "overlays/{{.Variables.ALIAS}}-{{.AppRepo.PrNumber}}"
+    argocdApp:
+      template:
+        namespace: test-ns
+        name: test-at
+      filepath: "apps/{{.Variables.ALIAS}}-{{.AppRepo.PrNumber}}.yaml"
+  variablesFrom:
+    - configMapRef:
+        name: test-cm
+    - secretRef:
+        name: test-secret
+      prefix: DB_
+    - configMapRef:
+        name: test-optional-cm
+        optional: true
+`
+
+const testRenderTemplates = `apiVersion: dreamkast.cloudnativedays.jp/v1alpha1
+kind: ApplicationTemplate
+metadata:
+  name: test-at
+spec:
+  stable: &application |
+    apiVersion: argoproj.io/v1alpha1
+    kind: Application
+    metadata:
+      name: "{{.Variables.ALIAS}}-{{.AppRepo.PrNumber}}"
+      namespace: argocd
+  candidate: *application
+---
+apiVersion: dreamkast.cloudnativedays.jp/v1alpha1
+kind: ManifestsTemplate
+metadata:
+  name: test-mt
+spec:
+  stable: &manifests
+    secret.yaml: |
+      password: {{.Variables.DB_PASSWORD}}
+  candidate: *manifests
+`
+
+const testRenderConfigMap = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test-cm
+data:
+  ALIAS: test
+`
+
+const testRenderSecret = `apiVersion: v1
+kind: Secret
+metadata:
+  name: test-secret
+stringData:
+  PASSWORD: s3cr3t
+`
+
+// resetRenderOptions resets flags of render command, because they are bound to global variables
+func resetRenderOptions() {
+	renderCmd.Flags().VisitAll(func(f *pflag.Flag) {
+		f.Changed = false
+	})
+	*ro = renderOptions{namespace: "test-ns", pr: renderPullRequest{BaseBranch: "main"}}
+}
+
+func TestRunRender(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		fpath := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(fpath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return fpath
+	}
+	ramFile := writeFile("ram.yaml", testRenderReviewAppManager)
+	templatesFile := writeFile("templates.yaml", testRenderTemplates)
+	cmFile := writeFile("cm.yaml", testRenderConfigMap)
+	secretFile := writeFile("secret.yaml", testRenderSecret)
+	prFile := writeFile("pr.yaml", "number: 2\nsha: 0123456789abcdef\n")
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "templated with variables from ConfigMap & Secret",
+			args: []string{"--number", "1", "--sha", "abcdef", ramFile, templatesFile, cmFile, secretFile},
+			want: `---
+# Source: apps/test-1.yaml
+apiVersion: argoproj.io/v1alpha1
+kind: Application
+metadata:
+  annotations:
+    dreamkast.cloudnativedays.jp/app-commit-hash: abcdef
+    dreamkast.cloudnativedays.jp/app-organization: test-org
+    dreamkast.cloudnativedays.jp/app-repository: test-app
+  name: test-1
+  namespace: argocd
+---
+# Source: overlays/test-1/secret.yaml
+password: s3cr3t
+`,
+		},
+		{
+			name: "PR data given by --pr-file",
+			args: []string{"--pr-file", prFile, ramFile, templatesFile, cmFile, secretFile},
+			want: `---
+# Source: apps/test-2.yaml
+apiVersion: argoproj.io/v1alpha1
+kind: Application
+metadata:
+  annotations:
+    dreamkast.cloudnativedays.jp/app-commit-hash: 0123456789abcdef
+    dreamkast.cloudnativedays.jp/app-organization: test-org
+    dreamkast.cloudnativedays.jp/app-repository: test-app
+  name: test-2
+  namespace: argocd
+---
+# Source: overlays/test-2/secret.yaml
+password: s3cr3t
+`,
+		},
+		{
+			name:    "Secret is not in files",
+			args:    []string{"--number", "1", ramFile, templatesFile, cmFile},
+			wantErr: true,
+		},
+		{
+			name:    "number of PR is not given",
+			args:    []string{ramFile, templatesFile, cmFile, secretFile},
+			wantErr: true,
+		},
+		{
+			name:    "ReviewAppManager is not in files",
+			args:    []string{"--number", "1", templatesFile, cmFile, secretFile},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			// not parallel because flags are bound to global variables
+			resetRenderOptions()
+			if err := renderCmd.ParseFlags(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			out := &bytes.Buffer{}
+			renderCmd.SetOut(out)
+			err := runRender(renderCmd, renderCmd.Flags().Args())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("runRender() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(tt.want, out.String()); diff != "" {
+				t.Errorf("runRender() output mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRunRender_outputDir(t *testing.T) {
+	dir := t.TempDir()
+	files := []string{
+		filepath.Join(dir, "ram.yaml"), filepath.Join(dir, "templates.yaml"),
+		filepath.Join(dir, "cm.yaml"), filepath.Join(dir, "secret.yaml"),
+	}
+	for i, content := range []string{testRenderReviewAppManager, testRenderTemplates, testRenderConfigMap, testRenderSecret} {
+		if err := ioutil.WriteFile(files[i], []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	outputDir := filepath.Join(dir, "infra")
+
+	resetRenderOptions()
+	if err := renderCmd.ParseFlags(append([]string{"--number", "1", "-o", outputDir}, files...)); err != nil {
+		t.Fatal(err)
+	}
+	renderCmd.SetOut(&bytes.Buffer{})
+	if err := runRender(renderCmd, renderCmd.Flags().Args()); err != nil {
+		t.Fatalf("runRender() error = %v", err)
+	}
+	got, err := ioutil.ReadFile(filepath.Join(outputDir, "overlays/test-1/secret.yaml"))
+	if err != nil {
+		t.Fatalf("file is not written: %v", err)
+	}
+	if diff := cmp.Diff("password: s3cr3t\n", string(got)); diff != "" {
+		t.Errorf("written file mismatch (-want +got):\n%s", diff)
+	}
+	if _, err := ioutil.ReadFile(filepath.Join(outputDir, "apps/test-1.yaml")); err != nil {
+		t.Errorf("file is not written: %v", err)
+	}
+}
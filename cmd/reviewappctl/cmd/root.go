@@ -32,7 +32,9 @@ func Execute() {
 	}
 }
 
-func init() {
+// initValidator initializes validator & builder.
+// It is called only by commands validating manifests, because it requires access to Kubernetes cluster.
+func initValidator() error {
 	var err error
 
 	//config := config.GetConfigOrDie()
@@ -41,10 +43,13 @@ func init() {
 	factory := cmdutil.NewFactory(matchVersionKubeConfigFlags)
 	validator, err = factory.Validator(true)
 	if err != nil {
-		panic(err)
+		return err
 	}
 	builder = factory.NewBuilder()
+	return nil
+}
 
+func init() {
 	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
 	RootCmd.PersistentFlags().AddGoFlagSet(flag.CommandLine)
 }
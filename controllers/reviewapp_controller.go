@@ -20,15 +20,23 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"reflect"
+	"time"
 
+	argocd_application_v1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
 	"github.com/go-logr/logr"
 	"golang.org/x/sync/singleflight"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/exec"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	dreamkastv1alpha1 "github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
 	"github.com/cloudnativedaysjp/reviewapp-operator/domain/models"
@@ -58,14 +66,26 @@ type ReviewAppReconciler struct {
 	K8sRepository        repositories.KubernetesRepository
 	GitApiRepository     repositories.GitAPI
 	GitCommandRepository repositories.GitCommand
+	ManifestsValidator   repositories.ManifestsValidator
 	PullRequestService   services.PullRequestServiceIface
+
+	// TemplateRolloutBatchSize is number of ReviewApps reconciled at once when templates are changed.
+	// All ReviewApps referring to the template are reconciled at once if it is not positive.
+	TemplateRolloutBatchSize int
+	// TemplateRolloutInterval is interval between batches of TemplateRolloutBatchSize
+	TemplateRolloutInterval time.Duration
 }
 
 //+kubebuilder:rbac:groups=dreamkast.cloudnativedays.jp,resources=reviewapps,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=dreamkast.cloudnativedays.jp,resources=reviewapps/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=dreamkast.cloudnativedays.jp,resources=reviewapps/finalizers,verbs=update
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
 //+kubebuilder:rbac:groups=argoproj.io,resources=applications,verbs=get;list;watch
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create
+//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list
+//+kubebuilder:rbac:groups="",resources=pods/log,verbs=get
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 
 func (r *ReviewAppReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	result, err, _ := singleflightGroupForReviewApp.Do(fmt.Sprintf("%s/%s", req.Namespace, req.Name), func() (interface{}, error) {
@@ -81,6 +101,9 @@ func (r *ReviewAppReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 				r.Log.Info(err.Error())
 				return result, nil
 			}
+			if myerrors.IsTemplatingFailed(err) {
+				return r.reportTemplatingFailure(ctx, ra, err)
+			}
 			return result, err
 		}
 
@@ -91,6 +114,11 @@ func (r *ReviewAppReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 		// Handle deletion reconciliation loop.
 		if !ra.ObjectMeta.DeletionTimestamp.IsZero() {
+			// while suspended, finalizer is kept because files in infra repo cannot be deleted
+			if ra.Spec.Suspend {
+				r.Log.Info(fmt.Sprintf("ReviewApp %s/%s is suspended, skip deletion", ra.Namespace, ra.Name))
+				return ctrl.Result{}, nil
+			}
 			return r.reconcileDelete(ctx, *dto)
 		}
 		return r.reconcile(ctx, *dto)
@@ -116,37 +144,91 @@ func (r *ReviewAppReconciler) reconcile(ctx context.Context, dto ReviewAppPhaseD
 	errs := []error{}
 	phase := func(cond bool, phase func(ctx context.Context, dto ReviewAppPhaseDTO) (models.ReviewAppStatus, ctrl.Result, error)) {
 		if cond {
-			raStatus, result, err = phase(ctx, dto)
+			var phaseResult ctrl.Result
+			raStatus, phaseResult, err = phase(ctx, dto)
 			if err != nil {
 				errs = append(errs, err)
 			}
+			result = earlierResult(result, phaseResult)
 			ra.Status = dreamkastv1alpha1.ReviewAppStatus(raStatus)
 			dto.ReviewApp = ra
 		}
 	}
 	// if s.sync.status is empty, set SyncStatusCodeInitialize
-	phase(reflect.DeepEqual(ra.Status, dreamkastv1alpha1.ReviewAppStatus{}),
+	phase(ra.Status.Sync.Status == "",
 		func(ctx context.Context, dto ReviewAppPhaseDTO) (models.ReviewAppStatus, ctrl.Result, error) {
 			s := dto.ReviewApp.GetStatus()
 			s.Sync.Status = dreamkastv1alpha1.SyncStatusCodeInitialize
 			return s, ctrl.Result{}, nil
 		},
 	)
+	// templates have been templated successfully in prepare
+	meta.SetStatusCondition(&raStatus.Conditions, metav1.Condition{
+		Type:   dreamkastv1alpha1.ReviewAppConditionTemplated,
+		Status: metav1.ConditionTrue,
+		Reason: "TemplatingSucceeded",
+	})
+	ra.Status = dreamkastv1alpha1.ReviewAppStatus(raStatus)
+	dto.ReviewApp = ra
+
+	// while suspended, skip phases that push to infra repo or comment to app repo
+	suspended := ra.Spec.Suspend
+	// in dry-run mode, manifests are rendered but neither pushed to infra repo nor commented to app repo
+	dryRun := ra.Spec.DryRun
+	// run commands from comments of PR
+	phase(!suspended && !dryRun && ra.Spec.AppConfig.ChatOps.Enabled,
+		r.runChatOpsCommands)
+	phase(!suspended && !dryRun && raStatus.ChatOps.Stopped && raStatus.Sync.Status != dreamkastv1alpha1.SyncStatusCodeStopped,
+		r.stopReviewApp)
 	// each phase
 	phase(raStatus.Sync.Status == dreamkastv1alpha1.SyncStatusCodeInitialize ||
 		raStatus.Sync.Status == dreamkastv1alpha1.SyncStatusCodeWatchingAppRepoAndTemplates,
 		r.confirmUpdated)
-	phase(raStatus.Sync.Status == dreamkastv1alpha1.SyncStatusCodeNeedToUpdateInfraRepo,
+	phase(!suspended && !dryRun && raStatus.Sync.Status == dreamkastv1alpha1.SyncStatusCodeNeedToUpdateInfraRepo,
 		r.deployReviewAppManifestsToInfraRepo)
-	phase(raStatus.Sync.Status == dreamkastv1alpha1.SyncStatusCodeUpdatedInfraRepo,
+	phase(!suspended && dryRun && raStatus.Sync.Status == dreamkastv1alpha1.SyncStatusCodeNeedToUpdateInfraRepo,
+		r.renderReviewAppManifestsForDryRun)
+	phase(!suspended && !dryRun && raStatus.Sync.Status == dreamkastv1alpha1.SyncStatusCodeUpdatedInfraRepo,
 		r.commentToAppRepoPullRequest)
 
 	// update status
+	ra.Status.Suspended = suspended
 	if err := r.K8sRepository.PatchReviewAppStatus(ctx, ra); err != nil {
 		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{}, kerrors.NewAggregate(errs)
+	return result, kerrors.NewAggregate(errs)
+}
+
+// earlierResult returns the Result that requeues earlier
+func earlierResult(a, b ctrl.Result) ctrl.Result {
+	switch {
+	case a.Requeue && a.RequeueAfter == 0:
+		return a
+	case b.Requeue && b.RequeueAfter == 0:
+		return b
+	case a.RequeueAfter == 0:
+		return b
+	case b.RequeueAfter == 0 || a.RequeueAfter <= b.RequeueAfter:
+		return a
+	}
+	return b
+}
+
+// reportTemplatingFailure records failure of templating to Event & status.conditions of ReviewApp
+func (r *ReviewAppReconciler) reportTemplatingFailure(ctx context.Context, ra models.ReviewApp, templatingErr error) (ctrl.Result, error) {
+	r.Log.Info(templatingErr.Error())
+	r.Recorder.Eventf(ra.ToReviewAppCR(), corev1.EventTypeWarning, "templating", "%s", templatingErr)
+	meta.SetStatusCondition(&ra.Status.Conditions, metav1.Condition{
+		Type:    dreamkastv1alpha1.ReviewAppConditionTemplated,
+		Status:  metav1.ConditionFalse,
+		Reason:  "TemplatingFailed",
+		Message: templatingErr.Error(),
+	})
+	if err := r.K8sRepository.PatchReviewAppStatus(ctx, ra); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
 }
 
 func (r *ReviewAppReconciler) removeMetrics(ra models.ReviewApp) {
@@ -169,7 +251,7 @@ func (r *ReviewAppReconciler) removeMetrics(ra models.ReviewApp) {
 func (r *ReviewAppReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	setupLog := ctrl.Log.WithName("setup")
 	var err error
-	r.K8sRepository, err = wire.NewKubernetesRepository(r.Log, mgr.GetClient())
+	r.K8sRepository, err = wire.NewKubernetesRepository(r.Log, mgr.GetClient(), mgr.GetConfig())
 	if err != nil {
 		setupLog.Error(err, "unable to initialize", "wire.NewKubernetesRepository")
 		os.Exit(1)
@@ -184,12 +266,44 @@ func (r *ReviewAppReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		setupLog.Error(err, "unable to initialize", "wire.NewGitCommandRepository")
 		os.Exit(1)
 	}
+	r.ManifestsValidator, err = wire.NewManifestsValidator(r.Log)
+	if err != nil {
+		setupLog.Error(err, "unable to initialize", "wire.NewManifestsValidator")
+		os.Exit(1)
+	}
 	r.PullRequestService, err = wire.NewPullRequestService(r.Log)
 	if err != nil {
 		setupLog.Error(err, "unable to initialize", "wire.NewPullRequestService")
 		os.Exit(1)
 	}
+	if err := setupTemplateIndexes(context.Background(), mgr.GetFieldIndexer()); err != nil {
+		return err
+	}
+	if err := setupApplicationIndex(context.Background(), mgr.GetFieldIndexer()); err != nil {
+		return err
+	}
+	if err := setupVariablesFromIndexes(context.Background(), mgr.GetFieldIndexer()); err != nil {
+		return err
+	}
+	templateHandler := &batchedEnqueueRequestsFromMapFunc{
+		toRequests: r.reviewAppsUsingTemplate,
+		batchSize:  r.TemplateRolloutBatchSize,
+		interval:   r.TemplateRolloutInterval,
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&dreamkastv1alpha1.ReviewApp{}).
+		// re-render when ConfigMaps or Secrets specified by spec.variablesFrom are changed
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}}, handler.EnqueueRequestsFromMapFunc(r.reviewAppsReferringTo),
+			builder.WithPredicates(variablesFromDataChanged)).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(r.reviewAppsReferringTo),
+			builder.WithPredicates(variablesFromDataChanged)).
+		// re-render when ApplicationTemplate or ManifestsTemplates are changed
+		Watches(&source.Kind{Type: &dreamkastv1alpha1.ApplicationTemplate{}}, templateHandler,
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&source.Kind{Type: &dreamkastv1alpha1.ManifestsTemplate{}}, templateHandler,
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// comment to App Repository's PR as soon as Argo CD applies Application of the new commit
+		Watches(&source.Kind{Type: &argocd_application_v1alpha1.Application{}}, handler.EnqueueRequestsFromMapFunc(r.reviewAppsOwningApplication),
+			builder.WithPredicates(applicationCommitHashChanged)).
 		Complete(r)
 }
@@ -0,0 +1,286 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	dreamkastv1alpha1 "github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
+	"github.com/cloudnativedaysjp/reviewapp-operator/domain/models"
+	myerrors "github.com/cloudnativedaysjp/reviewapp-operator/errors"
+)
+
+// runPostDeployJobs creates Jobs specified by spec.postDeployJobs once per commit pushed to Infra Repository,
+// and requeues until all of them finish.
+func (r *ReviewAppReconciler) runPostDeployJobs(ctx context.Context, dto ReviewAppPhaseDTO) (models.ReviewAppStatus, ctrl.Result, error) {
+	ra := dto.ReviewApp
+	raSource := ra.ToReviewAppCR()
+	raStatus := ra.GetStatus()
+	infraRepoCommitHash := raStatus.Sync.InfraRepoLatestCommitHash
+
+	if !models.HookJobsStatus(raStatus.PostDeployJobs).HasRunForInfraRepoCommit(infraRepoCommitHash) {
+		jobs, err := r.startHookJobs(ctx, dto, ra.Spec.PostDeployJobs, models.HookPostDeploy)
+		if err != nil {
+			return raStatus, ctrl.Result{}, err
+		}
+		raStatus.PostDeployJobs = dreamkastv1alpha1.HookJobsStatus{
+			AppRepoCommitHash:   raStatus.Sync.SyncedPullRequest.LatestCommitHash,
+			InfraRepoCommitHash: infraRepoCommitHash,
+			Jobs:                jobs,
+		}
+		meta.SetStatusCondition(&raStatus.Conditions, metav1.Condition{
+			Type:    dreamkastv1alpha1.ReviewAppConditionPostDeployJobsSucceeded,
+			Status:  metav1.ConditionUnknown,
+			Reason:  "Running",
+			Message: fmt.Sprintf("running postDeployJobs for commit %s of Infra Repository", infraRepoCommitHash),
+		})
+	} else {
+		jobs, err := r.updateHookJobs(ctx, ra, raStatus.PostDeployJobs.Jobs, models.HookPostDeploy)
+		if err != nil {
+			return raStatus, ctrl.Result{}, err
+		}
+		raStatus.PostDeployJobs.Jobs = jobs
+	}
+
+	status := models.HookJobsStatus(raStatus.PostDeployJobs)
+	if !status.Finished() {
+		return raStatus, ctrl.Result{RequeueAfter: jobPollingInterval}, nil
+	}
+	if status.Succeeded() {
+		meta.SetStatusCondition(&raStatus.Conditions, metav1.Condition{
+			Type:   dreamkastv1alpha1.ReviewAppConditionPostDeployJobsSucceeded,
+			Status: metav1.ConditionTrue,
+			Reason: "Succeeded",
+		})
+	} else {
+		r.Recorder.Eventf(raSource, corev1.EventTypeWarning, "postDeployJob", "some of postDeployJobs did not succeed for commit %s of Infra Repository", infraRepoCommitHash)
+		meta.SetStatusCondition(&raStatus.Conditions, metav1.Condition{
+			Type:    dreamkastv1alpha1.ReviewAppConditionPostDeployJobsSucceeded,
+			Status:  metav1.ConditionFalse,
+			Reason:  "Failed",
+			Message: fmt.Sprintf("some of postDeployJobs did not succeed for commit %s of Infra Repository", infraRepoCommitHash),
+		})
+	}
+	return raStatus, ctrl.Result{}, nil
+}
+
+// startHookJobs creates Jobs from JobTemplates of the hook.
+// Jobs that cannot be created are recorded as failed so that other Jobs are not created twice.
+func (r *ReviewAppReconciler) startHookJobs(ctx context.Context, dto ReviewAppPhaseDTO, hookJobs []dreamkastv1alpha1.HookJob, hook string) ([]dreamkastv1alpha1.HookJobStatus, error) {
+	ra := dto.ReviewApp
+	raSource := ra.ToReviewAppCR()
+	pr := dto.PullRequest
+
+	// init templator
+	v, err := newTemplator(ctx, r.K8sRepository, ra, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]dreamkastv1alpha1.HookJobStatus, 0, len(hookJobs))
+	for _, j := range hookJobs {
+		job, err := r.createHookJob(ctx, ra, pr, v, j, hook)
+		if err != nil {
+			r.Recorder.Eventf(raSource, corev1.EventTypeWarning, fmt.Sprintf("failed to run %sJob", hook), "cannot create Job from JobTemplate %s: %s", models.HookJobKey(j), err)
+			statuses = append(statuses, dreamkastv1alpha1.HookJobStatus(models.NewFailedHookJobStatus(j, err.Error())))
+			continue
+		}
+		r.Recorder.Eventf(raSource, corev1.EventTypeNormal, fmt.Sprintf("running %sJob", hook), "running %sJob (%s/%s)", hook, job.Namespace, job.Name)
+		statuses = append(statuses, dreamkastv1alpha1.HookJobStatus(models.NewRunningHookJobStatus(j, job, datetimeFactoryForRA)))
+	}
+	return statuses, nil
+}
+
+func (r *ReviewAppReconciler) createHookJob(ctx context.Context, ra models.ReviewApp, pr models.PullRequest, v models.Templator, j dreamkastv1alpha1.HookJob, hook string) (*batchv1.Job, error) {
+	jt, err := r.K8sRepository.GetJobTemplate(ctx, j.Namespace, j.Name)
+	if err != nil {
+		return nil, err
+	}
+	job, err := jt.GenerateHookJob(ra, pr, v, hook)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.K8sRepository.CreateJob(ctx, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// updateHookJobs updates phases of running Jobs, and collects logs of Jobs that did not succeed
+func (r *ReviewAppReconciler) updateHookJobs(ctx context.Context, ra models.ReviewApp, statuses []dreamkastv1alpha1.HookJobStatus, hook string) ([]dreamkastv1alpha1.HookJobStatus, error) {
+	raSource := ra.ToReviewAppCR()
+	updated := make([]dreamkastv1alpha1.HookJobStatus, 0, len(statuses))
+	for _, s := range statuses {
+		if s.Phase != dreamkastv1alpha1.HookJobPhaseRunning {
+			updated = append(updated, s)
+			continue
+		}
+		job, err := r.K8sRepository.GetJob(ctx, s.JobNamespace, s.JobName)
+		if err != nil {
+			if !myerrors.IsNotFound(err) {
+				return nil, err
+			}
+			job = nil
+		}
+		newStatus, err := models.HookJobStatus(s).Update(job, datetimeFactoryForRA)
+		if err != nil {
+			return nil, err
+		}
+		if newStatus.Phase == dreamkastv1alpha1.HookJobPhaseFailed || newStatus.Phase == dreamkastv1alpha1.HookJobPhaseTimedOut {
+			if job != nil {
+				newStatus.Logs = r.getJobLogs(ctx, ra, job)
+			}
+			r.Recorder.Eventf(raSource, corev1.EventTypeWarning, fmt.Sprintf("failed to run %sJob", hook), "%sJob (%s/%s) is %s: %s%s",
+				hook, s.JobNamespace, s.JobName, newStatus.Phase, newStatus.Message, models.JobLogsForEvent(newStatus.Logs))
+		}
+		updated = append(updated, dreamkastv1alpha1.HookJobStatus(newStatus))
+	}
+	return updated, nil
+}
+
+// runPreDeployJobs creates Jobs specified by spec.preDeployJobs once per commit of PR, and collects their outputs.
+// It returns ready=true when manifests templated with the outputs can be pushed to Infra Repository.
+func (r *ReviewAppReconciler) runPreDeployJobs(ctx context.Context, dto ReviewAppPhaseDTO) (models.ReviewAppStatus, bool, ctrl.Result, error) {
+	ra := dto.ReviewApp
+	raSource := ra.ToReviewAppCR()
+	raStatus := ra.GetStatus()
+	appRepoCommitHash := dto.PullRequest.LatestCommitHash
+
+	prev := models.HookJobsStatus(raStatus.PreDeployJobs)
+	switch {
+	case !prev.HasRunForAppRepoCommit(appRepoCommitHash):
+		jobs, err := r.startHookJobs(ctx, dto, ra.Spec.PreDeployJobs, models.HookPreDeploy)
+		if err != nil {
+			return raStatus, false, ctrl.Result{}, err
+		}
+		// outputs of previous commit are kept until the Jobs succeed
+		raStatus.PreDeployJobs = dreamkastv1alpha1.HookJobsStatus{
+			AppRepoCommitHash: appRepoCommitHash,
+			Jobs:              jobs,
+			Outputs:           raStatus.PreDeployJobs.Outputs,
+		}
+	case !prev.Finished():
+		jobs, err := r.updateHookJobs(ctx, ra, raStatus.PreDeployJobs.Jobs, models.HookPreDeploy)
+		if err != nil {
+			return raStatus, false, ctrl.Result{}, err
+		}
+		raStatus.PreDeployJobs.Jobs = jobs
+	default:
+		// Jobs for this commit have already finished
+		return raStatus, prev.Succeeded(), ctrl.Result{}, nil
+	}
+
+	if !models.HookJobsStatus(raStatus.PreDeployJobs).Finished() {
+		meta.SetStatusCondition(&raStatus.Conditions, metav1.Condition{
+			Type:    dreamkastv1alpha1.ReviewAppConditionPreDeployJobsSucceeded,
+			Status:  metav1.ConditionUnknown,
+			Reason:  "Running",
+			Message: fmt.Sprintf("running preDeployJobs for commit %s of App Repository", appRepoCommitHash),
+		})
+		return raStatus, false, ctrl.Result{RequeueAfter: jobPollingInterval}, nil
+	}
+	if models.HookJobsStatus(raStatus.PreDeployJobs).Succeeded() {
+		jobs, outputs, err := r.collectHookJobOutputs(ctx, dto, ra.Spec.PreDeployJobs, raStatus.PreDeployJobs.Jobs)
+		if err != nil {
+			return raStatus, false, ctrl.Result{}, err
+		}
+		raStatus.PreDeployJobs.Jobs = jobs
+		raStatus.PreDeployJobs.Outputs = outputs
+	}
+	if !models.HookJobsStatus(raStatus.PreDeployJobs).Succeeded() {
+		r.Recorder.Eventf(raSource, corev1.EventTypeWarning, "preDeployJob", "some of preDeployJobs did not succeed for commit %s of App Repository, manifests are not pushed", appRepoCommitHash)
+		// results are always posted because manifests of the commit are not deployed until the Jobs are retried
+		status := models.HookJobsStatus(raStatus.PreDeployJobs)
+		message := status.Markdown("Pre-deploy Jobs")
+		if ra.Spec.AppConfig.ChatOps.Enabled {
+			message += fmt.Sprintf("\nComment `%s` to run the Jobs again.\n", models.ChatOpsCommandRetry)
+		}
+		if ra.Spec.JobLogs.PostToPullRequest {
+			message += "\n" + status.LogsMarkdown()
+		}
+		if err := r.commentToPullRequest(ctx, ra, dto.PullRequest, message); err != nil {
+			return raStatus, false, ctrl.Result{}, err
+		}
+		meta.SetStatusCondition(&raStatus.Conditions, metav1.Condition{
+			Type:    dreamkastv1alpha1.ReviewAppConditionPreDeployJobsSucceeded,
+			Status:  metav1.ConditionFalse,
+			Reason:  "Failed",
+			Message: fmt.Sprintf("some of preDeployJobs did not succeed for commit %s of App Repository", appRepoCommitHash),
+		})
+		return raStatus, false, ctrl.Result{}, nil
+	}
+	meta.SetStatusCondition(&raStatus.Conditions, metav1.Condition{
+		Type:   dreamkastv1alpha1.ReviewAppConditionPreDeployJobsSucceeded,
+		Status: metav1.ConditionTrue,
+		Reason: "Succeeded",
+	})
+	// requeue immediately so that manifests are templated again with the outputs
+	return raStatus, false, ctrl.Result{Requeue: true}, nil
+}
+
+// collectHookJobOutputs reads ConfigMaps written by succeeded Jobs.
+// The Job whose output ConfigMap is not found is marked as failed.
+func (r *ReviewAppReconciler) collectHookJobOutputs(ctx context.Context, dto ReviewAppPhaseDTO, hookJobs []dreamkastv1alpha1.HookJob, statuses []dreamkastv1alpha1.HookJobStatus) ([]dreamkastv1alpha1.HookJobStatus, map[string]string, error) {
+	v, err := newTemplator(ctx, r.K8sRepository, dto.ReviewApp, dto.PullRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+	outputConfigMaps := make(map[string]string)
+	for _, j := range hookJobs {
+		if j.OutputConfigMap != "" {
+			outputConfigMaps[models.HookJobKey(j)] = j.OutputConfigMap
+		}
+	}
+
+	updated := make([]dreamkastv1alpha1.HookJobStatus, 0, len(statuses))
+	outputs := make(map[string]string)
+	for _, s := range statuses {
+		name, ok := outputConfigMaps[s.JobTemplate]
+		if !ok {
+			updated = append(updated, s)
+			continue
+		}
+		name, err := v.Templating(name)
+		if err != nil {
+			return nil, nil, err
+		}
+		data, err := r.K8sRepository.GetConfigMapData(ctx, s.JobNamespace, name)
+		if err != nil {
+			if !myerrors.IsNotFound(err) {
+				return nil, nil, err
+			}
+			s.Phase = dreamkastv1alpha1.HookJobPhaseFailed
+			s.Message = fmt.Sprintf("output ConfigMap %s/%s is not found", s.JobNamespace, name)
+		}
+		for key, val := range data {
+			outputs[key] = val
+		}
+		updated = append(updated, s)
+	}
+	return updated, outputs, nil
+}
+
+// getJobLogs returns redacted logs of the Job.
+// Failure to get logs is only logged because logs are supplementary information.
+func (r *ReviewAppReconciler) getJobLogs(ctx context.Context, ra models.ReviewApp, job *batchv1.Job) string {
+	logs, err := r.K8sRepository.GetJobLogs(ctx, job, ra.JobLogsTailLines(), ra.JobLogsLimitBytes())
+	if err != nil {
+		r.Log.Error(err, fmt.Sprintf("cannot get logs of Job %s/%s", job.Namespace, job.Name))
+		return ""
+	}
+	var secretValues []string
+	if len(ra.VariablesFrom()) != 0 {
+		secretValues, err = r.K8sRepository.GetSecretValuesFrom(ctx, ra)
+		if err != nil {
+			// logs are dropped because they may contain values of Secrets
+			r.Log.Error(err, fmt.Sprintf("cannot get Secrets to redact logs of Job %s/%s", job.Namespace, job.Name))
+			return ""
+		}
+	}
+	return models.NewJobLogs(logs, ra.JobLogsLimitBytes(), secretValues...)
+}
@@ -2,10 +2,14 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	dreamkastv1alpha1 "github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
@@ -15,7 +19,11 @@ import (
 )
 
 const (
-	preStopJobTimeoutSecond = 300
+	jobPollingInterval      = 10 * time.Second
+	teardownPollingInterval = 10 * time.Second
+	chatOpsResyncPeriod     = time.Minute
+	// preStopJobBlockedInterval is interval to remind that deletion of ReviewApp is blocked by failed preStop Job
+	preStopJobBlockedInterval = 30 * time.Minute
 )
 
 var (
@@ -27,6 +35,8 @@ type ReviewAppPhaseDTO struct {
 	PullRequest models.PullRequest
 	Application models.Application
 	Manifests   models.Manifests
+	// ManifestsSources is which ManifestsTemplates contributed each file of Manifests
+	ManifestsSources models.ManifestsSources
 }
 
 func (r *ReviewAppReconciler) prepare(ctx context.Context, ra models.ReviewApp) (*ReviewAppPhaseDTO, ctrl.Result, error) {
@@ -44,9 +54,21 @@ func (r *ReviewAppReconciler) prepare(ctx context.Context, ra models.ReviewApp)
 		return nil, ctrl.Result{}, err
 	}
 	ra.Status = dreamkastv1alpha1.ReviewAppStatus(raStatus)
+	// use candidate templates if "/reviewapp use-candidate" command was run
+	if raStatus.ChatOps.UseCandidate {
+		pr.UseCandidate = true
+	}
+	// select variant of templates by spec.variantRules
+	pr, err = pr.WithVariant(ra.VariantRules())
+	if err != nil {
+		return nil, ctrl.Result{}, err
+	}
 
 	// template ApplicationTemplate & ManifestsTemplate
-	v := models.NewTemplator(ra, pr)
+	v, err := newTemplator(ctx, r.K8sRepository, ra, pr)
+	if err != nil {
+		return nil, ctrl.Result{}, err
+	}
 
 	// get ApplicationTemplate & template to applicationStr
 	at, err := r.K8sRepository.GetApplicationTemplate(ctx, ra)
@@ -63,16 +85,12 @@ func (r *ReviewAppReconciler) prepare(ctx context.Context, ra models.ReviewApp)
 	if err != nil {
 		return nil, ctrl.Result{}, err
 	}
-	var mt models.ManifestsTemplate
-	for _, mtOne := range mts {
-		mt = mt.AppendOrUpdate(mtOne)
-	}
-	manifests, err := mt.GenerateManifests(pr, v)
+	manifests, manifestsSources, err := models.GenerateMergedManifests(mts, pr, v, ra.MtDirpath())
 	if err != nil {
 		return nil, ctrl.Result{}, err
 	}
 
-	return &ReviewAppPhaseDTO{ra, pr, application, manifests}, ctrl.Result{}, nil
+	return &ReviewAppPhaseDTO{ra, pr, application, manifests, manifestsSources}, ctrl.Result{}, nil
 }
 
 func (r *ReviewAppReconciler) confirmUpdated(ctx context.Context, dto ReviewAppPhaseDTO) (models.ReviewAppStatus, ctrl.Result, error) {
@@ -91,30 +109,111 @@ func (r *ReviewAppReconciler) confirmUpdated(ctx context.Context, dto ReviewAppP
 	}
 	// Is ManifestsTemplate updated?
 	updatedMt := raStatus.WasManifestsUpdated(manifests)
+	// Was dry-run mode disabled after manifests were rendered in it?
+	// (manifests have been cached in dry-run mode but never pushed)
+	dryRunDisabled := ra.WasDryRunDisabled()
 
-	// update ReviewApp.Status
-	if updatedAppRepo || updatedAt || updatedMt {
-		raStatus.Sync.Status = dreamkastv1alpha1.SyncStatusCodeNeedToUpdateInfraRepo
+	if !updatedAppRepo && !updatedAt && !updatedMt && !dryRunDisabled {
+		return raStatus, ctrl.Result{}, nil
 	}
+
+	// run preDeploy Jobs & wait until they succeed before pushing manifests to InfraRepo
+	// (Jobs are not run in dry-run mode because manifests are not pushed)
+	if len(ra.Spec.PreDeployJobs) != 0 && !ra.Spec.DryRun {
+		// while suspended, Jobs are not run because manifests are not pushed
+		if ra.Spec.Suspend {
+			return ra.GetStatus(), ctrl.Result{}, nil
+		}
+		s, ready, result, err := r.runPreDeployJobs(ctx, dto)
+		if err != nil || !ready {
+			// status of AppRepo & templates is not updated so that the update is detected again after the Jobs finished
+			return s, result, err
+		}
+		raStatus.PreDeployJobs = s.PreDeployJobs
+		raStatus.Conditions = s.Conditions
+	}
+
+	// update ReviewApp.Status
+	raStatus.Sync.Status = dreamkastv1alpha1.SyncStatusCodeNeedToUpdateInfraRepo
 	return raStatus, ctrl.Result{}, nil
 }
 
 func (r *ReviewAppReconciler) deployReviewAppManifestsToInfraRepo(ctx context.Context, dto ReviewAppPhaseDTO) (models.ReviewAppStatus, ctrl.Result, error) {
 	ra := dto.ReviewApp
 	raStatus := ra.GetStatus()
-	infraRepoTarget := ra.InfraRepoTarget()
-	pr := dto.PullRequest
 	application := dto.Application
 	manifests := dto.Manifests
 
-	// set annotations to Argo CD Application
-	appWithAnnotations, err := application.SetSomeAnnotations(ra)
-	if err != nil {
+	// update Application & other manifests from ApplicationTemplate & ManifestsTemplate to InfraRepo
+	// 処理中に誰かが同一ブランチにpushすると s.gitCommand.CommitAndPush() に失敗するため、リトライする
+	var pushedLocalDir *models.InfraRepoLocalDir
+	var files []models.File
+	var validationErr error
+	if err := backoff.Retry(func() error {
+		var localDir models.InfraRepoLocalDir
+		var err error
+		localDir, files, validationErr, err = r.writeManifestsToLocalInfraRepo(ctx, dto)
+		if err != nil {
+			if myerrors.IsNotFound(err) || myerrors.IsKeyMissing(err) {
+				return backoff.Permanent(err)
+			}
+			return err
+		}
+		// manifests are not pushed if they are invalid (not retried)
+		if validationErr != nil {
+			return nil
+		}
+		// commmit & push
+		pushedLocalDir, err = r.GitCommandRepository.CommitAndPush(ctx, localDir, localDir.CommitMsgUpdate(ra))
+		if err != nil {
+			return err
+		}
+		return nil
+	}, backoffRetryCount); err != nil {
+		if myerrors.IsNotFound(err) || myerrors.IsKeyMissing(err) {
+			r.Log.Info(err.Error())
+			return raStatus, ctrl.Result{}, nil
+		}
 		return raStatus, ctrl.Result{}, err
 	}
 
-	// get gitRemoteRepo credential from Secret
-	gitRemoteRepoToken, err := r.K8sRepository.GetSecretValue(ctx, ra.Namespace, infraRepoTarget)
+	// if manifests are invalid, abort without pushing
+	if validationErr != nil {
+		return r.abortWithInvalidManifests(ctx, dto, validationErr)
+	}
+	meta.SetStatusCondition(&raStatus.Conditions, metav1.Condition{
+		Type:   dreamkastv1alpha1.ReviewAppConditionManifestsValid,
+		Status: metav1.ConditionTrue,
+		Reason: "ValidationSucceeded",
+	})
+
+	// update ReviewApp.Status
+	if pushedLocalDir != nil {
+		raStatus.Sync.InfraRepoLatestCommitHash = pushedLocalDir.LatestCommitHash()
+		raStatus.InfraRepoFiles = dreamkastv1alpha1.InfraRepoFiles{
+			Paths:      models.FilePaths(files),
+			CommitHash: pushedLocalDir.LatestCommitHash(),
+		}
+	}
+	raStatus.Sync.Status = dreamkastv1alpha1.SyncStatusCodeUpdatedInfraRepo
+	raStatus = raStatus.CacheManifests(application, manifests)
+	// the diff rendered in dry-run mode is stale after pushed
+	raStatus.DryRun = dreamkastv1alpha1.DryRunStatus{}
+	raStatus.ManifestsSources = dto.ManifestsSources
+
+	return raStatus, ctrl.Result{}, nil
+}
+
+// renderReviewAppManifestsForDryRun writes Application & other manifests to local clone of InfraRepo,
+// and records the diff to ReviewApp.Status instead of pushing it.
+func (r *ReviewAppReconciler) renderReviewAppManifestsForDryRun(ctx context.Context, dto ReviewAppPhaseDTO) (models.ReviewAppStatus, ctrl.Result, error) {
+	ra := dto.ReviewApp
+	raStatus := ra.GetStatus()
+	application := dto.Application
+	manifests := dto.Manifests
+
+	// write Application & other manifests to InfraRepo in the same way as deployReviewAppManifestsToInfraRepo
+	localDir, _, validationErr, err := r.writeManifestsToLocalInfraRepo(ctx, dto)
 	if err != nil {
 		if myerrors.IsNotFound(err) || myerrors.IsKeyMissing(err) {
 			r.Log.Info(err.Error())
@@ -122,42 +221,144 @@ func (r *ReviewAppReconciler) deployReviewAppManifestsToInfraRepo(ctx context.Co
 		}
 		return raStatus, ctrl.Result{}, err
 	}
-	if err := r.GitCommandRepository.WithCredential(models.NewGitCredential(ra.Spec.InfraTarget.Username, gitRemoteRepoToken)); err != nil {
+	if validationErr != nil {
+		return r.abortWithInvalidManifests(ctx, dto, validationErr)
+	}
+	meta.SetStatusCondition(&raStatus.Conditions, metav1.Condition{
+		Type:   dreamkastv1alpha1.ReviewAppConditionManifestsValid,
+		Status: metav1.ConditionTrue,
+		Reason: "ValidationSucceeded",
+	})
+	// take diff against InfraRepo without pushing
+	diff, err := r.GitCommandRepository.Diff(ctx, localDir)
+	if err != nil {
 		return raStatus, ctrl.Result{}, err
 	}
-
-	// update Application & other manifests from ApplicationTemplate & ManifestsTemplate to InfraRepo
-	// 処理中に誰かが同一ブランチにpushすると s.gitCommand.CommitAndPush() に失敗するため、リトライする
-	var localDir models.InfraRepoLocalDir
-	if err := backoff.Retry(func() error {
-		// clone
-		localDir, err = r.GitCommandRepository.ForceClone(ctx, infraRepoTarget)
+	// values of Secrets specified by variablesFrom are redacted from the diff recorded to status
+	var secretValues []string
+	if len(ra.VariablesFrom()) != 0 {
+		secretValues, err = r.K8sRepository.GetSecretValuesFrom(ctx, ra)
 		if err != nil {
-			return err
+			return raStatus, ctrl.Result{}, err
 		}
-		// create files
-		files := append([]models.File{}, models.NewFileFromApplication(ra, appWithAnnotations, pr, localDir))
-		files = append(files, models.NewFilesFromManifests(ra, manifests, pr, localDir)...)
-		if err := r.GitCommandRepository.CreateFiles(ctx, localDir, files...); err != nil {
-			return err
+	}
+	r.Recorder.Eventf(ra.ToReviewAppCR(), corev1.EventTypeNormal, "dryRun",
+		"rendered manifests without pushing to Infra Repository (%s)", localDir.LatestCommitHash())
+
+	// update ReviewApp.Status
+	// Sync.Status goes back to watching, because there is nothing to comment to PR of AppRepo
+	raStatus = raStatus.RecordDryRun(diff, localDir, datetimeFactoryForRA, secretValues...)
+	raStatus.Sync.Status = dreamkastv1alpha1.SyncStatusCodeWatchingAppRepoAndTemplates
+	raStatus = raStatus.CacheManifests(application, manifests)
+	raStatus.ManifestsSources = dto.ManifestsSources
+
+	return raStatus, ctrl.Result{}, nil
+}
+
+// writeManifestsToLocalInfraRepo clones InfraRepo with the credential, prunes files that were written at last
+// but are no longer rendered (e.g. dirpath was changed), and writes & validates Application & other manifests.
+// Error of validation is returned as validationErr separately from err, because it is not worth retrying.
+func (r *ReviewAppReconciler) writeManifestsToLocalInfraRepo(ctx context.Context, dto ReviewAppPhaseDTO) (localDir models.InfraRepoLocalDir, files []models.File, validationErr error, err error) {
+	ra := dto.ReviewApp
+	raStatus := ra.GetStatus()
+	infraRepoTarget := ra.InfraRepoTarget()
+	pr := dto.PullRequest
+
+	// set annotations to Argo CD Application
+	appWithAnnotations, err := dto.Application.SetSomeAnnotations(ra)
+	if err != nil {
+		return localDir, nil, nil, err
+	}
+
+	// get gitRemoteRepo credential from Secret
+	gitRemoteRepoToken, err := r.K8sRepository.GetSecretValue(ctx, ra.Namespace, infraRepoTarget)
+	if err != nil {
+		return localDir, nil, nil, err
+	}
+	if err := r.GitCommandRepository.WithCredential(models.NewGitCredential(ra.Spec.InfraTarget.Username, gitRemoteRepoToken)); err != nil {
+		return localDir, nil, nil, err
+	}
+
+	// clone
+	localDir, err = r.GitCommandRepository.ForceClone(ctx, infraRepoTarget)
+	if err != nil {
+		return localDir, nil, nil, err
+	}
+	// prune files that were written at last but are no longer rendered
+	files = append([]models.File{}, models.NewFileFromApplication(ra, appWithAnnotations, pr, localDir))
+	files = append(files, models.NewFilesFromManifests(ra, dto.Manifests, pr, localDir)...)
+	if staleFiles := raStatus.StaleInfraRepoFiles(files, localDir); len(staleFiles) != 0 {
+		if err := r.GitCommandRepository.DeleteFiles(ctx, localDir, staleFiles...); err != nil {
+			return localDir, nil, nil, err
 		}
-		// commmit & push
-		if _, err := r.GitCommandRepository.CommitAndPush(ctx, localDir, localDir.CommitMsgUpdate(ra)); err != nil {
-			return err
+	}
+	// create files
+	if err := r.GitCommandRepository.CreateFiles(ctx, localDir, files...); err != nil {
+		return localDir, nil, nil, err
+	}
+	// validate manifests
+	validationErr = r.ManifestsValidator.Validate(ctx, localDir, ra.MtDirpath())
+	return localDir, files, validationErr, nil
+}
+
+// abortWithInvalidManifests reports that manifests are invalid, and records the commit of AppRepo & templates
+// so that manifests are not validated again until either of them is updated.
+func (r *ReviewAppReconciler) abortWithInvalidManifests(ctx context.Context, dto ReviewAppPhaseDTO, validationErr error) (models.ReviewAppStatus, ctrl.Result, error) {
+	ra := dto.ReviewApp
+	raStatus := ra.GetStatus()
+	pr := dto.PullRequest
+	message := validationErr.Error()
+	r.Log.Info(fmt.Sprintf("manifests of %s/%s are invalid: %s", ra.Namespace, ra.Name, message))
+
+	// send message to PR of AppRepo only once for the same failure
+	if !raStatus.ManifestsInvalidatedWith(message) {
+		r.Recorder.Eventf(ra.ToReviewAppCR(), corev1.EventTypeWarning, "validation", "manifests are invalid: %s", message)
+		// PR of AppRepo is not commented in dry-run mode
+		if !ra.Spec.DryRun {
+			if err := r.commentToPullRequest(ctx, ra, pr, ra.MessageOfInvalidManifests(validationErr)); err != nil {
+				return raStatus, ctrl.Result{}, err
+			}
 		}
-		return nil
-	}, backoffRetryCount); err != nil {
-		return raStatus, ctrl.Result{}, err
 	}
 
 	// update ReviewApp.Status
-	raStatus.Sync.Status = dreamkastv1alpha1.SyncStatusCodeUpdatedInfraRepo
-	raStatus.ManifestsCache.Application = string(application)
-	raStatus.ManifestsCache.Manifests = manifests
-
+	meta.SetStatusCondition(&raStatus.Conditions, metav1.Condition{
+		Type:    dreamkastv1alpha1.ReviewAppConditionManifestsValid,
+		Status:  metav1.ConditionFalse,
+		Reason:  "ValidationFailed",
+		Message: message,
+	})
+	// the diff rendered in dry-run mode is discarded not to validate the manifests again after dry-run mode was disabled
+	if !ra.Spec.DryRun {
+		raStatus.DryRun = dreamkastv1alpha1.DryRunStatus{}
+	}
+	// commit of AppRepo has been recorded by confirmUpdated
+	raStatus.Sync.Status = dreamkastv1alpha1.SyncStatusCodeWatchingAppRepoAndTemplates
+	raStatus = raStatus.CacheManifests(dto.Application, dto.Manifests)
 	return raStatus, ctrl.Result{}, nil
 }
 
+// commentToPullRequest sends the message to PR of App Repository
+func (r *ReviewAppReconciler) commentToPullRequest(ctx context.Context, ra models.ReviewApp, pr models.PullRequest, message string) error {
+	gitRemoteRepoToken, err := r.K8sRepository.GetSecretValue(ctx, ra.Namespace, ra.AppRepoTarget())
+	if err != nil {
+		return err
+	}
+	if err := r.GitApiRepository.WithCredential(models.NewGitCredential(ra.Spec.AppTarget.Username, gitRemoteRepoToken)); err != nil {
+		return err
+	}
+	if err := r.GitApiRepository.CommentToPullRequest(ctx, pr, message); err != nil {
+		return err
+	}
+	// add metrics
+	metrics.RequestToGitHubApiCounterVec.WithLabelValues(
+		ra.Name,
+		ra.Namespace,
+		"ReviewApp",
+	).Add(1)
+	return nil
+}
+
 func (r *ReviewAppReconciler) commentToAppRepoPullRequest(ctx context.Context, dto ReviewAppPhaseDTO) (models.ReviewAppStatus, ctrl.Result, error) {
 	ra := dto.ReviewApp
 	raStatus := ra.GetStatus()
@@ -183,8 +384,40 @@ func (r *ReviewAppReconciler) commentToAppRepoPullRequest(ctx context.Context, d
 		return raStatus, ctrl.Result{}, nil
 	}
 
-	// send message to PR of AppRepo
+	// run postDeploy Jobs & wait until they finish
+	if len(ra.Spec.PostDeployJobs) != 0 {
+		var result ctrl.Result
+		raStatus, result, err = r.runPostDeployJobs(ctx, dto)
+		if err != nil || !models.HookJobsStatus(raStatus.PostDeployJobs).Finished() {
+			return raStatus, result, err
+		}
+	}
+
+	// build message to PR of AppRepo
+	var messages []string
 	if !ra.HasMessageAlreadyBeenSent() {
+		// template Message with commit hash of InfraRepo
+		v, err := newTemplator(ctx, r.K8sRepository, ra, pr)
+		if err != nil {
+			return raStatus, ctrl.Result{}, err
+		}
+		message, err := v.WithInfraRepoLatestCommitHash(raStatus.Sync.InfraRepoLatestCommitHash).
+			Templating(ra.Spec.AppConfig.Message)
+		if err != nil {
+			return raStatus, ctrl.Result{}, err
+		}
+		messages = append(messages, message)
+	}
+	if len(ra.Spec.PostDeployJobs) != 0 {
+		status := models.HookJobsStatus(raStatus.PostDeployJobs)
+		messages = append(messages, status.Markdown("Post-deploy Jobs"))
+		if logs := status.LogsMarkdown(); ra.Spec.JobLogs.PostToPullRequest && logs != "" {
+			messages = append(messages, logs)
+		}
+	}
+
+	// send message to PR of AppRepo
+	if len(messages) != 0 {
 		// get gitRemoteRepo credential from Secret
 		gitRemoteRepoToken, err := r.K8sRepository.GetSecretValue(ctx, ra.Namespace, appTarget)
 		if err != nil {
@@ -198,7 +431,7 @@ func (r *ReviewAppReconciler) commentToAppRepoPullRequest(ctx context.Context, d
 			return raStatus, ctrl.Result{}, err
 		}
 		// Send Message to AppRepo's PR
-		if err := r.GitApiRepository.CommentToPullRequest(ctx, pr, ra.Spec.AppConfig.Message); err != nil {
+		if err := r.GitApiRepository.CommentToPullRequest(ctx, pr, strings.Join(messages, "\n\n")); err != nil {
 			return raStatus, ctrl.Result{}, err
 		}
 		// add metrics
@@ -216,103 +449,378 @@ func (r *ReviewAppReconciler) commentToAppRepoPullRequest(ctx context.Context, d
 	return raStatus, ctrl.Result{}, nil
 }
 
-func (r *ReviewAppReconciler) reconcileDelete(ctx context.Context, dto ReviewAppPhaseDTO) (ctrl.Result, error) {
+func (r *ReviewAppReconciler) runChatOpsCommands(ctx context.Context, dto ReviewAppPhaseDTO) (models.ReviewAppStatus, ctrl.Result, error) {
 	ra := dto.ReviewApp
 	raSource := ra.ToReviewAppCR()
-	infraRepoTarget := ra.InfraRepoTarget()
+	raStatus := ra.GetStatus()
+	appTarget := ra.AppRepoTarget()
 	pr := dto.PullRequest
-	application := dto.Application
-	manifests := dto.Manifests
+	lifetime := ra.Spec.AppConfig.ChatOps.Lifetime
+
+	// stop ReviewApp if it has expired
+	raStatus, err := raStatus.UpdateExpiration(lifetime, datetimeFactoryForRA)
+	if err != nil {
+		return raStatus, ctrl.Result{}, err
+	}
+
+	// if comments of PR were read recently, early return
+	needToSync, err := raStatus.NeedToSyncChatOpsCommands(chatOpsResyncPeriod, datetimeFactoryForRA)
+	if err != nil {
+		return raStatus, ctrl.Result{}, err
+	}
+	if !needToSync {
+		return raStatus, ctrl.Result{}, nil
+	}
+
+	// get gitRemoteRepo credential from Secret
+	gitRemoteRepoToken, err := r.K8sRepository.GetSecretValue(ctx, ra.Namespace, appTarget)
+	if err != nil {
+		if myerrors.IsNotFound(err) || myerrors.IsKeyMissing(err) {
+			r.Log.Info(err.Error())
+			return raStatus, ctrl.Result{}, nil
+		}
+		return raStatus, ctrl.Result{}, err
+	}
+	if err := r.GitApiRepository.WithCredential(models.NewGitCredential(ra.Spec.AppTarget.Username, gitRemoteRepoToken)); err != nil {
+		return raStatus, ctrl.Result{}, err
+	}
+	comments, err := r.GitApiRepository.ListCommentsOfPullRequest(ctx, pr, raStatus.ChatOps.LastHandledCommentTimestamp)
+	if err != nil {
+		return raStatus, ctrl.Result{}, err
+	}
+	// add metrics
+	metrics.RequestToGitHubApiCounterVec.WithLabelValues(
+		ra.Name,
+		ra.Namespace,
+		"ReviewApp",
+	).Add(1)
+
+	// run commands in order of comments
+	for _, c := range comments.After(raStatus.ChatOps.LastHandledCommentID) {
+		if cmd, ok := models.NewChatOpsCommand(c); ok {
+			reaction := models.ReactionForSucceededCommand
+			permitted, err := r.GitApiRepository.HasWritePermission(ctx, pr, c.Username)
+			if err != nil {
+				return raStatus, ctrl.Result{}, err
+			}
+			if !permitted {
+				reaction = models.ReactionForDeniedCommand
+				r.Recorder.Eventf(raSource, corev1.EventTypeWarning, "chatops", "%s does not have permission to run \"%s\"", c.Username, cmd)
+			} else if s, err := cmd.Apply(raStatus, lifetime, datetimeFactoryForRA); err != nil {
+				reaction = models.ReactionForInvalidCommand
+				r.Recorder.Eventf(raSource, corev1.EventTypeWarning, "chatops", "failed to run \"%s\" by %s: %s", cmd, c.Username, err)
+			} else {
+				raStatus = s
+				r.Recorder.Eventf(raSource, corev1.EventTypeNormal, "chatops", "run \"%s\" by %s", cmd, c.Username)
+			}
+			if err := r.GitApiRepository.ReactToComment(ctx, pr, c.ID, reaction); err != nil {
+				return raStatus, ctrl.Result{}, err
+			}
+		}
+		raStatus.ChatOps.LastHandledCommentID = c.ID
+		raStatus.ChatOps.LastHandledCommentTimestamp = c.CreatedAt
+	}
+
+	// update ReviewApp.Status
+	raStatus.ChatOps.SyncTimestamp = datetimeFactoryForRA.Now().ToString()
+
+	return raStatus, ctrl.Result{}, nil
+}
+
+func (r *ReviewAppReconciler) stopReviewApp(ctx context.Context, dto ReviewAppPhaseDTO) (models.ReviewAppStatus, ctrl.Result, error) {
+	ra := dto.ReviewApp
+	raStatus := ra.GetStatus()
+
+	// delete Application & other manifests from InfraRepo
+	reason := raStatus.StopReason(datetimeFactoryForRA)
+	commitMsg := func(l models.InfraRepoLocalDir, ra models.ReviewApp) string {
+		return l.CommitMsgStop(ra, reason)
+	}
+	if err := r.deleteManifestsFromInfraRepo(ctx, dto, commitMsg); err != nil {
+		if myerrors.IsNotFound(err) || myerrors.IsKeyMissing(err) {
+			// stop is retried after the credential is fixed, so users are notified by Event
+			r.Log.Info(err.Error())
+			r.Recorder.Eventf(ra.ToReviewAppCR(), corev1.EventTypeWarning, "stop", "failed to stop ReviewApp (%s): %s", reason, err)
+			return raStatus, ctrl.Result{}, nil
+		}
+		return raStatus, ctrl.Result{}, err
+	}
+
+	// update ReviewApp.Status
+	raStatus.Sync.Status = dreamkastv1alpha1.SyncStatusCodeStopped
+	raStatus.ManifestsCache = dreamkastv1alpha1.ManifestsCache{}
+	raStatus.InfraRepoFiles = dreamkastv1alpha1.InfraRepoFiles{}
+
+	return raStatus, ctrl.Result{}, nil
+}
+
+func (r *ReviewAppReconciler) reconcileDelete(ctx context.Context, dto ReviewAppPhaseDTO) (ctrl.Result, error) {
+	ra := dto.ReviewApp
+	// in dry-run mode, nothing (including preStop Job) has to be run unless manifests were pushed before dry-run mode was enabled
+	if ra.Spec.DryRun && !ra.GetStatus().HasPushedToInfraRepo() {
+		if err := r.K8sRepository.RemoveFinalizersFromReviewApp(ctx, ra, finalizer); err != nil {
+			return ctrl.Result{}, err
+		}
+		r.removeMetrics(ra)
+		return ctrl.Result{}, nil
+	}
+
 	// run preStop Job
 	if ra.HavingPreStopJob() {
-		// init templator
-		v := models.NewTemplator(ra, pr)
+		done, result, err := r.runPreStopJob(ctx, dto)
+		if err != nil || !done {
+			return result, err
+		}
+	}
 
-		jt, err := r.K8sRepository.GetPreStopJobTemplate(ctx, ra)
-		if err != nil {
+	// delete Application & other manifests from InfraRepo
+	if !ra.GetStatus().HasTeardownStarted() {
+		if err := r.deleteManifestsFromInfraRepo(ctx, dto, models.InfraRepoLocalDir.CommitMsgDeletion); err != nil {
 			if myerrors.IsNotFound(err) {
 				r.Log.Info(err.Error())
-				r.Recorder.Eventf(raSource, corev1.EventTypeWarning, "preStopJob", "not found JobTemplate %s: %s", jt.Name, err)
+				return ctrl.Result{}, nil
 			}
-			goto finalize
+			return ctrl.Result{}, err
+		}
+		if ra.Spec.TeardownVerification.Enabled {
+			if err := r.startTeardown(ctx, &dto); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+	}
+
+	// wait until Argo CD tears down environment
+	if ra.Spec.TeardownVerification.Enabled {
+		done, result, err := r.waitForTeardown(ctx, dto)
+		if err != nil || !done {
+			return result, err
 		}
+	}
+
+	// Remove Finalizers
+	if err := r.K8sRepository.RemoveFinalizersFromReviewApp(ctx, ra, finalizer); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// remove metrics
+	r.removeMetrics(ra)
+
+	return ctrl.Result{}, nil
+}
 
-		// get Job Object
-		preStopJob, err := jt.GenerateJob(ra, pr, v)
+// startTeardown records to status.teardown that manifests have been deleted from Infra Repository,
+// so that they are not deleted again while waiting for environment to be torn down.
+func (r *ReviewAppReconciler) startTeardown(ctx context.Context, dto *ReviewAppPhaseDTO) error {
+	ra := dto.ReviewApp
+
+	var namespace string
+	if ns := ra.Spec.TeardownVerification.Namespace; ns != "" {
+		v, err := newTemplator(ctx, r.K8sRepository, ra, dto.PullRequest)
 		if err != nil {
-			r.Recorder.Eventf(raSource, corev1.EventTypeWarning, "failed to run preStopJob", "cannot unmarshal .spec.template of JobTemplate %s: %s", jt.Name, err)
-			goto finalize
+			return err
+		}
+		if namespace, err = v.Templating(ns); err != nil {
+			return err
 		}
+	}
+	ra.Status = dreamkastv1alpha1.ReviewAppStatus(ra.GetStatus().StartTeardown(namespace, datetimeFactoryForRA))
+	if err := r.K8sRepository.PatchReviewAppStatus(ctx, ra); err != nil {
+		return err
+	}
+	dto.ReviewApp = ra
+	return nil
+}
+
+// waitForTeardown checks that Argo CD Application and Namespace of ReviewApp have been deleted.
+// It returns done=true when they are gone or timeout has passed.
+func (r *ReviewAppReconciler) waitForTeardown(ctx context.Context, dto ReviewAppPhaseDTO) (bool, ctrl.Result, error) {
+	ra := dto.ReviewApp
+	raSource := ra.ToReviewAppCR()
+	raStatus := ra.GetStatus()
 
-		// create job & wait until Job completed on singleflight
-		r.Recorder.Eventf(raSource, corev1.EventTypeNormal, "running preStopJob", "running preStopJob (%s: %s)", models.LabelReviewAppNameForJob, ra.Name)
-		if err := r.K8sRepository.CreateJob(ctx, preStopJob); err != nil {
-			r.Recorder.Eventf(raSource, corev1.EventTypeWarning, "failed to run preStopJob", "cannot create Job (%s: %s): %s", models.LabelReviewAppNameForJob, ra.Name, err)
-			goto finalize
+	var remaining []string
+	if raStatus.Sync.ApplicationName != "" {
+		if _, err := r.K8sRepository.GetArgoCDAppFromReviewAppStatus(ctx, raStatus); err == nil {
+			remaining = append(remaining, fmt.Sprintf("Application %s/%s", raStatus.Sync.ApplicationNamespace, raStatus.Sync.ApplicationName))
+		} else if !myerrors.IsNotFound(err) {
+			return false, ctrl.Result{}, err
 		}
-		timeout := time.Now().Add(preStopJobTimeoutSecond * time.Second)
-		for {
-			if time.Since(timeout) >= 0 {
-				r.Recorder.Eventf(raSource, corev1.EventTypeWarning, "preStopJob is timeout", "preStopJob (%s: %s) is timeout (%ds)", models.LabelReviewAppNameForJob, ra.Name, preStopJobTimeoutSecond)
-				goto finalize
-			}
-			appliedPreStopJob, err := r.K8sRepository.GetLatestJobFromLabel(ctx, preStopJob.Namespace, models.LabelReviewAppNameForJob, ra.Name)
+	}
+	if ns := raStatus.Teardown.Namespace; ns != "" {
+		if _, err := r.K8sRepository.GetNamespace(ctx, ns); err == nil {
+			remaining = append(remaining, fmt.Sprintf("Namespace %s", ns))
+		} else if !myerrors.IsNotFound(err) {
+			return false, ctrl.Result{}, err
+		}
+	}
+	if len(remaining) == 0 {
+		r.Recorder.Eventf(raSource, corev1.EventTypeNormal, "teardown", "environment of ReviewApp has been torn down")
+		return true, ctrl.Result{}, nil
+	}
+
+	timedOut, err := raStatus.HasTeardownTimedOut(ra.TeardownTimeout(), datetimeFactoryForRA)
+	if err != nil {
+		return false, ctrl.Result{}, err
+	}
+	if timedOut {
+		r.Recorder.Eventf(raSource, corev1.EventTypeWarning, "teardown is timeout", "%s still exist after %s, give up waiting for teardown",
+			strings.Join(remaining, ", "), ra.TeardownTimeout())
+		return true, ctrl.Result{}, nil
+	}
+	r.Log.Info(fmt.Sprintf("waiting for teardown of ReviewApp %s/%s: %s", ra.Namespace, ra.Name, strings.Join(remaining, ", ")))
+	return false, ctrl.Result{RequeueAfter: teardownPollingInterval}, nil
+}
+
+// runPreStopJob advances the preStop Job by one step according to status.preStop.
+// It returns done=true when deletion of ReviewApp can go on.
+func (r *ReviewAppReconciler) runPreStopJob(ctx context.Context, dto ReviewAppPhaseDTO) (bool, ctrl.Result, error) {
+	ra := dto.ReviewApp
+	raSource := ra.ToReviewAppCR()
+	raStatus := ra.GetStatus()
+
+	switch raStatus.PreStop.Phase {
+	case "":
+		return r.createPreStopJob(ctx, dto, 0)
+	case dreamkastv1alpha1.PreStopPhaseRunning:
+		job, err := r.K8sRepository.GetJob(ctx, raStatus.PreStop.JobNamespace, raStatus.PreStop.JobName)
+		if err != nil && !myerrors.IsNotFound(err) {
+			return false, ctrl.Result{}, err
+		}
+		switch {
+		case err != nil:
+			r.Recorder.Eventf(raSource, corev1.EventTypeWarning, "failed to run preStopJob", "Job %s/%s is not found", raStatus.PreStop.JobNamespace, raStatus.PreStop.JobName)
+			raStatus.PreStop.Phase = dreamkastv1alpha1.PreStopPhaseFailed
+		case job.Status.Succeeded != 0:
+			r.Recorder.Eventf(raSource, corev1.EventTypeNormal, "finish preStopJob", "preStopJob (%s/%s) is succeeded", job.Namespace, job.Name)
+			raStatus.PreStop.Phase = dreamkastv1alpha1.PreStopPhaseSucceeded
+		case models.FailedConditionOfJob(job) != nil:
+			raStatus.PreStop.Logs = r.getJobLogs(ctx, ra, job)
+			r.Recorder.Eventf(raSource, corev1.EventTypeWarning, "failed to run preStopJob", "preStopJob (%s/%s) is failed%s", job.Namespace, job.Name, models.JobLogsForEvent(raStatus.PreStop.Logs))
+			raStatus.PreStop.Phase = dreamkastv1alpha1.PreStopPhaseFailed
+		default:
+			timedOut, err := raStatus.HasPreStopJobTimedOut(ra.PreStopJobTimeout(), datetimeFactoryForRA)
 			if err != nil {
-				return ctrl.Result{}, err
+				return false, ctrl.Result{}, err
+			}
+			if !timedOut {
+				return false, ctrl.Result{RequeueAfter: jobPollingInterval}, nil
 			}
-			if appliedPreStopJob.Status.Succeeded != 0 {
-				r.Recorder.Eventf(raSource, corev1.EventTypeNormal, "finish preStopJob", "preStopJob (%s: %s) is succeeded", models.LabelReviewAppNameForJob, ra.Name)
-				break
+			raStatus.PreStop.Logs = r.getJobLogs(ctx, ra, job)
+			r.Recorder.Eventf(raSource, corev1.EventTypeWarning, "preStopJob is timeout", "preStopJob (%s/%s) is timeout (%s)%s", job.Namespace, job.Name, ra.PreStopJobTimeout(), models.JobLogsForEvent(raStatus.PreStop.Logs))
+			raStatus.PreStop.Phase = dreamkastv1alpha1.PreStopPhaseTimedOut
+		}
+		if logs := raStatus.PreStop.Logs; ra.Spec.JobLogs.PostToPullRequest && logs != "" {
+			summary := fmt.Sprintf("Logs of preStopJob %s/%s (%s)", raStatus.PreStop.JobNamespace, raStatus.PreStop.JobName, raStatus.PreStop.Phase)
+			if err := r.commentToPullRequest(ctx, ra, dto.PullRequest, models.JobLogsMarkdown(summary, logs)); err != nil {
+				return false, ctrl.Result{}, err
 			}
-			time.Sleep(10 * time.Second)
 		}
+		ra.Status = dreamkastv1alpha1.ReviewAppStatus(raStatus)
+		if err := r.K8sRepository.PatchReviewAppStatus(ctx, ra); err != nil {
+			return false, ctrl.Result{}, err
+		}
+		dto.ReviewApp = ra
+		return r.runPreStopJob(ctx, dto)
+	case dreamkastv1alpha1.PreStopPhaseSucceeded:
+		return true, ctrl.Result{}, nil
 	}
 
-finalize:
-	// get gitRemoteRepo credential from Secret
-	gitRemoteRepoToken, err := r.K8sRepository.GetSecretValue(ctx, ra.Namespace, infraRepoTarget)
+	// preStop Job was failed or timed out
+	switch ra.PreStopJobFailurePolicy() {
+	case dreamkastv1alpha1.PreStopJobFailurePolicyBlock:
+		r.Log.Info(fmt.Sprintf("preStopJob of ReviewApp %s/%s is %s, keep ReviewApp until spec.preStopJobPolicy.failurePolicy is changed",
+			ra.Namespace, ra.Name, raStatus.PreStop.Phase))
+		r.Recorder.Eventf(raSource, corev1.EventTypeWarning, "preStopJob", "preStopJob (%s/%s) is %s, deletion is blocked until spec.preStopJobPolicy.failurePolicy is changed",
+			raStatus.PreStop.JobNamespace, raStatus.PreStop.JobName, raStatus.PreStop.Phase)
+		return false, ctrl.Result{RequeueAfter: preStopJobBlockedInterval}, nil
+	case dreamkastv1alpha1.PreStopJobFailurePolicyRetry:
+		if ra.CanRetryPreStopJob() {
+			return r.createPreStopJob(ctx, dto, raStatus.PreStop.Retries+1)
+		}
+		r.Recorder.Eventf(raSource, corev1.EventTypeWarning, "failed to run preStopJob", "preStopJob is %s after %d retries, continue deletion", raStatus.PreStop.Phase, raStatus.PreStop.Retries)
+	}
+	return true, ctrl.Result{}, nil
+}
+
+// createPreStopJob creates the preStop Job and records it to status.preStop.
+// When the Job cannot be created, deletion of ReviewApp goes on as it is meaningless to retry.
+func (r *ReviewAppReconciler) createPreStopJob(ctx context.Context, dto ReviewAppPhaseDTO, retries int32) (bool, ctrl.Result, error) {
+	ra := dto.ReviewApp
+	raSource := ra.ToReviewAppCR()
+	pr := dto.PullRequest
+
+	// init templator
+	v, err := newTemplator(ctx, r.K8sRepository, ra, pr)
+	if err != nil {
+		return false, ctrl.Result{}, err
+	}
+
+	jt, err := r.K8sRepository.GetPreStopJobTemplate(ctx, ra)
 	if err != nil {
 		if myerrors.IsNotFound(err) {
 			r.Log.Info(err.Error())
-			return ctrl.Result{}, nil
+			r.Recorder.Eventf(raSource, corev1.EventTypeWarning, "preStopJob", "not found JobTemplate %s: %s", jt.Name, err)
+			return true, ctrl.Result{}, nil
 		}
-		return ctrl.Result{}, err
+		return false, ctrl.Result{}, err
+	}
+
+	// get Job Object
+	preStopJob, err := jt.GenerateJob(ra, pr, v)
+	if err != nil {
+		r.Recorder.Eventf(raSource, corev1.EventTypeWarning, "failed to run preStopJob", "cannot unmarshal .spec.template of JobTemplate %s: %s", jt.Name, err)
+		return true, ctrl.Result{}, nil
+	}
+
+	// create Job & wait until Job completed by requeue
+	r.Recorder.Eventf(raSource, corev1.EventTypeNormal, "running preStopJob", "running preStopJob (%s: %s)", models.LabelReviewAppNameForJob, ra.Name)
+	if err := r.K8sRepository.CreateJob(ctx, preStopJob); err != nil {
+		r.Recorder.Eventf(raSource, corev1.EventTypeWarning, "failed to run preStopJob", "cannot create Job (%s: %s): %s", models.LabelReviewAppNameForJob, ra.Name, err)
+		return true, ctrl.Result{}, nil
+	}
+	ra.Status = dreamkastv1alpha1.ReviewAppStatus(ra.GetStatus().StartPreStopJob(preStopJob, retries, datetimeFactoryForRA))
+	if err := r.K8sRepository.PatchReviewAppStatus(ctx, ra); err != nil {
+		return false, ctrl.Result{}, err
+	}
+	return false, ctrl.Result{RequeueAfter: jobPollingInterval}, nil
+}
+
+func (r *ReviewAppReconciler) deleteManifestsFromInfraRepo(ctx context.Context, dto ReviewAppPhaseDTO, commitMsg func(models.InfraRepoLocalDir, models.ReviewApp) string) error {
+	ra := dto.ReviewApp
+	infraRepoTarget := ra.InfraRepoTarget()
+	pr := dto.PullRequest
+	application := dto.Application
+	manifests := dto.Manifests
+
+	// get gitRemoteRepo credential from Secret
+	gitRemoteRepoToken, err := r.K8sRepository.GetSecretValue(ctx, ra.Namespace, infraRepoTarget)
+	if err != nil {
+		return err
 	}
 	if err := r.GitCommandRepository.WithCredential(models.NewGitCredential(ra.Spec.InfraTarget.Username, gitRemoteRepoToken)); err != nil {
-		return ctrl.Result{}, err
+		return err
 	}
 
 	// 処理中に誰かが同一ブランチにpushすると s.gitCommand.CommitAndPush() に失敗するため、リトライする
 	var localDir models.InfraRepoLocalDir
-	if err := backoff.Retry(
+	return backoff.Retry(
 		func() error {
 			// clone
 			localDir, err = r.GitCommandRepository.ForceClone(ctx, infraRepoTarget)
 			if err != nil {
 				return err
 			}
-			// delete files
+			// delete files rendered from current templates & files written at last
 			files := append([]models.File{}, models.NewFileFromApplication(ra, application, pr, localDir))
 			files = append(files, models.NewFilesFromManifests(ra, manifests, pr, localDir)...)
+			files = append(files, ra.GetStatus().StaleInfraRepoFiles(files, localDir)...)
 			if err := r.GitCommandRepository.DeleteFiles(ctx, localDir, files...); err != nil {
 				return err
 			}
 			// commmit & push
-			if _, err := r.GitCommandRepository.CommitAndPush(ctx, localDir, localDir.CommitMsgDeletion(ra)); err != nil {
+			if _, err := r.GitCommandRepository.CommitAndPush(ctx, localDir, commitMsg(localDir, ra)); err != nil {
 				return err
 			}
 			return nil
-		}, backoffRetryCount); err != nil {
-		return ctrl.Result{}, err
-	}
-
-	// Remove Finalizers
-	if err := r.K8sRepository.RemoveFinalizersFromReviewApp(ctx, ra, finalizer); err != nil {
-		return ctrl.Result{}, err
-	}
-
-	// remove metrics
-	r.removeMetrics(ra)
-
-	return ctrl.Result{}, nil
+		}, backoffRetryCount)
 }
@@ -12,8 +12,13 @@ import (
 	"github.com/go-logr/glogr"
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 
@@ -23,6 +28,7 @@ import (
 	"github.com/cloudnativedaysjp/reviewapp-operator/domain/models"
 	"github.com/cloudnativedaysjp/reviewapp-operator/domain/repositories"
 	"github.com/cloudnativedaysjp/reviewapp-operator/domain/services"
+	myerrors "github.com/cloudnativedaysjp/reviewapp-operator/errors"
 	"github.com/cloudnativedaysjp/reviewapp-operator/utils"
 )
 
@@ -38,7 +44,9 @@ var (
 	testManifestsNormal,
 	testPreStopJtNormal,
 	testPreStopJobNormal = testutils.GenerateObjects("testset_normal")
-	testPrNormal = models.PullRequest{
+	testPreStopJobName       = "test-prestop-job"
+	testManifestsCacheNormal = models.ReviewAppStatus{}.CacheManifests(testAppNormal, testManifestsNormal).ManifestsCache
+	testPrNormal             = models.PullRequest{
 		Organization:     testRaNormal.AppRepoTarget().Organization,
 		Repository:       testRaNormal.AppRepoTarget().Repository,
 		Branch:           "test",
@@ -55,6 +63,14 @@ var (
 	testManifestsNormal_updated,
 	_,
 	_ = testutils.GenerateObjects("testset_normal_updated")
+
+	_,
+	_,
+	_,
+	_,
+	_,
+	testPreStopJtVariants,
+	testPreStopJobVariants = testutils.GenerateObjects("testset_variants")
 )
 
 func TestMain(m *testing.M) {
@@ -107,12 +123,80 @@ func TestReviewAppReconciler_prepare(t *testing.T) {
 			},
 			args: args{ra: testRaNormal},
 			wantDTO: &ReviewAppPhaseDTO{
-				ReviewApp:   testRaNormal,
-				PullRequest: testPrNormal,
+				ReviewApp: testRaNormal,
+				PullRequest: func() models.PullRequest {
+					pr := testPrNormal
+					pr.Variant = models.VariantStable
+					return pr
+				}(),
 				Application: testAppNormal,
 				Manifests:   testManifestsNormal,
+				ManifestsSources: func() models.ManifestsSources {
+					sources := make(models.ManifestsSources)
+					for filename := range testManifestsNormal {
+						sources[filename] = []string{testMtNormal.NamespacedNameString()}
+					}
+					return sources
+				}(),
+			},
+			wantResult: ctrl.Result{},
+		},
+		{
+			name: "testset_normal with strict templating & missing key",
+			fields: fields{
+				NumOfCalledRecorder: 0,
+				K8sRepository: func() repositories.KubernetesRepository {
+					mtWithTypo := testMtNormal
+					mtWithTypo.Spec.StableData = map[string]string{
+						"typo.yaml": "a: 1\nb: {{.Variables.AppRepositryAlias}}\n",
+					}
+					m := mock.NewMockKubernetesRepository(mockCtrl)
+					m.EXPECT().GetSecretValue(testCtx, testRaNormal.Namespace, testRaNormal.AppRepoTarget()).
+						Return(testSecretToken, nil)
+					m.EXPECT().GetApplicationTemplate(testCtx, testutil_withStrictTemplating(testRaNormal)).
+						Return(testAtNormal, nil)
+					m.EXPECT().GetManifestsTemplate(testCtx, testutil_withStrictTemplating(testRaNormal)).
+						Return([]models.ManifestsTemplate{mtWithTypo}, nil)
+					return m
+				},
+				PullRequestService: func() services.PullRequestServiceIface {
+					m := mock.NewMockPullRequestServiceIface(mockCtrl)
+					m.EXPECT().Get(testCtx, testutil_withStrictTemplating(testRaNormal), models.NewGitCredential(testRaNormal.AppRepoTarget().Username, testSecretToken), datetimeFactoryForRA).
+						Return(testPrNormal, models.ReviewAppStatus(testRaNormal.Status), nil)
+					return m
+				},
+			},
+			args:       args{ra: testutil_withStrictTemplating(testRaNormal)},
+			wantResult: ctrl.Result{},
+			wantErr:    true,
+		},
+		{
+			name: "testset_normal with conflicted ManifestsTemplates",
+			fields: fields{
+				NumOfCalledRecorder: 0,
+				K8sRepository: func() repositories.KubernetesRepository {
+					mtConflicted := testMtNormal
+					mtConflicted.Name = "conflicted"
+					mtConflicted.Spec.MergeStrategy = dreamkastv1alpha1.MergeStrategyErrorOnConflict
+					m := mock.NewMockKubernetesRepository(mockCtrl)
+					m.EXPECT().GetSecretValue(testCtx, testRaNormal.Namespace, testRaNormal.AppRepoTarget()).
+						Return(testSecretToken, nil)
+					m.EXPECT().GetApplicationTemplate(testCtx, testRaNormal).
+						Return(testAtNormal, nil)
+					m.EXPECT().GetManifestsTemplate(testCtx, testRaNormal).
+						Return([]models.ManifestsTemplate{testMtNormal, mtConflicted}, nil)
+					return m
+				},
+				PullRequestService: func() services.PullRequestServiceIface {
+					m := mock.NewMockPullRequestServiceIface(mockCtrl)
+					m.EXPECT().Get(testCtx, testRaNormal, models.NewGitCredential(testRaNormal.AppRepoTarget().Username, testSecretToken), datetimeFactoryForRA).
+						Return(testPrNormal, models.ReviewAppStatus(testRaNormal.Status), nil)
+					return m
+				},
 			},
+			args:       args{ra: testRaNormal},
 			wantResult: ctrl.Result{},
+			wantErr:    true,
 		},
 	}
 	for _, tt := range tests {
@@ -144,9 +228,41 @@ func TestReviewAppReconciler_prepare(t *testing.T) {
 func TestReviewAppReconciler_confirmUpdated(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
+	testHookJob := dreamkastv1alpha1.HookJob{Namespace: testPreStopJtNormal.Namespace, Name: testPreStopJtNormal.Name}
+	testRaWithPreDeployJobs := testutil_withReviewAppStatus(testRaNormal, "argocd", "sample-1", "updated-commit-hash")
+	testRaWithPreDeployJobs.Spec.PreDeployJobs = []dreamkastv1alpha1.HookJob{testHookJob}
+	testSucceededPreDeployJobs := dreamkastv1alpha1.HookJobsStatus{
+		AppRepoCommitHash: testPrNormal.LatestCommitHash,
+		Jobs: []dreamkastv1alpha1.HookJobStatus{{
+			JobTemplate:  models.HookJobKey(testHookJob),
+			JobName:      "test-predeploy-job",
+			JobNamespace: testPreStopJobNormal.Namespace,
+			Phase:        dreamkastv1alpha1.HookJobPhaseSucceeded,
+		}},
+		Outputs: map[string]string{"DB_NAME": "db_1"},
+	}
+
+	testFailedPreDeployJobs := dreamkastv1alpha1.HookJobsStatus{
+		AppRepoCommitHash: testPrNormal.LatestCommitHash,
+		Jobs: []dreamkastv1alpha1.HookJobStatus{{
+			JobTemplate:  models.HookJobKey(testHookJob),
+			JobName:      "test-predeploy-job",
+			JobNamespace: testPreStopJobNormal.Namespace,
+			Phase:        dreamkastv1alpha1.HookJobPhaseFailed,
+			Message:      "Job is not found",
+		}},
+	}
+	testRunningPreDeployJobs := testFailedPreDeployJobs
+	testRunningPreDeployJobs.Jobs = []dreamkastv1alpha1.HookJobStatus{testFailedPreDeployJobs.Jobs[0]}
+	testRunningPreDeployJobs.Jobs[0].Phase = dreamkastv1alpha1.HookJobPhaseRunning
+	testRunningPreDeployJobs.Jobs[0].Message = ""
+	testRaWithFailedPreDeployJobs := testRaWithPreDeployJobs
+	testRaWithFailedPreDeployJobs.Status.PreDeployJobs = testFailedPreDeployJobs
 
 	type fields struct {
 		NumOfCalledRecorder int
+		K8sRepository       func() repositories.KubernetesRepository
+		GitApiRepository    func() repositories.GitAPI
 	}
 	type args struct {
 		dto ReviewAppPhaseDTO
@@ -217,10 +333,7 @@ func TestReviewAppReconciler_confirmUpdated(t *testing.T) {
 					ApplicationNamespace: "argocd",
 					AlreadySentMessage:   true,
 				},
-				ManifestsCache: dreamkastv1alpha1.ManifestsCache{
-					Application: string(testAppNormal),
-					Manifests:   testManifestsNormal,
-				},
+				ManifestsCache: testManifestsCacheNormal,
 			}),
 			wantResult: ctrl.Result{},
 		},
@@ -250,10 +363,7 @@ func TestReviewAppReconciler_confirmUpdated(t *testing.T) {
 					ApplicationNamespace: "argocd",
 					AlreadySentMessage:   true,
 				},
-				ManifestsCache: dreamkastv1alpha1.ManifestsCache{
-					Application: string(testAppNormal),
-					Manifests:   testManifestsNormal,
-				},
+				ManifestsCache: testManifestsCacheNormal,
 			}),
 			wantResult: ctrl.Result{},
 		},
@@ -283,13 +393,198 @@ func TestReviewAppReconciler_confirmUpdated(t *testing.T) {
 					ApplicationNamespace: "argocd",
 					AlreadySentMessage:   true,
 				},
-				ManifestsCache: dreamkastv1alpha1.ManifestsCache{
-					Application: string(testAppNormal),
+				ManifestsCache: testManifestsCacheNormal,
+			}),
+			wantResult: ctrl.Result{},
+		},
+		{
+			name: "[normal] wait for preDeployJobs before pushing",
+			fields: fields{
+				NumOfCalledRecorder: 1,
+				K8sRepository: func() repositories.KubernetesRepository {
+					m := mock.NewMockKubernetesRepository(mockCtrl)
+					m.EXPECT().GetJobTemplate(testCtx, testHookJob.Namespace, testHookJob.Name).
+						Return(testPreStopJtNormal, nil)
+					m.EXPECT().CreateJob(testCtx, gomock.Any()).
+						Return(nil)
+					return m
+				},
+			},
+			args: args{
+				dto: ReviewAppPhaseDTO{
+					ReviewApp:   testRaWithPreDeployJobs,
+					PullRequest: testPrNormal,
+					Application: testAppNormal,
+					Manifests:   testManifestsNormal,
+				},
+			},
+			wantRaStatus: func() models.ReviewAppStatus {
+				// status of AppRepo is not updated until preDeployJobs succeed
+				s := testRaWithPreDeployJobs.GetStatus()
+				s.PreDeployJobs = dreamkastv1alpha1.HookJobsStatus{
+					AppRepoCommitHash: testPrNormal.LatestCommitHash,
+					Jobs: []dreamkastv1alpha1.HookJobStatus{{
+						JobTemplate:  models.HookJobKey(testHookJob),
+						JobNamespace: testPreStopJobNormal.Namespace,
+						Phase:        dreamkastv1alpha1.HookJobPhaseRunning,
+					}},
+				}
+				s.Conditions = []metav1.Condition{{
+					Type:    dreamkastv1alpha1.ReviewAppConditionPreDeployJobsSucceeded,
+					Status:  metav1.ConditionUnknown,
+					Reason:  "Running",
+					Message: fmt.Sprintf("running preDeployJobs for commit %s of App Repository", testPrNormal.LatestCommitHash),
+				}}
+				return s
+			}(),
+			wantResult: ctrl.Result{RequeueAfter: jobPollingInterval},
+		},
+		{
+			name: "[normal] preDeployJobs have succeeded",
+			fields: fields{
+				NumOfCalledRecorder: 0,
+			},
+			args: args{
+				dto: ReviewAppPhaseDTO{
+					ReviewApp: func() models.ReviewApp {
+						m := testRaWithPreDeployJobs
+						m.Status.PreDeployJobs = testSucceededPreDeployJobs
+						return m
+					}(),
+					PullRequest: testPrNormal,
+					Application: testAppNormal,
 					Manifests:   testManifestsNormal,
 				},
+			},
+			wantRaStatus: models.ReviewAppStatus(dreamkastv1alpha1.ReviewAppStatus{
+				Sync: dreamkastv1alpha1.SyncStatus{
+					Status: dreamkastv1alpha1.SyncStatusCodeNeedToUpdateInfraRepo,
+					SyncedPullRequest: dreamkastv1alpha1.ReviewAppStatusSyncedPullRequest{
+						Branch:           testPrNormal.Branch,
+						LatestCommitHash: testPrNormal.LatestCommitHash,
+						Title:            testPrNormal.Title,
+						Labels:           testPrNormal.Labels,
+					},
+					ApplicationName:      "sample-1",
+					ApplicationNamespace: "argocd",
+					AlreadySentMessage:   true,
+				},
+				ManifestsCache: testManifestsCacheNormal,
+				PreDeployJobs:  testSucceededPreDeployJobs,
 			}),
 			wantResult: ctrl.Result{},
 		},
+		{
+			name: "[normal] results of failed preDeployJobs are posted to PR",
+			fields: fields{
+				NumOfCalledRecorder: 2,
+				K8sRepository: func() repositories.KubernetesRepository {
+					m := mock.NewMockKubernetesRepository(mockCtrl)
+					m.EXPECT().GetJob(testCtx, testPreStopJobNormal.Namespace, "test-predeploy-job").
+						Return(nil, myerrors.NewK8sObjectNotFound(fmt.Errorf("not found"), schema.GroupVersionKind{}, types.NamespacedName{}))
+					m.EXPECT().GetSecretValue(testCtx, testRaNormal.Namespace, testRaNormal.AppRepoTarget()).
+						Return("test-token", nil)
+					return m
+				},
+				GitApiRepository: func() repositories.GitAPI {
+					m := mock.NewMockGitAPI(mockCtrl)
+					m.EXPECT().WithCredential(models.NewGitCredential(testRaNormal.AppRepoTarget().Username, "test-token")).
+						Return(nil)
+					// logs are not posted because spec.jobLogs.postToPullRequest is false
+					m.EXPECT().CommentToPullRequest(testCtx, testPrNormal, models.HookJobsStatus(testFailedPreDeployJobs).Markdown("Pre-deploy Jobs")).
+						Return(nil)
+					return m
+				},
+			},
+			args: args{
+				dto: ReviewAppPhaseDTO{
+					ReviewApp: func() models.ReviewApp {
+						m := testRaWithPreDeployJobs
+						m.Status.PreDeployJobs = testRunningPreDeployJobs
+						return m
+					}(),
+					PullRequest: testPrNormal,
+					Application: testAppNormal,
+					Manifests:   testManifestsNormal,
+				},
+			},
+			wantRaStatus: func() models.ReviewAppStatus {
+				s := testRaWithFailedPreDeployJobs.GetStatus()
+				s.Conditions = []metav1.Condition{{
+					Type:    dreamkastv1alpha1.ReviewAppConditionPreDeployJobsSucceeded,
+					Status:  metav1.ConditionFalse,
+					Reason:  "Failed",
+					Message: fmt.Sprintf("some of preDeployJobs did not succeed for commit %s of App Repository", testPrNormal.LatestCommitHash),
+				}}
+				return s
+			}(),
+			wantResult: ctrl.Result{},
+		},
+		{
+			name: "[normal] failed preDeployJobs are not run again for the same commit",
+			fields: fields{
+				NumOfCalledRecorder: 0,
+			},
+			args: args{
+				dto: ReviewAppPhaseDTO{
+					ReviewApp:   testRaWithFailedPreDeployJobs,
+					PullRequest: testPrNormal,
+					Application: testAppNormal,
+					Manifests:   testManifestsNormal,
+				},
+			},
+			wantRaStatus: testRaWithFailedPreDeployJobs.GetStatus(),
+			wantResult:   ctrl.Result{},
+		},
+		{
+			name: "[normal] failed preDeployJobs are run again after retry command",
+			fields: fields{
+				NumOfCalledRecorder: 1,
+				K8sRepository: func() repositories.KubernetesRepository {
+					m := mock.NewMockKubernetesRepository(mockCtrl)
+					m.EXPECT().GetJobTemplate(testCtx, testHookJob.Namespace, testHookJob.Name).
+						Return(testPreStopJtNormal, nil)
+					m.EXPECT().CreateJob(testCtx, gomock.Any()).
+						Return(nil)
+					return m
+				},
+			},
+			args: args{
+				dto: ReviewAppPhaseDTO{
+					ReviewApp: func() models.ReviewApp {
+						m := testRaWithFailedPreDeployJobs
+						s, err := models.ChatOpsCommand{Name: "retry"}.Apply(m.GetStatus(), "", datetimeFactoryForRA)
+						if err != nil {
+							t.Fatal(err)
+						}
+						m.Status = dreamkastv1alpha1.ReviewAppStatus(s)
+						return m
+					}(),
+					PullRequest: testPrNormal,
+					Application: testAppNormal,
+					Manifests:   testManifestsNormal,
+				},
+			},
+			wantRaStatus: func() models.ReviewAppStatus {
+				s := testRaWithPreDeployJobs.GetStatus()
+				s.PreDeployJobs = dreamkastv1alpha1.HookJobsStatus{
+					AppRepoCommitHash: testPrNormal.LatestCommitHash,
+					Jobs: []dreamkastv1alpha1.HookJobStatus{{
+						JobTemplate:  models.HookJobKey(testHookJob),
+						JobNamespace: testPreStopJobNormal.Namespace,
+						Phase:        dreamkastv1alpha1.HookJobPhaseRunning,
+					}},
+				}
+				s.Conditions = []metav1.Condition{{
+					Type:    dreamkastv1alpha1.ReviewAppConditionPreDeployJobsSucceeded,
+					Status:  metav1.ConditionUnknown,
+					Reason:  "Running",
+					Message: fmt.Sprintf("running preDeployJobs for commit %s of App Repository", testPrNormal.LatestCommitHash),
+				}}
+				return s
+			}(),
+			wantResult: ctrl.Result{RequeueAfter: jobPollingInterval},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -300,12 +595,21 @@ func TestReviewAppReconciler_confirmUpdated(t *testing.T) {
 				Scheme:   testScheme,
 				Recorder: record.NewFakeRecorder(tt.fields.NumOfCalledRecorder),
 			}
+			if tt.fields.K8sRepository != nil {
+				r.K8sRepository = tt.fields.K8sRepository()
+			}
+			if tt.fields.GitApiRepository != nil {
+				r.GitApiRepository = tt.fields.GitApiRepository()
+			}
 			raStatus, result, err := r.confirmUpdated(testCtx, tt.args.dto)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ReviewAppReconciler.confirmUpdated() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if diff := cmp.Diff(raStatus, tt.wantRaStatus); diff != "" {
+			if diff := cmp.Diff(raStatus, tt.wantRaStatus,
+				cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime"),
+				cmpopts.IgnoreFields(dreamkastv1alpha1.HookJobStatus{}, "StartTimestamp", "DeadlineTimestamp"),
+			); diff != "" {
 				t.Errorf("ReviewAppReconciler.confirmUpdated() is unexpected:\n%v", diff)
 			}
 			if diff := cmp.Diff(result, tt.wantResult); diff != "" {
@@ -318,12 +622,52 @@ func TestReviewAppReconciler_confirmUpdated(t *testing.T) {
 func TestReviewAppReconciler_deployReviewAppManifestsToInfraRepo(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
+	testSecretToken := "test-token"
+	testInfraRepoLatestCommitHash := "12345678"
+	testValidationErr := fmt.Errorf("kustomize build: invalid")
+	testRa := testutil_withReviewAppStatus(testRaNormal, "argocd", "test-ra-test-1", "testset_normal")
+	testRa.Status.Sync.Status = dreamkastv1alpha1.SyncStatusCodeNeedToUpdateInfraRepo
+
+	infraRepoTarget := testRa.InfraRepoTarget()
+	localDir := models.NewInfraRepoLocal(fmt.Sprintf("/tmp/%s/%s", infraRepoTarget.Organization, infraRepoTarget.Repository))
+	pushedLocalDir := localDir.SetLatestCommitHash(testInfraRepoLatestCommitHash)
+	testWrittenPaths := models.FilePaths(append(
+		[]models.File{models.NewFileFromApplication(testRa, testAppNormal, testPrNormal, localDir)},
+		models.NewFilesFromManifests(testRa, testManifestsNormal, testPrNormal, localDir)...,
+	))
+	testRaWithStaleFile := testRa
+	testRaWithStaleFile.Status.InfraRepoFiles = dreamkastv1alpha1.InfraRepoFiles{
+		Paths:      append([]string{"old/application.yaml"}, testWrittenPaths...),
+		CommitHash: "87654321",
+	}
+	gitCommandRepository := func(push bool, staleFiles ...models.File) func() repositories.GitCommand {
+		return func() repositories.GitCommand {
+			m := mock.NewMockGitCommand(mockCtrl)
+			m.EXPECT().WithCredential(models.NewGitCredential(infraRepoTarget.Username, testSecretToken)).
+				Return(nil)
+			m.EXPECT().ForceClone(testCtx, infraRepoTarget).
+				Return(localDir, nil)
+			if len(staleFiles) != 0 {
+				m.EXPECT().DeleteFiles(testCtx, localDir, staleFiles).
+					Return(nil)
+			}
+			// CreateFiles の引数は順不同なので gomock.Any を利用
+			m.EXPECT().CreateFiles(testCtx, localDir, gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(nil)
+			if push {
+				m.EXPECT().CommitAndPush(testCtx, localDir, localDir.CommitMsgUpdate(testRa)).
+					Return(&pushedLocalDir, nil)
+			}
+			return m
+		}
+	}
 
 	type fields struct {
 		NumOfCalledRecorder  int
 		K8sRepository        func() repositories.KubernetesRepository
 		GitApiRepository     func() repositories.GitAPI
 		GitCommandRepository func() repositories.GitCommand
+		ManifestsValidator   func() repositories.ManifestsValidator
 	}
 	type args struct {
 		dto ReviewAppPhaseDTO
@@ -336,7 +680,143 @@ func TestReviewAppReconciler_deployReviewAppManifestsToInfraRepo(t *testing.T) {
 		wantResult   ctrl.Result
 		wantErr      bool
 	}{
-		// TODO: Add test cases.
+		{
+			name: "[normal] manifests are valid",
+			fields: fields{
+				K8sRepository: func() repositories.KubernetesRepository {
+					m := mock.NewMockKubernetesRepository(mockCtrl)
+					m.EXPECT().GetSecretValue(testCtx, testRa.Namespace, infraRepoTarget).
+						Return(testSecretToken, nil)
+					return m
+				},
+				GitApiRepository: func() repositories.GitAPI {
+					return mock.NewMockGitAPI(mockCtrl)
+				},
+				GitCommandRepository: gitCommandRepository(true),
+				ManifestsValidator: func() repositories.ManifestsValidator {
+					m := mock.NewMockManifestsValidator(mockCtrl)
+					m.EXPECT().Validate(testCtx, localDir, testRa.MtDirpath()).
+						Return(nil)
+					return m
+				},
+			},
+			args: args{
+				dto: ReviewAppPhaseDTO{
+					ReviewApp:   testRa,
+					PullRequest: testPrNormal,
+					Application: testAppNormal,
+					Manifests:   testManifestsNormal,
+				},
+			},
+			wantRaStatus: func() models.ReviewAppStatus {
+				s := testRa.GetStatus()
+				s.Sync.Status = dreamkastv1alpha1.SyncStatusCodeUpdatedInfraRepo
+				s.Sync.InfraRepoLatestCommitHash = testInfraRepoLatestCommitHash
+				s.InfraRepoFiles = dreamkastv1alpha1.InfraRepoFiles{
+					Paths:      testWrittenPaths,
+					CommitHash: testInfraRepoLatestCommitHash,
+				}
+				s.Conditions = []metav1.Condition{{
+					Type:   dreamkastv1alpha1.ReviewAppConditionManifestsValid,
+					Status: metav1.ConditionTrue,
+					Reason: "ValidationSucceeded",
+				}}
+				return s
+			}(),
+		},
+		{
+			name: "[normal] prune files written at last but no longer rendered",
+			fields: fields{
+				K8sRepository: func() repositories.KubernetesRepository {
+					m := mock.NewMockKubernetesRepository(mockCtrl)
+					m.EXPECT().GetSecretValue(testCtx, testRa.Namespace, infraRepoTarget).
+						Return(testSecretToken, nil)
+					return m
+				},
+				GitApiRepository: func() repositories.GitAPI {
+					return mock.NewMockGitAPI(mockCtrl)
+				},
+				GitCommandRepository: gitCommandRepository(true, models.File{BaseDir: localDir.BaseDir(), Filepath: "old/application.yaml"}),
+				ManifestsValidator: func() repositories.ManifestsValidator {
+					m := mock.NewMockManifestsValidator(mockCtrl)
+					m.EXPECT().Validate(testCtx, localDir, testRa.MtDirpath()).
+						Return(nil)
+					return m
+				},
+			},
+			args: args{
+				dto: ReviewAppPhaseDTO{
+					ReviewApp:   testRaWithStaleFile,
+					PullRequest: testPrNormal,
+					Application: testAppNormal,
+					Manifests:   testManifestsNormal,
+				},
+			},
+			wantRaStatus: func() models.ReviewAppStatus {
+				s := testRaWithStaleFile.GetStatus()
+				s.Sync.Status = dreamkastv1alpha1.SyncStatusCodeUpdatedInfraRepo
+				s.Sync.InfraRepoLatestCommitHash = testInfraRepoLatestCommitHash
+				s.InfraRepoFiles = dreamkastv1alpha1.InfraRepoFiles{
+					Paths:      testWrittenPaths,
+					CommitHash: testInfraRepoLatestCommitHash,
+				}
+				s.Conditions = []metav1.Condition{{
+					Type:   dreamkastv1alpha1.ReviewAppConditionManifestsValid,
+					Status: metav1.ConditionTrue,
+					Reason: "ValidationSucceeded",
+				}}
+				return s
+			}(),
+		},
+		{
+			name: "[abnormal] manifests are invalid",
+			fields: fields{
+				NumOfCalledRecorder: 1,
+				K8sRepository: func() repositories.KubernetesRepository {
+					m := mock.NewMockKubernetesRepository(mockCtrl)
+					m.EXPECT().GetSecretValue(testCtx, testRa.Namespace, infraRepoTarget).
+						Return(testSecretToken, nil)
+					m.EXPECT().GetSecretValue(testCtx, testRa.Namespace, testRa.AppRepoTarget()).
+						Return(testSecretToken, nil)
+					return m
+				},
+				GitApiRepository: func() repositories.GitAPI {
+					m := mock.NewMockGitAPI(mockCtrl)
+					m.EXPECT().WithCredential(models.NewGitCredential(testRa.AppRepoTarget().Username, testSecretToken)).
+						Return(nil)
+					m.EXPECT().CommentToPullRequest(testCtx, testPrNormal, testRa.MessageOfInvalidManifests(testValidationErr)).
+						Return(nil)
+					return m
+				},
+				GitCommandRepository: gitCommandRepository(false),
+				ManifestsValidator: func() repositories.ManifestsValidator {
+					m := mock.NewMockManifestsValidator(mockCtrl)
+					m.EXPECT().Validate(testCtx, localDir, testRa.MtDirpath()).
+						Return(testValidationErr)
+					return m
+				},
+			},
+			args: args{
+				dto: ReviewAppPhaseDTO{
+					ReviewApp:   testRa,
+					PullRequest: testPrNormal,
+					Application: testAppNormal,
+					Manifests:   testManifestsNormal,
+				},
+			},
+			wantRaStatus: func() models.ReviewAppStatus {
+				// not validated again until AppRepo or templates are updated
+				s := testRa.GetStatus()
+				s.Sync.Status = dreamkastv1alpha1.SyncStatusCodeWatchingAppRepoAndTemplates
+				s.Conditions = []metav1.Condition{{
+					Type:    dreamkastv1alpha1.ReviewAppConditionManifestsValid,
+					Status:  metav1.ConditionFalse,
+					Reason:  "ValidationFailed",
+					Message: testValidationErr.Error(),
+				}}
+				return s
+			}(),
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -349,13 +829,14 @@ func TestReviewAppReconciler_deployReviewAppManifestsToInfraRepo(t *testing.T) {
 				K8sRepository:        tt.fields.K8sRepository(),
 				GitApiRepository:     tt.fields.GitApiRepository(),
 				GitCommandRepository: tt.fields.GitCommandRepository(),
+				ManifestsValidator:   tt.fields.ManifestsValidator(),
 			}
 			raStatus, result, err := r.deployReviewAppManifestsToInfraRepo(testCtx, tt.args.dto)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ReviewAppReconciler.deployReviewAppManifestsToInfraRepo() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if diff := cmp.Diff(raStatus, tt.wantRaStatus); diff != "" {
+			if diff := cmp.Diff(raStatus, tt.wantRaStatus, cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime")); diff != "" {
 				t.Errorf("ReviewAppReconciler.deployReviewAppManifestsToInfraRepo() is unexpected:\n%v", diff)
 			}
 			if diff := cmp.Diff(result, tt.wantResult); diff != "" {
@@ -365,15 +846,45 @@ func TestReviewAppReconciler_deployReviewAppManifestsToInfraRepo(t *testing.T) {
 	}
 }
 
-func TestReviewAppReconciler_commentToAppRepoPullRequest(t *testing.T) {
+func TestReviewAppReconciler_renderReviewAppManifestsForDryRun(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
+	testSecretToken := "test-token"
+	testInfraRepoLatestCommitHash := "12345678"
+	testDiff := "diff --git a/apps/test-ra-test-1.yaml b/apps/test-ra-test-1.yaml\n"
+	testDiffWithSecret := testDiff + "+  DB_PASS: s3cr3t\n"
+	testValidationErr := fmt.Errorf("kustomize build: invalid")
+	testRa := testutil_withDryRun(testutil_withReviewAppStatus(testRaNormal, "argocd", "test-ra-test-1", "testset_normal"))
+	testRa.Status.Sync.Status = dreamkastv1alpha1.SyncStatusCodeNeedToUpdateInfraRepo
+
+	infraRepoTarget := testRa.InfraRepoTarget()
+	localDir := models.NewInfraRepoLocal(fmt.Sprintf("/tmp/%s/%s", infraRepoTarget.Organization, infraRepoTarget.Repository)).
+		SetLatestCommitHash(testInfraRepoLatestCommitHash)
+	// Diff is not expected if diff is empty
+	gitCommandRepository := func(diff string) func() repositories.GitCommand {
+		return func() repositories.GitCommand {
+			m := mock.NewMockGitCommand(mockCtrl)
+			m.EXPECT().WithCredential(models.NewGitCredential(infraRepoTarget.Username, testSecretToken)).
+				Return(nil)
+			m.EXPECT().ForceClone(testCtx, infraRepoTarget).
+				Return(localDir, nil)
+			// CreateFiles の引数は順不同なので gomock.Any を利用
+			m.EXPECT().CreateFiles(testCtx, localDir, gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(nil)
+			if diff != "" {
+				m.EXPECT().Diff(testCtx, localDir).
+					Return(diff, nil)
+			}
+			return m
+		}
+	}
 
 	type fields struct {
 		NumOfCalledRecorder  int
 		K8sRepository        func() repositories.KubernetesRepository
 		GitApiRepository     func() repositories.GitAPI
 		GitCommandRepository func() repositories.GitCommand
+		ManifestsValidator   func() repositories.ManifestsValidator
 	}
 	type args struct {
 		dto ReviewAppPhaseDTO
@@ -386,30 +897,830 @@ func TestReviewAppReconciler_commentToAppRepoPullRequest(t *testing.T) {
 		wantResult   ctrl.Result
 		wantErr      bool
 	}{
-		// TODO: Add test cases.
-	}
-	for _, tt := range tests {
-		tt := tt
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-			r := &ReviewAppReconciler{
-				Log:                  testLogger,
-				Scheme:               testScheme,
-				Recorder:             record.NewFakeRecorder(tt.fields.NumOfCalledRecorder),
-				K8sRepository:        tt.fields.K8sRepository(),
-				GitApiRepository:     tt.fields.GitApiRepository(),
-				GitCommandRepository: tt.fields.GitCommandRepository(),
-			}
-			raStatus, result, err := r.commentToAppRepoPullRequest(testCtx, tt.args.dto)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("ReviewAppReconciler.commentToAppRepoPullRequest() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-			if diff := cmp.Diff(raStatus, tt.wantRaStatus); diff != "" {
+		{
+			name: "[normal] diff is recorded without pushing",
+			fields: fields{
+				NumOfCalledRecorder: 1,
+				K8sRepository: func() repositories.KubernetesRepository {
+					m := mock.NewMockKubernetesRepository(mockCtrl)
+					m.EXPECT().GetSecretValue(testCtx, testRa.Namespace, infraRepoTarget).
+						Return(testSecretToken, nil)
+					return m
+				},
+				GitApiRepository: func() repositories.GitAPI {
+					return mock.NewMockGitAPI(mockCtrl)
+				},
+				GitCommandRepository: gitCommandRepository(testDiff),
+				ManifestsValidator: func() repositories.ManifestsValidator {
+					m := mock.NewMockManifestsValidator(mockCtrl)
+					m.EXPECT().Validate(testCtx, localDir, testRa.MtDirpath()).
+						Return(nil)
+					return m
+				},
+			},
+			args: args{
+				dto: ReviewAppPhaseDTO{
+					ReviewApp:   testRa,
+					PullRequest: testPrNormal,
+					Application: testAppNormal,
+					Manifests:   testManifestsNormal,
+				},
+			},
+			wantRaStatus: func() models.ReviewAppStatus {
+				s := testRa.GetStatus()
+				s.Sync.Status = dreamkastv1alpha1.SyncStatusCodeWatchingAppRepoAndTemplates
+				s.ManifestsCache = testManifestsCacheNormal
+				s.DryRun = dreamkastv1alpha1.DryRunStatus{
+					InfraRepoCommitHash: testInfraRepoLatestCommitHash,
+					Diff:                testDiff,
+				}
+				s.Conditions = []metav1.Condition{{
+					Type:   dreamkastv1alpha1.ReviewAppConditionManifestsValid,
+					Status: metav1.ConditionTrue,
+					Reason: "ValidationSucceeded",
+				}}
+				return s
+			}(),
+		},
+		{
+			name: "[normal] values of Secrets are redacted from diff",
+			fields: fields{
+				NumOfCalledRecorder: 1,
+				K8sRepository: func() repositories.KubernetesRepository {
+					m := mock.NewMockKubernetesRepository(mockCtrl)
+					m.EXPECT().GetSecretValue(testCtx, testRa.Namespace, infraRepoTarget).
+						Return(testSecretToken, nil)
+					m.EXPECT().GetSecretValuesFrom(testCtx, gomock.Any()).
+						Return([]string{"s3cr3t"}, nil)
+					return m
+				},
+				GitApiRepository: func() repositories.GitAPI {
+					return mock.NewMockGitAPI(mockCtrl)
+				},
+				GitCommandRepository: gitCommandRepository(testDiffWithSecret),
+				ManifestsValidator: func() repositories.ManifestsValidator {
+					m := mock.NewMockManifestsValidator(mockCtrl)
+					m.EXPECT().Validate(testCtx, localDir, testRa.MtDirpath()).
+						Return(nil)
+					return m
+				},
+			},
+			args: args{
+				dto: ReviewAppPhaseDTO{
+					ReviewApp: func() models.ReviewApp {
+						m := testRa
+						m.Spec.VariablesFrom = []corev1.EnvFromSource{{
+							SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "db"}},
+						}}
+						return m
+					}(),
+					PullRequest: testPrNormal,
+					Application: testAppNormal,
+					Manifests:   testManifestsNormal,
+				},
+			},
+			wantRaStatus: func() models.ReviewAppStatus {
+				s := testRa.GetStatus()
+				s.Sync.Status = dreamkastv1alpha1.SyncStatusCodeWatchingAppRepoAndTemplates
+				s.ManifestsCache = testManifestsCacheNormal
+				s.DryRun = dreamkastv1alpha1.DryRunStatus{
+					InfraRepoCommitHash: testInfraRepoLatestCommitHash,
+					Diff:                testDiff + "+  DB_PASS: [REDACTED]\n",
+				}
+				s.Conditions = []metav1.Condition{{
+					Type:   dreamkastv1alpha1.ReviewAppConditionManifestsValid,
+					Status: metav1.ConditionTrue,
+					Reason: "ValidationSucceeded",
+				}}
+				return s
+			}(),
+		},
+		{
+			name: "[normal] manifests are invalid & PR is not commented",
+			fields: fields{
+				NumOfCalledRecorder: 1,
+				K8sRepository: func() repositories.KubernetesRepository {
+					m := mock.NewMockKubernetesRepository(mockCtrl)
+					m.EXPECT().GetSecretValue(testCtx, testRa.Namespace, infraRepoTarget).
+						Return(testSecretToken, nil)
+					return m
+				},
+				GitApiRepository: func() repositories.GitAPI {
+					return mock.NewMockGitAPI(mockCtrl)
+				},
+				GitCommandRepository: gitCommandRepository(""),
+				ManifestsValidator: func() repositories.ManifestsValidator {
+					m := mock.NewMockManifestsValidator(mockCtrl)
+					m.EXPECT().Validate(testCtx, localDir, testRa.MtDirpath()).
+						Return(testValidationErr)
+					return m
+				},
+			},
+			args: args{
+				dto: ReviewAppPhaseDTO{
+					ReviewApp:   testRa,
+					PullRequest: testPrNormal,
+					Application: testAppNormal,
+					Manifests:   testManifestsNormal,
+				},
+			},
+			wantRaStatus: func() models.ReviewAppStatus {
+				// not validated again until AppRepo or templates are updated
+				s := testRa.GetStatus()
+				s.Sync.Status = dreamkastv1alpha1.SyncStatusCodeWatchingAppRepoAndTemplates
+				s.Conditions = []metav1.Condition{{
+					Type:    dreamkastv1alpha1.ReviewAppConditionManifestsValid,
+					Status:  metav1.ConditionFalse,
+					Reason:  "ValidationFailed",
+					Message: testValidationErr.Error(),
+				}}
+				return s
+			}(),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := &ReviewAppReconciler{
+				Log:                  testLogger,
+				Scheme:               testScheme,
+				Recorder:             record.NewFakeRecorder(tt.fields.NumOfCalledRecorder),
+				K8sRepository:        tt.fields.K8sRepository(),
+				GitApiRepository:     tt.fields.GitApiRepository(),
+				GitCommandRepository: tt.fields.GitCommandRepository(),
+				ManifestsValidator:   tt.fields.ManifestsValidator(),
+			}
+			raStatus, result, err := r.renderReviewAppManifestsForDryRun(testCtx, tt.args.dto)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReviewAppReconciler.renderReviewAppManifestsForDryRun() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(raStatus, tt.wantRaStatus,
+				cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime"),
+				cmpopts.IgnoreFields(dreamkastv1alpha1.DryRunStatus{}, "RenderedTimestamp"),
+			); diff != "" {
+				t.Errorf("ReviewAppReconciler.renderReviewAppManifestsForDryRun() is unexpected:\n%v", diff)
+			}
+			if diff := cmp.Diff(result, tt.wantResult); diff != "" {
+				t.Errorf("result in ReviewAppReconciler.renderReviewAppManifestsForDryRun() is unexpected:\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestReviewAppReconciler_disableDryRun(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	testSecretToken := "test-token"
+	testInfraRepoLatestCommitHash := "12345678"
+	testRa := testutil_withDryRun(testutil_withReviewAppStatus(testRaNormal, "argocd", "sample-1", "old-commit-hash"))
+
+	infraRepoTarget := testRa.InfraRepoTarget()
+	localDir := models.NewInfraRepoLocal(fmt.Sprintf("/tmp/%s/%s", infraRepoTarget.Organization, infraRepoTarget.Repository)).
+		SetLatestCommitHash(testInfraRepoLatestCommitHash)
+	k8sRepository := mock.NewMockKubernetesRepository(mockCtrl)
+	k8sRepository.EXPECT().GetSecretValue(testCtx, testRa.Namespace, infraRepoTarget).
+		Return(testSecretToken, nil).Times(2)
+	gitCommandRepository := mock.NewMockGitCommand(mockCtrl)
+	gitCommandRepository.EXPECT().WithCredential(models.NewGitCredential(infraRepoTarget.Username, testSecretToken)).
+		Return(nil).Times(2)
+	gitCommandRepository.EXPECT().ForceClone(testCtx, infraRepoTarget).
+		Return(localDir, nil).Times(2)
+	gitCommandRepository.EXPECT().CreateFiles(testCtx, localDir, gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil).Times(2)
+	gitCommandRepository.EXPECT().Diff(testCtx, localDir).
+		Return("diff --git a/apps/sample-1.yaml b/apps/sample-1.yaml\n", nil)
+	gitCommandRepository.EXPECT().CommitAndPush(testCtx, localDir, gomock.Any()).
+		Return(&localDir, nil)
+	manifestsValidator := mock.NewMockManifestsValidator(mockCtrl)
+	manifestsValidator.EXPECT().Validate(testCtx, localDir, testRa.MtDirpath()).
+		Return(nil).Times(2)
+	r := &ReviewAppReconciler{
+		Log:                  testLogger,
+		Scheme:               testScheme,
+		Recorder:             record.NewFakeRecorder(1),
+		K8sRepository:        k8sRepository,
+		GitApiRepository:     mock.NewMockGitAPI(mockCtrl),
+		GitCommandRepository: gitCommandRepository,
+		ManifestsValidator:   manifestsValidator,
+	}
+	dto := ReviewAppPhaseDTO{
+		ReviewApp:   testRa,
+		PullRequest: testPrNormal,
+		Application: testAppNormal,
+		Manifests:   testManifestsNormal,
+	}
+	// runPhase runs the phase and sets the status to ReviewApp of dto for the next phase
+	runPhase := func(name string, f func(context.Context, ReviewAppPhaseDTO) (models.ReviewAppStatus, ctrl.Result, error), wantSyncStatus dreamkastv1alpha1.SyncStatusCode) {
+		t.Helper()
+		raStatus, _, err := f(testCtx, dto)
+		if err != nil {
+			t.Fatalf("ReviewAppReconciler.%s() error = %v", name, err)
+		}
+		if raStatus.Sync.Status != wantSyncStatus {
+			t.Fatalf("ReviewAppReconciler.%s() sync status = %v, want %v", name, raStatus.Sync.Status, wantSyncStatus)
+		}
+		dto.ReviewApp.Status = dreamkastv1alpha1.ReviewAppStatus(raStatus)
+	}
+
+	// render manifests in dry-run mode
+	runPhase("confirmUpdated", r.confirmUpdated, dreamkastv1alpha1.SyncStatusCodeNeedToUpdateInfraRepo)
+	runPhase("renderReviewAppManifestsForDryRun", r.renderReviewAppManifestsForDryRun, dreamkastv1alpha1.SyncStatusCodeWatchingAppRepoAndTemplates)
+	if dto.ReviewApp.Status.DryRun.RenderedTimestamp == "" {
+		t.Fatalf("ReviewAppReconciler.renderReviewAppManifestsForDryRun() does not record status.dryRun")
+	}
+	// nothing is updated while dry-run mode is enabled
+	runPhase("confirmUpdated", r.confirmUpdated, dreamkastv1alpha1.SyncStatusCodeWatchingAppRepoAndTemplates)
+
+	// manifests rendered in dry-run mode are pushed after dry-run mode is disabled
+	dto.ReviewApp.Spec.DryRun = false
+	runPhase("confirmUpdated", r.confirmUpdated, dreamkastv1alpha1.SyncStatusCodeNeedToUpdateInfraRepo)
+	runPhase("deployReviewAppManifestsToInfraRepo", r.deployReviewAppManifestsToInfraRepo, dreamkastv1alpha1.SyncStatusCodeUpdatedInfraRepo)
+	if diff := cmp.Diff(dto.ReviewApp.Status.DryRun, dreamkastv1alpha1.DryRunStatus{}); diff != "" {
+		t.Errorf("ReviewAppReconciler.deployReviewAppManifestsToInfraRepo() does not clear status.dryRun:\n%v", diff)
+	}
+	// nothing is updated after pushed
+	runPhase("confirmUpdated", r.confirmUpdated, dreamkastv1alpha1.SyncStatusCodeUpdatedInfraRepo)
+}
+
+func TestReviewAppReconciler_commentToAppRepoPullRequest(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	type fields struct {
+		NumOfCalledRecorder  int
+		K8sRepository        func() repositories.KubernetesRepository
+		GitApiRepository     func() repositories.GitAPI
+		GitCommandRepository func() repositories.GitCommand
+	}
+	type args struct {
+		dto ReviewAppPhaseDTO
+	}
+	tests := []struct {
+		name         string
+		fields       fields
+		args         args
+		wantRaStatus models.ReviewAppStatus
+		wantResult   ctrl.Result
+		wantErr      bool
+	}{
+		// TODO: Add test cases.
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := &ReviewAppReconciler{
+				Log:                  testLogger,
+				Scheme:               testScheme,
+				Recorder:             record.NewFakeRecorder(tt.fields.NumOfCalledRecorder),
+				K8sRepository:        tt.fields.K8sRepository(),
+				GitApiRepository:     tt.fields.GitApiRepository(),
+				GitCommandRepository: tt.fields.GitCommandRepository(),
+			}
+			raStatus, result, err := r.commentToAppRepoPullRequest(testCtx, tt.args.dto)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReviewAppReconciler.commentToAppRepoPullRequest() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(raStatus, tt.wantRaStatus); diff != "" {
 				t.Errorf("ReviewAppReconciler.commentToAppRepoPullRequest() is unexpected:\n%v", diff)
 			}
-			if diff := cmp.Diff(result, tt.wantResult); diff != "" {
-				t.Errorf("result in ReviewAppReconciler.commentToAppRepoPullRequest() is unexpected:\n%v", diff)
+			if diff := cmp.Diff(result, tt.wantResult); diff != "" {
+				t.Errorf("result in ReviewAppReconciler.commentToAppRepoPullRequest() is unexpected:\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestReviewAppReconciler_runPostDeployJobs(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	testInfraRepoLatestCommitHash := "12345678"
+	testHookJob := dreamkastv1alpha1.HookJob{Namespace: testPreStopJtNormal.Namespace, Name: testPreStopJtNormal.Name}
+	testRa := testutil_withReviewAppStatus(testRaNormal, "argocd", "sample-1", testPrNormal.LatestCommitHash)
+	testRa.Spec.PostDeployJobs = []dreamkastv1alpha1.HookJob{testHookJob}
+	testRa.Status.Sync.InfraRepoLatestCommitHash = testInfraRepoLatestCommitHash
+	testRunningJobStatus := dreamkastv1alpha1.HookJobStatus{
+		JobTemplate:       models.HookJobKey(testHookJob),
+		JobName:           "test-postdeploy-job",
+		JobNamespace:      testPreStopJobNormal.Namespace,
+		Phase:             dreamkastv1alpha1.HookJobPhaseRunning,
+		StartTimestamp:    "2022-01-01T00:00:00Z",
+		DeadlineTimestamp: "2999-01-01T00:00:00Z",
+	}
+	testRaRunning := testRa
+	testRaRunning.Status.PostDeployJobs = dreamkastv1alpha1.HookJobsStatus{
+		InfraRepoCommitHash: testInfraRepoLatestCommitHash,
+		Jobs:                []dreamkastv1alpha1.HookJobStatus{testRunningJobStatus},
+	}
+
+	type fields struct {
+		NumOfCalledRecorder int
+		K8sRepository       func() repositories.KubernetesRepository
+	}
+	type args struct {
+		dto ReviewAppPhaseDTO
+	}
+	tests := []struct {
+		name         string
+		fields       fields
+		args         args
+		wantRaStatus models.ReviewAppStatus
+		wantResult   ctrl.Result
+		wantErr      bool
+	}{
+		{
+			name: "[normal] create postDeployJobs for new commit",
+			fields: fields{
+				NumOfCalledRecorder: 1,
+				K8sRepository: func() repositories.KubernetesRepository {
+					m := mock.NewMockKubernetesRepository(mockCtrl)
+					m.EXPECT().GetJobTemplate(testCtx, testHookJob.Namespace, testHookJob.Name).
+						Return(testPreStopJtNormal, nil)
+					m.EXPECT().CreateJob(testCtx, gomock.Any()).
+						Return(nil)
+					return m
+				},
+			},
+			args: args{
+				dto: ReviewAppPhaseDTO{
+					ReviewApp:   testRa,
+					PullRequest: testPrNormal,
+				},
+			},
+			wantRaStatus: func() models.ReviewAppStatus {
+				s := testRa.GetStatus()
+				s.PostDeployJobs = dreamkastv1alpha1.HookJobsStatus{
+					AppRepoCommitHash:   testPrNormal.LatestCommitHash,
+					InfraRepoCommitHash: testInfraRepoLatestCommitHash,
+					Jobs: []dreamkastv1alpha1.HookJobStatus{{
+						JobTemplate:  models.HookJobKey(testHookJob),
+						JobNamespace: testPreStopJobNormal.Namespace,
+						Phase:        dreamkastv1alpha1.HookJobPhaseRunning,
+					}},
+				}
+				s.Conditions = []metav1.Condition{{
+					Type:    dreamkastv1alpha1.ReviewAppConditionPostDeployJobsSucceeded,
+					Status:  metav1.ConditionUnknown,
+					Reason:  "Running",
+					Message: fmt.Sprintf("running postDeployJobs for commit %s of Infra Repository", testInfraRepoLatestCommitHash),
+				}}
+				return s
+			}(),
+			wantResult: ctrl.Result{RequeueAfter: jobPollingInterval},
+		},
+		{
+			name: "[normal] postDeployJob failed",
+			fields: fields{
+				NumOfCalledRecorder: 2,
+				K8sRepository: func() repositories.KubernetesRepository {
+					job := testPreStopJobNormal
+					job.Status.Conditions = []batchv1.JobCondition{{
+						Type:    batchv1.JobFailed,
+						Status:  corev1.ConditionTrue,
+						Message: "Job has reached the specified backoff limit",
+					}}
+					m := mock.NewMockKubernetesRepository(mockCtrl)
+					m.EXPECT().GetJob(testCtx, testRunningJobStatus.JobNamespace, testRunningJobStatus.JobName).
+						Return(&job, nil)
+					m.EXPECT().GetJobLogs(testCtx, &job, int64(20), int64(4096)).
+						Return("==> test-job <==\nlogin with password=hunter2\nexit 1\n", nil)
+					return m
+				},
+			},
+			args: args{
+				dto: ReviewAppPhaseDTO{
+					ReviewApp:   testRaRunning,
+					PullRequest: testPrNormal,
+				},
+			},
+			wantRaStatus: func() models.ReviewAppStatus {
+				s := testRaRunning.GetStatus()
+				failed := testRunningJobStatus
+				failed.Phase = dreamkastv1alpha1.HookJobPhaseFailed
+				failed.Message = "Job has reached the specified backoff limit"
+				failed.Logs = "==> test-job <==\nlogin with password=[REDACTED]\nexit 1\n"
+				s.PostDeployJobs = dreamkastv1alpha1.HookJobsStatus{
+					InfraRepoCommitHash: testInfraRepoLatestCommitHash,
+					Jobs:                []dreamkastv1alpha1.HookJobStatus{failed},
+				}
+				s.Conditions = []metav1.Condition{{
+					Type:    dreamkastv1alpha1.ReviewAppConditionPostDeployJobsSucceeded,
+					Status:  metav1.ConditionFalse,
+					Reason:  "Failed",
+					Message: fmt.Sprintf("some of postDeployJobs did not succeed for commit %s of Infra Repository", testInfraRepoLatestCommitHash),
+				}}
+				return s
+			}(),
+		},
+		{
+			name: "[normal] values of Secrets in variablesFrom are redacted from logs",
+			fields: fields{
+				NumOfCalledRecorder: 2,
+				K8sRepository: func() repositories.KubernetesRepository {
+					job := testPreStopJobNormal
+					job.Status.Conditions = []batchv1.JobCondition{{
+						Type:    batchv1.JobFailed,
+						Status:  corev1.ConditionTrue,
+						Message: "Job has reached the specified backoff limit",
+					}}
+					m := mock.NewMockKubernetesRepository(mockCtrl)
+					m.EXPECT().GetJob(testCtx, testRunningJobStatus.JobNamespace, testRunningJobStatus.JobName).
+						Return(&job, nil)
+					m.EXPECT().GetJobLogs(testCtx, &job, int64(20), int64(4096)).
+						Return("==> test-job <==\nconnect to mysql://app:s3cr3t@db\nDB_PASS s3cr3t is invalid\n", nil)
+					m.EXPECT().GetSecretValuesFrom(testCtx, gomock.Any()).
+						Return([]string{"s3cr3t"}, nil)
+					return m
+				},
+			},
+			args: args{
+				dto: ReviewAppPhaseDTO{
+					ReviewApp: func() models.ReviewApp {
+						m := testRaRunning
+						m.Spec.VariablesFrom = []corev1.EnvFromSource{{
+							SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "db"}},
+						}}
+						return m
+					}(),
+					PullRequest: testPrNormal,
+				},
+			},
+			wantRaStatus: func() models.ReviewAppStatus {
+				s := testRaRunning.GetStatus()
+				failed := testRunningJobStatus
+				failed.Phase = dreamkastv1alpha1.HookJobPhaseFailed
+				failed.Message = "Job has reached the specified backoff limit"
+				failed.Logs = "==> test-job <==\nconnect to mysql://app:[REDACTED]@db\nDB_PASS [REDACTED] is invalid\n"
+				s.PostDeployJobs = dreamkastv1alpha1.HookJobsStatus{
+					InfraRepoCommitHash: testInfraRepoLatestCommitHash,
+					Jobs:                []dreamkastv1alpha1.HookJobStatus{failed},
+				}
+				s.Conditions = []metav1.Condition{{
+					Type:    dreamkastv1alpha1.ReviewAppConditionPostDeployJobsSucceeded,
+					Status:  metav1.ConditionFalse,
+					Reason:  "Failed",
+					Message: fmt.Sprintf("some of postDeployJobs did not succeed for commit %s of Infra Repository", testInfraRepoLatestCommitHash),
+				}}
+				return s
+			}(),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := &ReviewAppReconciler{
+				Log:           testLogger,
+				Scheme:        testScheme,
+				Recorder:      record.NewFakeRecorder(tt.fields.NumOfCalledRecorder),
+				K8sRepository: tt.fields.K8sRepository(),
+			}
+			raStatus, result, err := r.runPostDeployJobs(testCtx, tt.args.dto)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReviewAppReconciler.runPostDeployJobs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(raStatus, tt.wantRaStatus,
+				cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime"),
+				cmpopts.IgnoreFields(dreamkastv1alpha1.HookJobStatus{}, "StartTimestamp", "DeadlineTimestamp"),
+			); diff != "" {
+				t.Errorf("ReviewAppReconciler.runPostDeployJobs() is unexpected:\n%v", diff)
+			}
+			if diff := cmp.Diff(result, tt.wantResult); diff != "" {
+				t.Errorf("result in ReviewAppReconciler.runPostDeployJobs() is unexpected:\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestReviewAppReconciler_runChatOpsCommands(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	testSecretToken := "test-token"
+	testRaWithChatOps := testutil_withReviewAppStatus(testRaNormal, "argocd", "sample-1", testPrNormal.LatestCommitHash)
+	testRaWithChatOps.Spec.AppConfig.ChatOps.Enabled = true
+
+	type fields struct {
+		NumOfCalledRecorder int
+		K8sRepository       func() repositories.KubernetesRepository
+		GitApiRepository    func() repositories.GitAPI
+	}
+	type args struct {
+		dto ReviewAppPhaseDTO
+	}
+	tests := []struct {
+		name         string
+		fields       fields
+		args         args
+		wantRaStatus models.ReviewAppStatus
+		wantResult   ctrl.Result
+		wantErr      bool
+	}{
+		{
+			name: "[normal] stop command by permitted user & ignore other comments",
+			fields: fields{
+				NumOfCalledRecorder: 1,
+				K8sRepository: func() repositories.KubernetesRepository {
+					m := mock.NewMockKubernetesRepository(mockCtrl)
+					m.EXPECT().GetSecretValue(testCtx, testRaWithChatOps.Namespace, testRaWithChatOps.AppRepoTarget()).
+						Return(testSecretToken, nil)
+					return m
+				},
+				GitApiRepository: func() repositories.GitAPI {
+					m := mock.NewMockGitAPI(mockCtrl)
+					m.EXPECT().WithCredential(models.NewGitCredential(testRaWithChatOps.AppRepoTarget().Username, testSecretToken)).
+						Return(nil)
+					m.EXPECT().ListCommentsOfPullRequest(testCtx, testPrNormal, "").
+						Return(models.PullRequestComments{
+							{ID: 1, Username: "alice", Body: "LGTM", CreatedAt: "2021-01-01T00:00:00Z"},
+							{ID: 2, Username: "bob", Body: "/reviewapp stop", CreatedAt: "2021-01-01T00:01:00Z"},
+						}, nil)
+					m.EXPECT().HasWritePermission(testCtx, testPrNormal, "bob").
+						Return(true, nil)
+					m.EXPECT().ReactToComment(testCtx, testPrNormal, int64(2), models.ReactionForSucceededCommand).
+						Return(nil)
+					return m
+				},
+			},
+			args: args{
+				dto: ReviewAppPhaseDTO{
+					ReviewApp:   testRaWithChatOps,
+					PullRequest: testPrNormal,
+					Application: testAppNormal,
+					Manifests:   testManifestsNormal,
+				},
+			},
+			wantRaStatus: func() models.ReviewAppStatus {
+				s := testRaWithChatOps.GetStatus()
+				s.ChatOps = dreamkastv1alpha1.ChatOpsStatus{
+					Stopped:                     true,
+					LastHandledCommentID:        2,
+					LastHandledCommentTimestamp: "2021-01-01T00:01:00Z",
+				}
+				return s
+			}(),
+			wantResult: ctrl.Result{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := &ReviewAppReconciler{
+				Log:              testLogger,
+				Scheme:           testScheme,
+				Recorder:         record.NewFakeRecorder(tt.fields.NumOfCalledRecorder),
+				K8sRepository:    tt.fields.K8sRepository(),
+				GitApiRepository: tt.fields.GitApiRepository(),
+			}
+			raStatus, result, err := r.runChatOpsCommands(testCtx, tt.args.dto)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReviewAppReconciler.runChatOpsCommands() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(raStatus, tt.wantRaStatus, cmpopts.IgnoreFields(dreamkastv1alpha1.ChatOpsStatus{}, "SyncTimestamp")); diff != "" {
+				t.Errorf("ReviewAppReconciler.runChatOpsCommands() is unexpected:\n%v", diff)
+			}
+			if diff := cmp.Diff(result, tt.wantResult); diff != "" {
+				t.Errorf("result in ReviewAppReconciler.runChatOpsCommands() is unexpected:\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestReviewAppReconciler_stopReviewApp(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	testSecretToken := "test-token"
+	testInfraRepoLatestCommitHash := "12345678"
+	testRaExpired := testRaNormal
+	testRaExpired.Status.ChatOps.Stopped = true
+	testRaExpired.Status.ChatOps.ExpiresAt = "2022-01-01T00:00:00Z"
+	testRaStoppedByCommand := testRaNormal
+	testRaStoppedByCommand.Status.ChatOps.Stopped = true
+
+	infraRepoTarget := testRaNormal.InfraRepoTarget()
+	localDir := models.NewInfraRepoLocal(fmt.Sprintf("/tmp/%s/%s", infraRepoTarget.Organization, infraRepoTarget.Repository)).SetLatestCommitHash(testInfraRepoLatestCommitHash)
+	gitCommandRepository := func(ra models.ReviewApp, reason string) func() repositories.GitCommand {
+		return func() repositories.GitCommand {
+			m := mock.NewMockGitCommand(mockCtrl)
+			m.EXPECT().WithCredential(models.NewGitCredential(infraRepoTarget.Username, testSecretToken)).
+				Return(nil)
+			m.EXPECT().ForceClone(testCtx, infraRepoTarget).
+				Return(localDir, nil)
+			// DeleteFiles の引数は順不同なので gomock.Any を利用
+			m.EXPECT().DeleteFiles(testCtx, localDir, gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(nil)
+			m.EXPECT().CommitAndPush(testCtx, localDir, localDir.CommitMsgStop(ra, reason))
+			return m
+		}
+	}
+
+	type fields struct {
+		NumOfCalledRecorder  int
+		K8sRepository        func() repositories.KubernetesRepository
+		GitCommandRepository func() repositories.GitCommand
+	}
+	type args struct {
+		dto ReviewAppPhaseDTO
+	}
+	tests := []struct {
+		name         string
+		fields       fields
+		args         args
+		wantRaStatus models.ReviewAppStatus
+		wantErr      bool
+	}{
+		{
+			name: "[normal] stopped by lifetime",
+			fields: fields{
+				K8sRepository: func() repositories.KubernetesRepository {
+					m := mock.NewMockKubernetesRepository(mockCtrl)
+					m.EXPECT().GetSecretValue(testCtx, testRaExpired.Namespace, infraRepoTarget).
+						Return(testSecretToken, nil)
+					return m
+				},
+				GitCommandRepository: gitCommandRepository(testRaExpired, models.StopReasonExpired),
+			},
+			args: args{
+				dto: ReviewAppPhaseDTO{
+					ReviewApp:   testRaExpired,
+					PullRequest: testPrNormal,
+					Application: testAppNormal,
+					Manifests:   testManifestsNormal,
+				},
+			},
+			wantRaStatus: func() models.ReviewAppStatus {
+				s := testRaExpired.GetStatus()
+				s.Sync.Status = dreamkastv1alpha1.SyncStatusCodeStopped
+				return s
+			}(),
+		},
+		{
+			name: "[normal] stopped by ChatOps command",
+			fields: fields{
+				K8sRepository: func() repositories.KubernetesRepository {
+					m := mock.NewMockKubernetesRepository(mockCtrl)
+					m.EXPECT().GetSecretValue(testCtx, testRaStoppedByCommand.Namespace, infraRepoTarget).
+						Return(testSecretToken, nil)
+					return m
+				},
+				GitCommandRepository: gitCommandRepository(testRaStoppedByCommand, models.StopReasonCommand),
+			},
+			args: args{
+				dto: ReviewAppPhaseDTO{
+					ReviewApp:   testRaStoppedByCommand,
+					PullRequest: testPrNormal,
+					Application: testAppNormal,
+					Manifests:   testManifestsNormal,
+				},
+			},
+			wantRaStatus: func() models.ReviewAppStatus {
+				s := testRaStoppedByCommand.GetStatus()
+				s.Sync.Status = dreamkastv1alpha1.SyncStatusCodeStopped
+				return s
+			}(),
+		},
+		{
+			name: "[normal] Secret of Infra Repository is not found",
+			fields: fields{
+				NumOfCalledRecorder: 1,
+				K8sRepository: func() repositories.KubernetesRepository {
+					m := mock.NewMockKubernetesRepository(mockCtrl)
+					m.EXPECT().GetSecretValue(testCtx, testRaStoppedByCommand.Namespace, infraRepoTarget).
+						Return("", myerrors.NewK8sObjectNotFound(fmt.Errorf("not found"), schema.GroupVersionKind{}, types.NamespacedName{}))
+					return m
+				},
+				GitCommandRepository: func() repositories.GitCommand {
+					return mock.NewMockGitCommand(mockCtrl)
+				},
+			},
+			args: args{
+				dto: ReviewAppPhaseDTO{
+					ReviewApp:   testRaStoppedByCommand,
+					PullRequest: testPrNormal,
+					Application: testAppNormal,
+					Manifests:   testManifestsNormal,
+				},
+			},
+			wantRaStatus: testRaStoppedByCommand.GetStatus(),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			recorder := record.NewFakeRecorder(tt.fields.NumOfCalledRecorder)
+			r := &ReviewAppReconciler{
+				Log:                  testLogger,
+				Scheme:               testScheme,
+				Recorder:             recorder,
+				K8sRepository:        tt.fields.K8sRepository(),
+				GitCommandRepository: tt.fields.GitCommandRepository(),
+			}
+			raStatus, _, err := r.stopReviewApp(testCtx, tt.args.dto)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReviewAppReconciler.stopReviewApp() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(raStatus, tt.wantRaStatus); diff != "" {
+				t.Errorf("ReviewAppReconciler.stopReviewApp() is unexpected:\n%v", diff)
+			}
+			if len(recorder.Events) != tt.fields.NumOfCalledRecorder {
+				t.Errorf("number of Events recorded by ReviewAppReconciler.stopReviewApp() = %d, want %d", len(recorder.Events), tt.fields.NumOfCalledRecorder)
+			}
+		})
+	}
+}
+
+func TestReviewAppReconciler_reconcile(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testRaSuspended := testutil_withSuspend(testutil_withReviewAppStatus(testRaNormal, "argocd", "sample-1", "testset_normal"))
+	testRaSuspended.Spec.AppConfig.ChatOps.Enabled = true
+	testRaSuspendedBeforePush := testRaSuspended
+	testRaSuspendedBeforePush.Status.Sync.Status = dreamkastv1alpha1.SyncStatusCodeNeedToUpdateInfraRepo
+	testRaSuspendedBeforeComment := testRaSuspended
+	testRaSuspendedBeforeComment.Status.Sync.Status = dreamkastv1alpha1.SyncStatusCodeUpdatedInfraRepo
+	testRaSuspendedBeforeComment.Status.Sync.AlreadySentMessage = false
+
+	type fields struct {
+		NumOfCalledRecorder int
+	}
+	type args struct {
+		dto ReviewAppPhaseDTO
+	}
+	tests := []struct {
+		name           string
+		fields         fields
+		args           args
+		wantSyncStatus dreamkastv1alpha1.SyncStatusCode
+		wantSuspended  bool
+		wantErr        bool
+	}{
+		{
+			name: "[suspend] neither push to Infra Repository nor run ChatOps commands",
+			args: args{
+				dto: ReviewAppPhaseDTO{
+					ReviewApp:   testRaSuspendedBeforePush,
+					PullRequest: testPrNormal,
+					Application: testAppNormal_updated,
+					Manifests:   testManifestsNormal_updated,
+				},
+			},
+			wantSyncStatus: dreamkastv1alpha1.SyncStatusCodeNeedToUpdateInfraRepo,
+			wantSuspended:  true,
+		},
+		{
+			name: "[suspend] don't comment to App Repository",
+			args: args{
+				dto: ReviewAppPhaseDTO{
+					ReviewApp:   testRaSuspendedBeforeComment,
+					PullRequest: testPrNormal,
+					Application: testAppNormal,
+					Manifests:   testManifestsNormal,
+				},
+			},
+			wantSyncStatus: dreamkastv1alpha1.SyncStatusCodeUpdatedInfraRepo,
+			wantSuspended:  true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var patched *models.ReviewApp
+			// only status is patched: calling any other method of the mocks fails the test
+			k8sRepository := mock.NewMockKubernetesRepository(mockCtrl)
+			k8sRepository.EXPECT().PatchReviewAppStatus(testCtx, gomock.Any()).
+				DoAndReturn(func(_ context.Context, ra models.ReviewApp) error {
+					patched = &ra
+					return nil
+				})
+			r := &ReviewAppReconciler{
+				Log:                  testLogger,
+				Scheme:               testScheme,
+				Recorder:             record.NewFakeRecorder(tt.fields.NumOfCalledRecorder),
+				K8sRepository:        k8sRepository,
+				GitApiRepository:     mock.NewMockGitAPI(mockCtrl),
+				GitCommandRepository: mock.NewMockGitCommand(mockCtrl),
+			}
+			if _, err := r.reconcile(testCtx, tt.args.dto); (err != nil) != tt.wantErr {
+				t.Errorf("ReviewAppReconciler.reconcile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if patched == nil {
+				t.Fatalf("ReviewAppReconciler.reconcile() didn't patch status")
+			}
+			if patched.Status.Sync.Status != tt.wantSyncStatus {
+				t.Errorf("status.sync.status patched by ReviewAppReconciler.reconcile() = %v, want %v", patched.Status.Sync.Status, tt.wantSyncStatus)
+			}
+			if patched.Status.Suspended != tt.wantSuspended {
+				t.Errorf("status.suspended patched by ReviewAppReconciler.reconcile() = %v, want %v", patched.Status.Suspended, tt.wantSuspended)
 			}
 		})
 	}
@@ -437,20 +1748,101 @@ func TestReviewAppReconciler_reconcileDelete(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "[normal] having preStopJob",
+			name: "[normal] create preStopJob",
 			fields: fields{
-				NumOfCalledRecorder: 2,
+				NumOfCalledRecorder: 1,
 				K8sRepository: func() repositories.KubernetesRepository {
 					m := mock.NewMockKubernetesRepository(mockCtrl)
 					m.EXPECT().GetPreStopJobTemplate(testCtx, testRaNormal).
 						Return(testPreStopJtNormal, nil)
 					m.EXPECT().CreateJob(testCtx, &testPreStopJobNormal).
 						Return(nil)
-					m.EXPECT().GetLatestJobFromLabel(testCtx, testPreStopJobNormal.Namespace, models.LabelReviewAppNameForJob, testRaNormal.Name).
+					m.EXPECT().PatchReviewAppStatus(testCtx, gomock.Any()).
+						Return(nil)
+					return m
+				},
+				GitCommandRepository: func() repositories.GitCommand {
+					return mock.NewMockGitCommand(mockCtrl)
+				},
+			},
+			args: args{
+				dto: ReviewAppPhaseDTO{
+					ReviewApp:   testRaNormal,
+					PullRequest: testPrNormal,
+					Application: testAppNormal,
+					Manifests:   testManifestsNormal,
+				},
+			},
+			want: ctrl.Result{RequeueAfter: jobPollingInterval},
+		},
+		{
+			name: "[normal] create preStopJob from template of the variant",
+			fields: fields{
+				NumOfCalledRecorder: 1,
+				K8sRepository: func() repositories.KubernetesRepository {
+					m := mock.NewMockKubernetesRepository(mockCtrl)
+					m.EXPECT().GetPreStopJobTemplate(testCtx, testRaNormal).
+						Return(testPreStopJtVariants, nil)
+					m.EXPECT().CreateJob(testCtx, &testPreStopJobVariants).
+						Return(nil)
+					m.EXPECT().PatchReviewAppStatus(testCtx, gomock.Any()).
+						Return(nil)
+					return m
+				},
+				GitCommandRepository: func() repositories.GitCommand {
+					return mock.NewMockGitCommand(mockCtrl)
+				},
+			},
+			args: args{
+				dto: ReviewAppPhaseDTO{
+					ReviewApp: testRaNormal,
+					PullRequest: func() models.PullRequest {
+						pr := testPrNormal
+						pr.Variant = models.VariantCandidate
+						return pr
+					}(),
+					Application: testAppNormal,
+					Manifests:   testManifestsNormal,
+				},
+			},
+			want: ctrl.Result{RequeueAfter: jobPollingInterval},
+		},
+		{
+			name: "[normal] preStopJob is running",
+			fields: fields{
+				K8sRepository: func() repositories.KubernetesRepository {
+					m := mock.NewMockKubernetesRepository(mockCtrl)
+					m.EXPECT().GetJob(testCtx, testPreStopJobNormal.Namespace, testPreStopJobName).
+						Return(testutil_withJobStatus(testPreStopJobNormal, false), nil)
+					return m
+				},
+				GitCommandRepository: func() repositories.GitCommand {
+					return mock.NewMockGitCommand(mockCtrl)
+				},
+			},
+			args: args{
+				dto: ReviewAppPhaseDTO{
+					ReviewApp:   testutil_withPreStopStatus(testRaNormal, dreamkastv1alpha1.PreStopPhaseRunning, datetimeFactoryForRA.Now().ToString(), 0),
+					PullRequest: testPrNormal,
+					Application: testAppNormal,
+					Manifests:   testManifestsNormal,
+				},
+			},
+			want: ctrl.Result{RequeueAfter: jobPollingInterval},
+		},
+		{
+			name: "[normal] preStopJob succeeded",
+			fields: fields{
+				NumOfCalledRecorder: 1,
+				K8sRepository: func() repositories.KubernetesRepository {
+					m := mock.NewMockKubernetesRepository(mockCtrl)
+					m.EXPECT().GetJob(testCtx, testPreStopJobNormal.Namespace, testPreStopJobName).
 						Return(testutil_withJobStatus(testPreStopJobNormal, true), nil)
+					m.EXPECT().PatchReviewAppStatus(testCtx, gomock.Any()).
+						Return(nil)
 					m.EXPECT().GetSecretValue(testCtx, testRaNormal.Namespace, testRaNormal.InfraRepoTarget()).
 						Return(testSecretToken, nil)
-					m.EXPECT().RemoveFinalizersFromReviewApp(testCtx, testRaNormal, finalizer).
+					m.EXPECT().RemoveFinalizersFromReviewApp(testCtx, gomock.Any(), finalizer).
 						Return(nil)
 					return m
 				},
@@ -473,7 +1865,197 @@ func TestReviewAppReconciler_reconcileDelete(t *testing.T) {
 			},
 			args: args{
 				dto: ReviewAppPhaseDTO{
-					ReviewApp:   testRaNormal,
+					ReviewApp:   testutil_withPreStopStatus(testRaNormal, dreamkastv1alpha1.PreStopPhaseRunning, datetimeFactoryForRA.Now().ToString(), 0),
+					PullRequest: testPrNormal,
+					Application: testAppNormal,
+					Manifests:   testManifestsNormal,
+				},
+			},
+		},
+		{
+			name: "[normal] preStopJob timed out with failurePolicy Block",
+			fields: fields{
+				NumOfCalledRecorder: 2,
+				K8sRepository: func() repositories.KubernetesRepository {
+					m := mock.NewMockKubernetesRepository(mockCtrl)
+					m.EXPECT().GetJob(testCtx, testPreStopJobNormal.Namespace, testPreStopJobName).
+						Return(testutil_withJobStatus(testPreStopJobNormal, false), nil)
+					// failure to get logs does not stop deletion
+					m.EXPECT().GetJobLogs(testCtx, gomock.Any(), int64(20), int64(4096)).
+						Return("", fmt.Errorf("pods is forbidden"))
+					m.EXPECT().PatchReviewAppStatus(testCtx, gomock.Any()).
+						Return(nil)
+					return m
+				},
+				GitCommandRepository: func() repositories.GitCommand {
+					return mock.NewMockGitCommand(mockCtrl)
+				},
+			},
+			args: args{
+				dto: ReviewAppPhaseDTO{
+					ReviewApp:   testutil_withPreStopJobPolicy(testutil_withPreStopStatus(testRaNormal, dreamkastv1alpha1.PreStopPhaseRunning, "2022-01-01T00:00:00Z", 0), dreamkastv1alpha1.PreStopJobFailurePolicyBlock, 0),
+					PullRequest: testPrNormal,
+					Application: testAppNormal,
+					Manifests:   testManifestsNormal,
+				},
+			},
+			want: ctrl.Result{RequeueAfter: preStopJobBlockedInterval},
+		},
+		{
+			name: "[normal] deletion is blocked by failed preStopJob",
+			fields: fields{
+				NumOfCalledRecorder: 1,
+				K8sRepository: func() repositories.KubernetesRepository {
+					return mock.NewMockKubernetesRepository(mockCtrl)
+				},
+				GitCommandRepository: func() repositories.GitCommand {
+					return mock.NewMockGitCommand(mockCtrl)
+				},
+			},
+			args: args{
+				dto: ReviewAppPhaseDTO{
+					ReviewApp:   testutil_withPreStopJobPolicy(testutil_withPreStopStatus(testRaNormal, dreamkastv1alpha1.PreStopPhaseFailed, "2022-01-01T00:00:00Z", 0), dreamkastv1alpha1.PreStopJobFailurePolicyBlock, 0),
+					PullRequest: testPrNormal,
+					Application: testAppNormal,
+					Manifests:   testManifestsNormal,
+				},
+			},
+			want: ctrl.Result{RequeueAfter: preStopJobBlockedInterval},
+		},
+		{
+			name: "[normal] retry failed preStopJob",
+			fields: fields{
+				NumOfCalledRecorder: 1,
+				K8sRepository: func() repositories.KubernetesRepository {
+					m := mock.NewMockKubernetesRepository(mockCtrl)
+					m.EXPECT().GetPreStopJobTemplate(testCtx, gomock.Any()).
+						Return(testPreStopJtNormal, nil)
+					m.EXPECT().CreateJob(testCtx, &testPreStopJobNormal).
+						Return(nil)
+					m.EXPECT().PatchReviewAppStatus(testCtx, gomock.Any()).
+						Return(nil)
+					return m
+				},
+				GitCommandRepository: func() repositories.GitCommand {
+					return mock.NewMockGitCommand(mockCtrl)
+				},
+			},
+			args: args{
+				dto: ReviewAppPhaseDTO{
+					ReviewApp:   testutil_withPreStopJobPolicy(testutil_withPreStopStatus(testRaNormal, dreamkastv1alpha1.PreStopPhaseFailed, "2022-01-01T00:00:00Z", 0), dreamkastv1alpha1.PreStopJobFailurePolicyRetry, 1),
+					PullRequest: testPrNormal,
+					Application: testAppNormal,
+					Manifests:   testManifestsNormal,
+				},
+			},
+			want: ctrl.Result{RequeueAfter: jobPollingInterval},
+		},
+		{
+			name: "[normal] continue deletion after retries are exhausted",
+			fields: fields{
+				NumOfCalledRecorder: 1,
+				K8sRepository: func() repositories.KubernetesRepository {
+					m := mock.NewMockKubernetesRepository(mockCtrl)
+					m.EXPECT().GetSecretValue(testCtx, testRaNormal.Namespace, testRaNormal.InfraRepoTarget()).
+						Return(testSecretToken, nil)
+					m.EXPECT().RemoveFinalizersFromReviewApp(testCtx, gomock.Any(), finalizer).
+						Return(nil)
+					return m
+				},
+				GitCommandRepository: func() repositories.GitCommand {
+					// vars
+					infraRepoTarget := testRaNormal.InfraRepoTarget()
+					localDir := models.NewInfraRepoLocal(fmt.Sprintf("/tmp/%s/%s", infraRepoTarget.Organization, infraRepoTarget.Repository)).SetLatestCommitHash(testInfraRepoLatestCommitHash)
+					// mock
+					m := mock.NewMockGitCommand(mockCtrl)
+					m.EXPECT().WithCredential(models.NewGitCredential(infraRepoTarget.Username, testSecretToken)).
+						Return(nil)
+					m.EXPECT().ForceClone(testCtx, infraRepoTarget).
+						Return(localDir, nil)
+					// DeleteFiles の引数は順不同なので gomock.Any を利用
+					m.EXPECT().DeleteFiles(testCtx, localDir, gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(nil)
+					m.EXPECT().CommitAndPush(testCtx, localDir, localDir.CommitMsgDeletion(testRaNormal))
+					return m
+				},
+			},
+			args: args{
+				dto: ReviewAppPhaseDTO{
+					ReviewApp:   testutil_withPreStopJobPolicy(testutil_withPreStopStatus(testRaNormal, dreamkastv1alpha1.PreStopPhaseFailed, "2022-01-01T00:00:00Z", 1), dreamkastv1alpha1.PreStopJobFailurePolicyRetry, 1),
+					PullRequest: testPrNormal,
+					Application: testAppNormal,
+					Manifests:   testManifestsNormal,
+				},
+			},
+		},
+		{
+			name: "[normal] wait for Application & Namespace to be deleted",
+			fields: fields{
+				K8sRepository: func() repositories.KubernetesRepository {
+					m := mock.NewMockKubernetesRepository(mockCtrl)
+					m.EXPECT().GetArgoCDAppFromReviewAppStatus(testCtx, gomock.Any()).
+						Return(testAppNormal, nil)
+					m.EXPECT().GetNamespace(testCtx, "demo").
+						Return(&corev1.Namespace{}, nil)
+					return m
+				},
+				GitCommandRepository: func() repositories.GitCommand {
+					return mock.NewMockGitCommand(mockCtrl)
+				},
+			},
+			args: args{
+				dto: ReviewAppPhaseDTO{
+					ReviewApp:   testutil_withTeardownStatus(testutil_withPreStopStatus(testutil_withReviewAppStatus(testRaNormal, "argocd", "sample-1", testPrNormal.LatestCommitHash), dreamkastv1alpha1.PreStopPhaseSucceeded, "2022-01-01T00:00:00Z", 0), datetimeFactoryForRA.Now().ToString(), "demo"),
+					PullRequest: testPrNormal,
+					Application: testAppNormal,
+					Manifests:   testManifestsNormal,
+				},
+			},
+			want: ctrl.Result{RequeueAfter: teardownPollingInterval},
+		},
+		{
+			name: "[normal] nothing is cleaned up in dry-run mode",
+			fields: fields{
+				K8sRepository: func() repositories.KubernetesRepository {
+					m := mock.NewMockKubernetesRepository(mockCtrl)
+					m.EXPECT().RemoveFinalizersFromReviewApp(testCtx, gomock.Any(), finalizer).
+						Return(nil)
+					return m
+				},
+				GitCommandRepository: func() repositories.GitCommand {
+					return mock.NewMockGitCommand(mockCtrl)
+				},
+			},
+			args: args{
+				dto: ReviewAppPhaseDTO{
+					ReviewApp:   testutil_withDryRun(testRaNormal),
+					PullRequest: testPrNormal,
+					Application: testAppNormal,
+					Manifests:   testManifestsNormal,
+				},
+			},
+		},
+		{
+			name: "[normal] teardown timed out",
+			fields: fields{
+				NumOfCalledRecorder: 1,
+				K8sRepository: func() repositories.KubernetesRepository {
+					m := mock.NewMockKubernetesRepository(mockCtrl)
+					m.EXPECT().GetArgoCDAppFromReviewAppStatus(testCtx, gomock.Any()).
+						Return(models.Application(""), myerrors.NewK8sObjectNotFound(fmt.Errorf("not found"), schema.GroupVersionKind{}, types.NamespacedName{}))
+					m.EXPECT().GetNamespace(testCtx, "demo").
+						Return(&corev1.Namespace{}, nil)
+					m.EXPECT().RemoveFinalizersFromReviewApp(testCtx, gomock.Any(), finalizer).
+						Return(nil)
+					return m
+				},
+				GitCommandRepository: func() repositories.GitCommand {
+					return mock.NewMockGitCommand(mockCtrl)
+				},
+			},
+			args: args{
+				dto: ReviewAppPhaseDTO{
+					ReviewApp:   testutil_withTeardownStatus(testutil_withPreStopStatus(testutil_withReviewAppStatus(testRaNormal, "argocd", "sample-1", testPrNormal.LatestCommitHash), dreamkastv1alpha1.PreStopPhaseSucceeded, "2022-01-01T00:00:00Z", 0), "2022-01-01T00:00:00Z", "demo"),
 					PullRequest: testPrNormal,
 					Application: testAppNormal,
 					Manifests:   testManifestsNormal,
@@ -518,10 +2100,7 @@ func testutil_withReviewAppStatus(m models.ReviewApp, appNamespace, appName, com
 			ApplicationNamespace: appNamespace,
 			AlreadySentMessage:   true,
 		},
-		ManifestsCache: dreamkastv1alpha1.ManifestsCache{
-			Application: string(testAppNormal),
-			Manifests:   testManifestsNormal,
-		},
+		ManifestsCache: testManifestsCacheNormal,
 	}
 	return m
 }
@@ -532,3 +2111,40 @@ func testutil_withJobStatus(job batchv1.Job, succeeded bool) *batchv1.Job {
 	}
 	return &job
 }
+
+func testutil_withPreStopStatus(m models.ReviewApp, phase dreamkastv1alpha1.PreStopPhase, startTimestamp string, retries int32) models.ReviewApp {
+	m.Status.PreStop = dreamkastv1alpha1.PreStopStatus{
+		Phase:          phase,
+		JobName:        testPreStopJobName,
+		JobNamespace:   testPreStopJobNormal.Namespace,
+		StartTimestamp: startTimestamp,
+		Retries:        retries,
+	}
+	return m
+}
+
+func testutil_withTeardownStatus(m models.ReviewApp, startTimestamp, namespace string) models.ReviewApp {
+	m.Spec.TeardownVerification = dreamkastv1alpha1.TeardownVerification{Enabled: true, Namespace: namespace}
+	m.Status.Teardown = dreamkastv1alpha1.TeardownStatus{StartTimestamp: startTimestamp, Namespace: namespace}
+	return m
+}
+
+func testutil_withPreStopJobPolicy(m models.ReviewApp, policy dreamkastv1alpha1.PreStopJobFailurePolicy, maxRetries int32) models.ReviewApp {
+	m.Spec.PreStopJobPolicy = dreamkastv1alpha1.PreStopJobPolicy{FailurePolicy: policy, MaxRetries: maxRetries}
+	return m
+}
+
+func testutil_withSuspend(m models.ReviewApp) models.ReviewApp {
+	m.Spec.Suspend = true
+	return m
+}
+
+func testutil_withDryRun(m models.ReviewApp) models.ReviewApp {
+	m.Spec.DryRun = true
+	return m
+}
+
+func testutil_withStrictTemplating(m models.ReviewApp) models.ReviewApp {
+	m.Spec.StrictTemplating = true
+	return m
+}
@@ -66,7 +66,7 @@ var _ = Describe("ReviewApp controller", func() {
 		})
 		Expect(err).ToNot(HaveOccurred())
 		logger := glogr.NewWithOptions(glogr.Options{LogCaller: glogr.None})
-		k8sRepository, err := wire.NewKubernetesRepository(logger, k8sClient)
+		k8sRepository, err := wire.NewKubernetesRepository(logger, k8sClient, cfg)
 		Expect(err).ToNot(HaveOccurred())
 		gitApiRepository, err := wire.NewGitHubAPIRepository(logger)
 		Expect(err).ToNot(HaveOccurred())
@@ -22,8 +22,10 @@ import (
 	"os"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/exec"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -46,8 +48,9 @@ type ReviewAppManagerReconciler struct {
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
 
-	K8sRepository    repositories.KubernetesRepository
-	GitApiRepository repositories.GitAPI
+	K8sRepository        repositories.KubernetesRepository
+	GitApiRepository     repositories.GitAPI
+	GitCommandRepository repositories.GitCommand
 }
 
 //+kubebuilder:rbac:groups=dreamkast.cloudnativedays.jp,resources=reviewappmanagers,verbs=get;list;watch;create;update;patch;delete
@@ -104,32 +107,53 @@ func (r *ReviewAppManagerReconciler) reconcile(ctx context.Context, ram models.R
 	).Add(1)
 
 	// exclude PRs with specific labels
-	prs = prs.ExcludeSpecificPR(ram)
+	prs, err = prs.ExcludeSpecificPR(ram)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
 	// apply ReviewApp
 	var syncedPullRequests []dreamkastv1alpha1.ReviewAppManagerStatusSyncedPullRequests
 	for _, pr := range prs {
+		// apply variables overridden by PR's labels or description
+		overrides, err := ram.VariableOverrides(pr)
+		if err != nil {
+			r.Log.Info(err.Error())
+			r.Recorder.Eventf(ram.ToReviewAppCR(), corev1.EventTypeWarning, "variableOverrides", "%s", err)
+		}
+		ramForPR := ram.WithVariableOverrides(overrides)
 		// init templator
-		v := models.NewTemplator(ram, pr)
+		v, err := newTemplator(ctx, r.K8sRepository, ramForPR, pr)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
 		// generate RA
-		ra, err := ram.GenerateReviewApp(pr, v, datetimeFactoryForRAM)
+		ra, err := ramForPR.GenerateReviewApp(pr, v, datetimeFactoryForRAM)
 		if err != nil {
 			return ctrl.Result{}, err
 		}
 		// get RA
+		created := false
 		if _, err := r.K8sRepository.GetReviewApp(ctx, ra.Namespace, ra.Name); err != nil {
 			if !myerrors.IsNotFound(err) {
 				return ctrl.Result{}, err
 			}
 			// if ReviewApp Object has not existed, set to status.sync.status
 			ra.Status.Sync.Status = dreamkastv1alpha1.SyncStatusCodeInitialize
+			created = true
+		}
+		// don't create RA while RAM is suspended
+		if created && ram.Spec.Suspend {
+			continue
 		}
 		// apply RA
 		if err := r.K8sRepository.ApplyReviewAppWithOwnerRef(ctx, ra, ram); err != nil {
 			return ctrl.Result{}, err
 		}
-		// update Status
-		if err := r.K8sRepository.PatchReviewAppStatus(ctx, ra); err != nil {
-			return ctrl.Result{}, err
+		// update Status only when RA is created, because status of existing RA is managed by ReviewAppReconciler
+		if created {
+			if err := r.K8sRepository.PatchReviewAppStatus(ctx, ra); err != nil {
+				return ctrl.Result{}, err
+			}
 		}
 		// update values for updating RAM.status
 		syncedPullRequests = append(syncedPullRequests, dreamkastv1alpha1.ReviewAppManagerStatusSyncedPullRequests{
@@ -139,19 +163,33 @@ func (r *ReviewAppManagerReconciler) reconcile(ctx context.Context, ram models.R
 			ReviewAppName: ra.Name,
 		})
 	}
-	// delete RA that only exists ResourceStatus
-	for _, name := range ram.ListOutOfSyncReviewAppName(prs) {
-		if err := r.K8sRepository.DeleteReviewApp(ctx, ram.Namespace, name); err != nil {
-			return ctrl.Result{}, client.IgnoreNotFound(err)
+	if ram.Spec.Suspend {
+		// don't delete RA while RAM is suspended, and keep it in status to delete after resumed
+		syncedPullRequests = append(syncedPullRequests, ram.ListOutOfSyncPullRequests(prs)...)
+	} else {
+		// delete RA that only exists ResourceStatus
+		for _, name := range ram.ListOutOfSyncReviewAppName(prs) {
+			if err := r.K8sRepository.DeleteReviewApp(ctx, ram.Namespace, name); err != nil {
+				return ctrl.Result{}, client.IgnoreNotFound(err)
+			}
+		}
+	}
+	// sweep files of ReviewApps that no longer exist from InfraRepo
+	var result ctrl.Result
+	if ram.Spec.OrphanSweeper.Enabled && !ram.Spec.Suspend {
+		ram, result, err = r.sweepOrphans(ctx, ram)
+		if err != nil {
+			return ctrl.Result{}, err
 		}
 	}
 	// update ReviewAppManager Status
+	ram.Status.Suspended = ram.Spec.Suspend
 	ram.Status.SyncedPullRequests = syncedPullRequests
 	if err := r.K8sRepository.UpdateReviewAppManagerStatus(ctx, ram); err != nil {
 		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{}, nil
+	return result, nil
 }
 
 func (r *ReviewAppManagerReconciler) removeMetrics(name, namespace string) {
@@ -166,7 +204,7 @@ func (r *ReviewAppManagerReconciler) removeMetrics(name, namespace string) {
 func (r *ReviewAppManagerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	setupLog := ctrl.Log.WithName("setup")
 	var err error
-	r.K8sRepository, err = wire.NewKubernetesRepository(r.Log, mgr.GetClient())
+	r.K8sRepository, err = wire.NewKubernetesRepository(r.Log, mgr.GetClient(), mgr.GetConfig())
 	if err != nil {
 		setupLog.Error(err, "unable to initialize", "wire.NewKubernetesRepository")
 		os.Exit(1)
@@ -176,17 +214,15 @@ func (r *ReviewAppManagerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		setupLog.Error(err, "unable to initialize", "wire.NewGitHubAPIRepository")
 		os.Exit(1)
 	}
+	r.GitCommandRepository, err = wire.NewGitCommandRepositoryWithBaseDir(r.Log, exec.New(), orphanSweeperBaseDir)
+	if err != nil {
+		setupLog.Error(err, "unable to initialize", "wire.NewGitCommandRepositoryWithBaseDir")
+		os.Exit(1)
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&dreamkastv1alpha1.ReviewAppManager{}).
 		Owns(&dreamkastv1alpha1.ReviewApp{}).
-		// TODO: at, mt 更新時にも reconcile が走るようにする
-		// Watches(
-		// 	&source.Kind{Type: &dreamkastv1alpha1.ApplicationTemplate{}},
-		// 	&handler.EnqueueRequestForObject{},
-		// ).
-		// Watches(
-		// 	&source.Kind{Type: &dreamkastv1alpha1.ManifestsTemplate{}},
-		// 	&handler.EnqueueRequestForObject{},
-		// ).
+		// changes of ApplicationTemplate & ManifestsTemplate are watched by ReviewAppReconciler,
+		// because ReviewApps (not ReviewAppManager) template them.
 		Complete(r)
 }
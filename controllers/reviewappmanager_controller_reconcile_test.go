@@ -0,0 +1,168 @@
+//go:build !integration_test
+// +build !integration_test
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	dreamkastv1alpha1 "github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
+	"github.com/cloudnativedaysjp/reviewapp-operator/domain/mock"
+	"github.com/cloudnativedaysjp/reviewapp-operator/domain/models"
+	"github.com/cloudnativedaysjp/reviewapp-operator/domain/repositories"
+	myerrors "github.com/cloudnativedaysjp/reviewapp-operator/errors"
+)
+
+func TestReviewAppManagerReconciler_reconcile(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	testSecretToken := "test-token"
+
+	testRam := models.ReviewAppManager{
+		ObjectMeta: metav1.ObjectMeta{Name: "reviewappmanager-sample", Namespace: testRaNormal.Namespace},
+		Spec: dreamkastv1alpha1.ReviewAppManagerSpec{
+			AppTarget:   testRaNormal.Spec.AppTarget,
+			InfraTarget: testRaNormal.Spec.InfraTarget,
+			Suspend:     true,
+		},
+	}
+	appRepoTarget := testRam.AppRepoTarget()
+	testPrOpened := models.PullRequest{
+		Organization:     appRepoTarget.Organization,
+		Repository:       appRepoTarget.Repository,
+		Branch:           "opened",
+		Number:           2,
+		LatestCommitHash: "opened-commit-hash",
+	}
+	testPrClosed := dreamkastv1alpha1.ReviewAppManagerStatusSyncedPullRequests{
+		Organization:  appRepoTarget.Organization,
+		Repository:    appRepoTarget.Repository,
+		Number:        1,
+		ReviewAppName: testRam.ReviewAppName(models.PullRequest{Organization: appRepoTarget.Organization, Repository: appRepoTarget.Repository, Number: 1}),
+	}
+	testPrOpenedSynced := dreamkastv1alpha1.ReviewAppManagerStatusSyncedPullRequests{
+		Organization:  testPrOpened.Organization,
+		Repository:    testPrOpened.Repository,
+		Number:        testPrOpened.Number,
+		ReviewAppName: testRam.ReviewAppName(testPrOpened),
+	}
+	testRamWithClosedPr := testRam
+	testRamWithClosedPr.Status.SyncedPullRequests = []dreamkastv1alpha1.ReviewAppManagerStatusSyncedPullRequests{testPrClosed}
+	testRamWithOpenedPr := testRam
+	testRamWithOpenedPr.Status.SyncedPullRequests = []dreamkastv1alpha1.ReviewAppManagerStatusSyncedPullRequests{testPrOpenedSynced}
+
+	newGitApi := func() repositories.GitAPI {
+		m := mock.NewMockGitAPI(mockCtrl)
+		m.EXPECT().WithCredential(models.NewGitCredential(appRepoTarget.Username, testSecretToken)).
+			Return(nil)
+		m.EXPECT().ListOpenPullRequests(testCtx, appRepoTarget).
+			Return(models.PullRequests{testPrOpened}, nil)
+		return m
+	}
+
+	type fields struct {
+		K8sRepository    func(updated *models.ReviewAppManager) repositories.KubernetesRepository
+		GitApiRepository func() repositories.GitAPI
+	}
+	type args struct {
+		ram models.ReviewAppManager
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		args       args
+		wantStatus dreamkastv1alpha1.ReviewAppManagerStatus
+		wantErr    bool
+	}{
+		{
+			name: "[suspend] neither create ReviewApp of opened PR nor delete ReviewApp of closed PR",
+			fields: fields{
+				K8sRepository: func(updated *models.ReviewAppManager) repositories.KubernetesRepository {
+					m := mock.NewMockKubernetesRepository(mockCtrl)
+					m.EXPECT().GetSecretValue(testCtx, testRam.Namespace, &appRepoTarget).
+						Return(testSecretToken, nil)
+					m.EXPECT().GetReviewApp(testCtx, testRam.Namespace, testRam.ReviewAppName(testPrOpened)).
+						Return(models.ReviewApp{}, myerrors.NewK8sObjectNotFound(fmt.Errorf("not found"), schema.GroupVersionKind{}, types.NamespacedName{}))
+					m.EXPECT().UpdateReviewAppManagerStatus(testCtx, gomock.Any()).
+						DoAndReturn(func(_ context.Context, ram models.ReviewAppManager) error {
+							*updated = ram
+							return nil
+						})
+					return m
+				},
+				GitApiRepository: newGitApi,
+			},
+			args: args{
+				ram: testRamWithClosedPr,
+			},
+			wantStatus: dreamkastv1alpha1.ReviewAppManagerStatus{
+				SyncedPullRequests: []dreamkastv1alpha1.ReviewAppManagerStatusSyncedPullRequests{testPrClosed},
+				Suspended:          true,
+			},
+		},
+		{
+			name: "[suspend] apply existing ReviewApp to propagate spec.suspend",
+			fields: fields{
+				K8sRepository: func(updated *models.ReviewAppManager) repositories.KubernetesRepository {
+					m := mock.NewMockKubernetesRepository(mockCtrl)
+					m.EXPECT().GetSecretValue(testCtx, testRam.Namespace, &appRepoTarget).
+						Return(testSecretToken, nil)
+					m.EXPECT().GetReviewApp(testCtx, testRam.Namespace, testRam.ReviewAppName(testPrOpened)).
+						Return(models.ReviewApp{}, nil)
+					m.EXPECT().ApplyReviewAppWithOwnerRef(testCtx, gomock.Any(), testRamWithOpenedPr).
+						DoAndReturn(func(_ context.Context, ra models.ReviewApp, _ models.ReviewAppManager) error {
+							if !ra.Spec.Suspend {
+								t.Errorf("spec.suspend of ReviewApp applied by ReviewAppManagerReconciler.reconcile() = false, want true")
+							}
+							return nil
+						})
+					m.EXPECT().UpdateReviewAppManagerStatus(testCtx, gomock.Any()).
+						DoAndReturn(func(_ context.Context, ram models.ReviewAppManager) error {
+							*updated = ram
+							return nil
+						})
+					return m
+				},
+				GitApiRepository: newGitApi,
+			},
+			args: args{
+				ram: testRamWithOpenedPr,
+			},
+			wantStatus: dreamkastv1alpha1.ReviewAppManagerStatus{
+				SyncedPullRequests: []dreamkastv1alpha1.ReviewAppManagerStatusSyncedPullRequests{testPrOpenedSynced},
+				Suspended:          true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var updated models.ReviewAppManager
+			r := &ReviewAppManagerReconciler{
+				Log:                  testLogger,
+				Scheme:               testScheme,
+				Recorder:             record.NewFakeRecorder(0),
+				K8sRepository:        tt.fields.K8sRepository(&updated),
+				GitApiRepository:     tt.fields.GitApiRepository(),
+				GitCommandRepository: mock.NewMockGitCommand(mockCtrl),
+			}
+			if _, err := r.reconcile(testCtx, tt.args.ram); (err != nil) != tt.wantErr {
+				t.Errorf("ReviewAppManagerReconciler.reconcile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(updated.Status, tt.wantStatus); diff != "" {
+				t.Errorf("status updated by ReviewAppManagerReconciler.reconcile() is unexpected:\n%v", diff)
+			}
+		})
+	}
+}
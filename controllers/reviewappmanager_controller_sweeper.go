@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	dreamkastv1alpha1 "github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
+	"github.com/cloudnativedaysjp/reviewapp-operator/domain/models"
+	myerrors "github.com/cloudnativedaysjp/reviewapp-operator/errors"
+)
+
+// orphanSweeperBaseDir is directory where Infra Repository is cloned for sweeping orphans.
+// It differs from the directory used by ReviewAppReconciler so that both controllers can clone the same repository concurrently.
+const orphanSweeperBaseDir = "/tmp/orphan-sweeper"
+
+// sweepOrphans removes files left in Infra Repository by ReviewApps that no longer exist, once per spec.orphanSweeper.intervalSeconds.
+// Orphans are only reported to Event & status in dry-run mode.
+func (r *ReviewAppManagerReconciler) sweepOrphans(ctx context.Context, ram models.ReviewAppManager) (models.ReviewAppManager, ctrl.Result, error) {
+	ramSource := ram.ToReviewAppCR()
+
+	// sweep once per interval
+	d, err := ram.DurationUntilNextOrphanSweep(datetimeFactoryForRAM)
+	if err != nil {
+		return ram, ctrl.Result{}, err
+	}
+	if d > 0 {
+		return ram, ctrl.Result{RequeueAfter: d}, nil
+	}
+	status := dreamkastv1alpha1.OrphanSweepStatus{
+		LastSweepTimestamp: datetimeFactoryForRAM.Now().ToString(),
+	}
+	patterns := ram.InfraRepoPathPatterns()
+	if len(patterns) == 0 {
+		r.Log.Info(fmt.Sprintf("skip sweeping orphans of ReviewAppManager %s/%s: paths in infraRepoConfig are not templated per ReviewApp", ram.Namespace, ram.Name))
+		ram.Status.OrphanSweep = status
+		return ram, ctrl.Result{RequeueAfter: ram.OrphanSweepInterval()}, nil
+	}
+
+	// get gitRemoteRepo credential from Secret
+	infraRepoTarget := ram.InfraRepoTarget()
+	gitRemoteRepoToken, err := r.K8sRepository.GetSecretValue(ctx, ram.Namespace, infraRepoTarget)
+	if err != nil {
+		if myerrors.IsNotFound(err) || myerrors.IsKeyMissing(err) {
+			r.Log.Info(err.Error())
+			return ram, ctrl.Result{}, nil
+		}
+		return ram, ctrl.Result{}, err
+	}
+	if err := r.GitCommandRepository.WithCredential(models.NewGitCredential(ram.Spec.InfraTarget.Username, gitRemoteRepoToken)); err != nil {
+		return ram, ctrl.Result{}, err
+	}
+	infraRepoLocalDir, err := r.GitCommandRepository.ForceClone(ctx, infraRepoTarget)
+	if err != nil {
+		return ram, ctrl.Result{}, err
+	}
+
+	// list orphans
+	var paths []string
+	for _, pattern := range patterns {
+		matches, err := r.GitCommandRepository.GlobFiles(ctx, infraRepoLocalDir, pattern)
+		if err != nil {
+			return ram, ctrl.Result{}, err
+		}
+		paths = append(paths, matches...)
+	}
+	// ReviewApps in all namespaces are listed, because ReviewAppManagers in other namespaces may target the same Infra Repository
+	ras, err := r.K8sRepository.ListReviewApps(ctx, "")
+	if err != nil {
+		return ram, ctrl.Result{}, err
+	}
+	status.Orphans = ram.ListOrphans(paths, ras)
+
+	// remove or report orphans
+	switch {
+	case len(status.Orphans) == 0:
+	case ram.Spec.OrphanSweeper.DryRun:
+		r.Recorder.Eventf(ramSource, corev1.EventTypeWarning, "orphanSweeper", "found orphans in Infra Repository (dry-run): %s", strings.Join(status.Orphans, ", "))
+	default:
+		var files []models.File
+		for _, p := range status.Orphans {
+			files = append(files, models.File{BaseDir: infraRepoLocalDir.BaseDir(), Filepath: p})
+		}
+		if err := r.GitCommandRepository.DeleteFiles(ctx, infraRepoLocalDir, files...); err != nil {
+			return ram, ctrl.Result{}, err
+		}
+		updated, err := r.GitCommandRepository.CommitAndPush(ctx, infraRepoLocalDir, infraRepoLocalDir.CommitMsgOrphanSweep(ram, status.Orphans))
+		if err != nil {
+			return ram, ctrl.Result{}, err
+		}
+		status.InfraRepoCommitHash = updated.LatestCommitHash()
+		r.Recorder.Eventf(ramSource, corev1.EventTypeNormal, "orphanSweeper", "removed orphans from Infra Repository: %s", strings.Join(status.Orphans, ", "))
+	}
+	ram.Status.OrphanSweep = status
+	return ram, ctrl.Result{RequeueAfter: ram.OrphanSweepInterval()}, nil
+}
@@ -0,0 +1,186 @@
+//go:build !integration_test
+// +build !integration_test
+
+package controllers
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	dreamkastv1alpha1 "github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
+	"github.com/cloudnativedaysjp/reviewapp-operator/domain/mock"
+	"github.com/cloudnativedaysjp/reviewapp-operator/domain/models"
+	"github.com/cloudnativedaysjp/reviewapp-operator/domain/repositories"
+)
+
+func TestReviewAppManagerReconciler_sweepOrphans(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	testSecretToken := "test-token"
+	testInfraRepoLatestCommitHash := "12345678"
+
+	testRam := models.ReviewAppManager{
+		ObjectMeta: metav1.ObjectMeta{Name: "reviewappmanager-sample", Namespace: testRaNormal.Namespace},
+		Spec: dreamkastv1alpha1.ReviewAppManagerSpec{
+			InfraTarget: testRaNormal.Spec.InfraTarget,
+			OrphanSweeper: dreamkastv1alpha1.OrphanSweeper{
+				Enabled: true,
+			},
+		},
+	}
+	testRam.Spec.InfraConfig.ArgoCDApp.Filepath = "apps/pr-{{.AppRepo.PrNumber}}.yaml"
+	testRam.Spec.InfraConfig.Manifests.Dirpath = "overlays/pr-{{.AppRepo.PrNumber}}"
+	testRamDryRun := testRam
+	testRamDryRun.Spec.OrphanSweeper.DryRun = true
+	testRa := testRaNormal
+	testRa.Spec.InfraConfig.ArgoCDApp.Filepath = "apps/pr-1.yaml"
+	testRa.Spec.InfraConfig.Manifests.Dirpath = "overlays/pr-1"
+
+	infraRepoTarget := testRam.InfraRepoTarget()
+	localDir := models.NewInfraRepoLocal(fmt.Sprintf("/tmp/%s/%s", infraRepoTarget.Organization, infraRepoTarget.Repository))
+	pushedLocalDir := localDir.SetLatestCommitHash(testInfraRepoLatestCommitHash)
+	testOrphans := []string{"apps/pr-2.yaml", "overlays/pr-2"}
+
+	newK8sRepository := func() repositories.KubernetesRepository {
+		m := mock.NewMockKubernetesRepository(mockCtrl)
+		m.EXPECT().GetSecretValue(testCtx, testRam.Namespace, infraRepoTarget).
+			Return(testSecretToken, nil)
+		m.EXPECT().ListReviewApps(testCtx, "").
+			Return([]models.ReviewApp{testRa}, nil)
+		return m
+	}
+	newGitCommand := func() *mock.MockGitCommand {
+		m := mock.NewMockGitCommand(mockCtrl)
+		m.EXPECT().WithCredential(models.NewGitCredential(infraRepoTarget.Username, testSecretToken)).
+			Return(nil)
+		m.EXPECT().ForceClone(testCtx, infraRepoTarget).
+			Return(localDir, nil)
+		m.EXPECT().GlobFiles(testCtx, localDir, "apps/pr-*.yaml").
+			Return([]string{"apps/pr-1.yaml", "apps/pr-2.yaml"}, nil)
+		m.EXPECT().GlobFiles(testCtx, localDir, "overlays/pr-*").
+			Return([]string{"overlays/pr-1", "overlays/pr-2"}, nil)
+		return m
+	}
+
+	type fields struct {
+		NumOfCalledRecorder  int
+		K8sRepository        func() repositories.KubernetesRepository
+		GitCommandRepository func() repositories.GitCommand
+	}
+	type args struct {
+		ram models.ReviewAppManager
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		args       args
+		wantStatus dreamkastv1alpha1.OrphanSweepStatus
+		wantResult ctrl.Result
+		wantErr    bool
+	}{
+		{
+			name: "[normal] report orphans in dry-run mode",
+			fields: fields{
+				NumOfCalledRecorder: 1,
+				K8sRepository:       newK8sRepository,
+				GitCommandRepository: func() repositories.GitCommand {
+					return newGitCommand()
+				},
+			},
+			args: args{
+				ram: testRamDryRun,
+			},
+			wantStatus: dreamkastv1alpha1.OrphanSweepStatus{
+				Orphans: testOrphans,
+			},
+			wantResult: ctrl.Result{RequeueAfter: time.Hour},
+		},
+		{
+			name: "[normal] remove orphans",
+			fields: fields{
+				NumOfCalledRecorder: 1,
+				K8sRepository:       newK8sRepository,
+				GitCommandRepository: func() repositories.GitCommand {
+					m := newGitCommand()
+					m.EXPECT().DeleteFiles(testCtx, localDir,
+						models.File{BaseDir: localDir.BaseDir(), Filepath: "apps/pr-2.yaml"},
+						models.File{BaseDir: localDir.BaseDir(), Filepath: "overlays/pr-2"},
+					).Return(nil)
+					m.EXPECT().CommitAndPush(testCtx, localDir, localDir.CommitMsgOrphanSweep(testRam, testOrphans)).
+						Return(&pushedLocalDir, nil)
+					return m
+				},
+			},
+			args: args{
+				ram: testRam,
+			},
+			wantStatus: dreamkastv1alpha1.OrphanSweepStatus{
+				Orphans:             testOrphans,
+				InfraRepoCommitHash: testInfraRepoLatestCommitHash,
+			},
+			wantResult: ctrl.Result{RequeueAfter: time.Hour},
+		},
+		{
+			name: "[normal] skip until interval has passed",
+			fields: fields{
+				K8sRepository: func() repositories.KubernetesRepository {
+					return mock.NewMockKubernetesRepository(mockCtrl)
+				},
+				GitCommandRepository: func() repositories.GitCommand {
+					return mock.NewMockGitCommand(mockCtrl)
+				},
+			},
+			args: args{
+				ram: func() models.ReviewAppManager {
+					m := testRam
+					m.Status.OrphanSweep = dreamkastv1alpha1.OrphanSweepStatus{
+						LastSweepTimestamp: datetimeFactoryForRAM.Now().ToString(),
+						Orphans:            testOrphans,
+					}
+					return m
+				}(),
+			},
+			wantStatus: dreamkastv1alpha1.OrphanSweepStatus{
+				Orphans: testOrphans,
+			},
+			wantResult: ctrl.Result{RequeueAfter: time.Hour},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := &ReviewAppManagerReconciler{
+				Log:                  testLogger,
+				Scheme:               testScheme,
+				Recorder:             record.NewFakeRecorder(tt.fields.NumOfCalledRecorder),
+				K8sRepository:        tt.fields.K8sRepository(),
+				GitCommandRepository: tt.fields.GitCommandRepository(),
+			}
+			ram, result, err := r.sweepOrphans(testCtx, tt.args.ram)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReviewAppManagerReconciler.sweepOrphans() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(ram.Status.OrphanSweep, tt.wantStatus,
+				cmpopts.IgnoreFields(dreamkastv1alpha1.OrphanSweepStatus{}, "LastSweepTimestamp"),
+			); diff != "" {
+				t.Errorf("ReviewAppManagerReconciler.sweepOrphans() is unexpected:\n%v", diff)
+			}
+			// timestamps in status are accurate to the second
+			if diff := cmp.Diff(result, tt.wantResult, cmp.Comparer(func(x, y time.Duration) bool {
+				return x-y < time.Second && y-x < time.Second
+			})); diff != "" {
+				t.Errorf("result in ReviewAppManagerReconciler.sweepOrphans() is unexpected:\n%v", diff)
+			}
+		})
+	}
+}
@@ -73,7 +73,7 @@ var _ = Describe("ReviewAppManager controller", func() {
 		})
 		Expect(err).ToNot(HaveOccurred())
 		logger := glogr.NewWithOptions(glogr.Options{LogCaller: glogr.None})
-		k8sRepository, err := wire.NewKubernetesRepository(logger, k8sClient)
+		k8sRepository, err := wire.NewKubernetesRepository(logger, k8sClient, cfg)
 		Expect(err).ToNot(HaveOccurred())
 		gitApiRepository, err := wire.NewGitHubAPIRepository(logger)
 		Expect(err).ToNot(HaveOccurred())
@@ -148,14 +148,8 @@ var _ = Describe("ReviewAppManager controller", func() {
 
 		Expect(ra.Spec.AppTarget).To(Equal(ram.Spec.AppTarget))
 		Expect(ra.Spec.InfraTarget).To(Equal(ram.Spec.InfraTarget))
-		Expect(ra.Spec.AppConfig.Message).To(Equal(`
-* ShotaKitazawa
-* reviewapp-operator-demo-app
-* 1
-* ShotaKitazawa
-* reviewapp-operator-demo-infra
-* test-ram
-* <no value>`))
+		// Message is templated by ReviewApp when it is sent
+		Expect(ra.Spec.AppConfig.Message).To(Equal(ram.Spec.AppConfig.Message))
 		Expect(ra.Status.Sync.SyncedPullRequest.Branch).To(Equal("demo-01"))
 		Expect(ra.Status.Sync.SyncedPullRequest.LatestCommitHash).NotTo(BeZero())
 		Expect(ra.Status.Sync.SyncedPullRequest.Title).To(Equal("test PR for github.com/cloudnativedaysjp/reviewapp-operator (ReviewAppManager)"))
@@ -0,0 +1,24 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/cloudnativedaysjp/reviewapp-operator/domain/models"
+	"github.com/cloudnativedaysjp/reviewapp-operator/domain/repositories"
+)
+
+// newTemplator initializes Templator with variables given by spec.variables, spec.variablesFrom and outputs of preDeploy Jobs
+func newTemplator(ctx context.Context, k8sRepo repositories.KubernetesRepository, m models.ReviewAppOrReviewAppManager, pr models.PullRequest) (models.Templator, error) {
+	v := models.NewTemplator(m, pr)
+	if len(m.VariablesFrom()) != 0 {
+		vars, err := k8sRepo.GetVariablesFrom(ctx, m)
+		if err != nil {
+			return models.Templator{}, err
+		}
+		v = *v.WithVariablesFrom(vars)
+	}
+	if outputs := m.PreDeployOutputs(); len(outputs) != 0 {
+		v = *v.WithOutputs(outputs)
+	}
+	return v, nil
+}
@@ -0,0 +1,214 @@
+package controllers
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	dreamkastv1alpha1 "github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
+	"github.com/cloudnativedaysjp/reviewapp-operator/domain/models"
+)
+
+const (
+	// indexFieldApplicationTemplate indexes ReviewApps by "namespace/name" of ApplicationTemplate
+	indexFieldApplicationTemplate = "spec.infraRepoConfig.argocdApp.template"
+	// indexFieldManifestsTemplates indexes ReviewApps by "namespace/name" of ManifestsTemplates
+	indexFieldManifestsTemplates = "spec.infraRepoConfig.manifests.templates"
+	// indexFieldArgoCDApplication indexes ReviewApps by "namespace/name" of Argo CD Application in status
+	indexFieldArgoCDApplication = "status.sync.application"
+	// indexFieldVariablesFromConfigMaps indexes ReviewApps by "namespace/name" of ConfigMaps in spec.variablesFrom
+	indexFieldVariablesFromConfigMaps = "spec.variablesFrom.configMapRef"
+	// indexFieldVariablesFromSecrets indexes ReviewApps by "namespace/name" of Secrets in spec.variablesFrom
+	indexFieldVariablesFromSecrets = "spec.variablesFrom.secretRef"
+)
+
+// setupTemplateIndexes registers indexes from templates to ReviewApps referring to them.
+// ReviewAppManagers are not indexed, because they don't render templates:
+// they copy references to ReviewApps and ReviewApps are re-rendered when the templates are changed.
+func setupTemplateIndexes(ctx context.Context, indexer client.FieldIndexer) error {
+	if err := indexer.IndexField(ctx, &dreamkastv1alpha1.ReviewApp{}, indexFieldApplicationTemplate, func(obj client.Object) []string {
+		return []string{models.NewReviewApp(obj.(*dreamkastv1alpha1.ReviewApp)).ApplicationTemplateKey()}
+	}); err != nil {
+		return err
+	}
+	return indexer.IndexField(ctx, &dreamkastv1alpha1.ReviewApp{}, indexFieldManifestsTemplates, func(obj client.Object) []string {
+		return models.NewReviewApp(obj.(*dreamkastv1alpha1.ReviewApp)).ManifestsTemplateKeys()
+	})
+}
+
+// setupApplicationIndex registers index from Argo CD Application to ReviewApp that pushed it
+func setupApplicationIndex(ctx context.Context, indexer client.FieldIndexer) error {
+	return indexer.IndexField(ctx, &dreamkastv1alpha1.ReviewApp{}, indexFieldArgoCDApplication, func(obj client.Object) []string {
+		key := models.NewReviewApp(obj.(*dreamkastv1alpha1.ReviewApp)).ArgoCDApplicationKey()
+		if key == "" {
+			return nil
+		}
+		return []string{key}
+	})
+}
+
+// setupVariablesFromIndexes registers indexes from ConfigMaps & Secrets to ReviewApps referring to them by spec.variablesFrom
+func setupVariablesFromIndexes(ctx context.Context, indexer client.FieldIndexer) error {
+	if err := indexer.IndexField(ctx, &dreamkastv1alpha1.ReviewApp{}, indexFieldVariablesFromConfigMaps, func(obj client.Object) []string {
+		return models.NewReviewApp(obj.(*dreamkastv1alpha1.ReviewApp)).VariablesFromConfigMapKeys()
+	}); err != nil {
+		return err
+	}
+	return indexer.IndexField(ctx, &dreamkastv1alpha1.ReviewApp{}, indexFieldVariablesFromSecrets, func(obj client.Object) []string {
+		return models.NewReviewApp(obj.(*dreamkastv1alpha1.ReviewApp)).VariablesFromSecretKeys()
+	})
+}
+
+func (r *ReviewAppReconciler) reviewAppsReferringTo(obj client.Object) []reconcile.Request {
+	var field string
+	switch obj.(type) {
+	case *corev1.ConfigMap:
+		field = indexFieldVariablesFromConfigMaps
+	case *corev1.Secret:
+		field = indexFieldVariablesFromSecrets
+	default:
+		return nil
+	}
+	key := types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}.String()
+	ras, err := r.K8sRepository.ListReviewAppsByIndex(context.Background(), field, key)
+	if err != nil {
+		r.Log.Error(err, "unable to list ReviewApps", field, key)
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, ra := range ras {
+		requests = append(requests, reconcile.Request{NamespacedName: ra.NamespaceName()})
+	}
+	return requests
+}
+
+// variablesFromDataChanged filters update events of ConfigMaps & Secrets whose data is not changed,
+// because some of them (e.g. ConfigMaps for leader election) are updated frequently.
+var variablesFromDataChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		switch oldObj := e.ObjectOld.(type) {
+		case *corev1.ConfigMap:
+			newObj, ok := e.ObjectNew.(*corev1.ConfigMap)
+			return !ok || !equality.Semantic.DeepEqual(oldObj.Data, newObj.Data) ||
+				!equality.Semantic.DeepEqual(oldObj.BinaryData, newObj.BinaryData)
+		case *corev1.Secret:
+			newObj, ok := e.ObjectNew.(*corev1.Secret)
+			return !ok || !equality.Semantic.DeepEqual(oldObj.Data, newObj.Data) ||
+				!equality.Semantic.DeepEqual(oldObj.StringData, newObj.StringData)
+		}
+		return true
+	},
+}
+
+func (r *ReviewAppReconciler) reviewAppsOwningApplication(obj client.Object) []reconcile.Request {
+	key := types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}.String()
+	ras, err := r.K8sRepository.ListReviewAppsByIndex(context.Background(), indexFieldArgoCDApplication, key)
+	if err != nil {
+		r.Log.Error(err, "unable to list ReviewApps", indexFieldArgoCDApplication, key)
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, ra := range ras {
+		requests = append(requests, reconcile.Request{NamespacedName: ra.NamespaceName()})
+	}
+	return requests
+}
+
+// applicationCommitHashChanged filters Argo CD Applications pushed by this operator.
+// Update events are passed only when the commit hash of App Repository in annotations is changed,
+// because Argo CD updates status of Applications frequently.
+var applicationCommitHashChanged = predicate.Funcs{
+	CreateFunc: func(e event.CreateEvent) bool {
+		return hasCommitHashAnnotation(e.Object)
+	},
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return hasCommitHashAnnotation(e.ObjectNew) &&
+			e.ObjectOld.GetAnnotations()[models.AnnotationAppCommitHashForArgoCDApplication] !=
+				e.ObjectNew.GetAnnotations()[models.AnnotationAppCommitHashForArgoCDApplication]
+	},
+	DeleteFunc: func(e event.DeleteEvent) bool {
+		return hasCommitHashAnnotation(e.Object)
+	},
+	GenericFunc: func(e event.GenericEvent) bool {
+		return hasCommitHashAnnotation(e.Object)
+	},
+}
+
+func hasCommitHashAnnotation(obj client.Object) bool {
+	_, ok := obj.GetAnnotations()[models.AnnotationAppCommitHashForArgoCDApplication]
+	return ok
+}
+
+func (r *ReviewAppReconciler) reviewAppsUsingTemplate(obj client.Object) []reconcile.Request {
+	var field string
+	switch obj.(type) {
+	case *dreamkastv1alpha1.ApplicationTemplate:
+		field = indexFieldApplicationTemplate
+	case *dreamkastv1alpha1.ManifestsTemplate:
+		field = indexFieldManifestsTemplates
+	default:
+		return nil
+	}
+	key := types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}.String()
+	ras, err := r.K8sRepository.ListReviewAppsByIndex(context.Background(), field, key)
+	if err != nil {
+		r.Log.Error(err, "unable to list ReviewApps", field, key)
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, ra := range ras {
+		requests = append(requests, reconcile.Request{NamespacedName: ra.NamespaceName()})
+	}
+	return requests
+}
+
+// batchedEnqueueRequestsFromMapFunc enqueues requests mapped from the object like handler.EnqueueRequestsFromMapFunc.
+// If batchSize is positive, requests are enqueued in batches of batchSize every interval,
+// so that changes of templates are rolled out to ReviewApps gradually.
+type batchedEnqueueRequestsFromMapFunc struct {
+	toRequests handler.MapFunc
+	batchSize  int
+	interval   time.Duration
+}
+
+var _ handler.EventHandler = &batchedEnqueueRequestsFromMapFunc{}
+
+func (e *batchedEnqueueRequestsFromMapFunc) Create(evt event.CreateEvent, q workqueue.RateLimitingInterface) {
+	e.enqueue(evt.Object, q)
+}
+
+func (e *batchedEnqueueRequestsFromMapFunc) Update(evt event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	e.enqueue(evt.ObjectNew, q)
+}
+
+func (e *batchedEnqueueRequestsFromMapFunc) Delete(evt event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	e.enqueue(evt.Object, q)
+}
+
+func (e *batchedEnqueueRequestsFromMapFunc) Generic(evt event.GenericEvent, q workqueue.RateLimitingInterface) {
+	e.enqueue(evt.Object, q)
+}
+
+func (e *batchedEnqueueRequestsFromMapFunc) enqueue(obj client.Object, q workqueue.RateLimitingInterface) {
+	requests := e.toRequests(obj)
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].String() < requests[j].String()
+	})
+	for i, req := range requests {
+		if e.batchSize <= 0 {
+			q.Add(req)
+			continue
+		}
+		q.AddAfter(req, time.Duration(i/e.batchSize)*e.interval)
+	}
+}
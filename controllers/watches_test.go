@@ -0,0 +1,438 @@
+//go:build !integration_test
+// +build !integration_test
+
+package controllers
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	dreamkastv1alpha1 "github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
+	"github.com/cloudnativedaysjp/reviewapp-operator/domain/mock"
+	"github.com/cloudnativedaysjp/reviewapp-operator/domain/models"
+)
+
+func TestReviewAppReconciler_reviewAppsReferringTo(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	testNamespace := testRaNormal.Namespace
+
+	newReviewApp := func(name string) models.ReviewApp {
+		ra := testRaNormal
+		ra.ObjectMeta = metav1.ObjectMeta{Name: name, Namespace: testNamespace}
+		return ra
+	}
+
+	tests := []struct {
+		name      string
+		obj       client.Object
+		wantField string
+		listErr   error
+		want      []reconcile.Request
+	}{
+		{
+			name:      "ConfigMap",
+			obj:       &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace}},
+			wantField: indexFieldVariablesFromConfigMaps,
+			want:      []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: "ra-1"}}},
+		},
+		{
+			name:      "Secret",
+			obj:       &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace}},
+			wantField: indexFieldVariablesFromSecrets,
+			want:      []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: "ra-1"}}},
+		},
+		{
+			name:      "failed to list ReviewApps",
+			obj:       &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace}},
+			wantField: indexFieldVariablesFromSecrets,
+			listErr:   fmt.Errorf("internal error"),
+			want:      nil,
+		},
+		{
+			name: "other object",
+			obj:  &dreamkastv1alpha1.JobTemplate{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: testNamespace}},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := mock.NewMockKubernetesRepository(mockCtrl)
+			if tt.wantField != "" {
+				key := types.NamespacedName{Namespace: tt.obj.GetNamespace(), Name: tt.obj.GetName()}.String()
+				m.EXPECT().ListReviewAppsByIndex(gomock.Any(), tt.wantField, key).
+					Return([]models.ReviewApp{newReviewApp("ra-1")}, tt.listErr)
+			}
+			r := &ReviewAppReconciler{
+				Log:           testLogger,
+				K8sRepository: m,
+			}
+			if diff := cmp.Diff(r.reviewAppsReferringTo(tt.obj), tt.want); diff != "" {
+				t.Errorf("ReviewAppReconciler.reviewAppsReferringTo() is unexpected:\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestVariablesFromDataChanged(t *testing.T) {
+	newConfigMap := func(resourceVersion string, data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test-ns", ResourceVersion: resourceVersion},
+			Data:       data,
+		}
+	}
+	newSecret := func(resourceVersion string, data map[string][]byte) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test-ns", ResourceVersion: resourceVersion},
+			Data:       data,
+		}
+	}
+
+	tests := []struct {
+		name string
+		evt  interface{}
+		want bool
+	}{
+		{
+			name: "create ConfigMap",
+			evt:  event.CreateEvent{Object: newConfigMap("1", nil)},
+			want: true,
+		},
+		{
+			name: "update data of ConfigMap",
+			evt:  event.UpdateEvent{ObjectOld: newConfigMap("1", map[string]string{"A": "a"}), ObjectNew: newConfigMap("2", map[string]string{"A": "b"})},
+			want: true,
+		},
+		{
+			name: "update only metadata of ConfigMap",
+			evt:  event.UpdateEvent{ObjectOld: newConfigMap("1", map[string]string{"A": "a"}), ObjectNew: newConfigMap("2", map[string]string{"A": "a"})},
+			want: false,
+		},
+		{
+			name: "update data of Secret",
+			evt:  event.UpdateEvent{ObjectOld: newSecret("1", map[string][]byte{"A": []byte("a")}), ObjectNew: newSecret("2", map[string][]byte{"A": []byte("b")})},
+			want: true,
+		},
+		{
+			name: "update only metadata of Secret",
+			evt:  event.UpdateEvent{ObjectOld: newSecret("1", map[string][]byte{"A": []byte("a")}), ObjectNew: newSecret("2", map[string][]byte{"A": []byte("a")})},
+			want: false,
+		},
+		{
+			name: "delete Secret",
+			evt:  event.DeleteEvent{Object: newSecret("1", nil)},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got bool
+			switch evt := tt.evt.(type) {
+			case event.CreateEvent:
+				got = variablesFromDataChanged.Create(evt)
+			case event.UpdateEvent:
+				got = variablesFromDataChanged.Update(evt)
+			case event.DeleteEvent:
+				got = variablesFromDataChanged.Delete(evt)
+			}
+			if got != tt.want {
+				t.Errorf("variablesFromDataChanged = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReviewAppReconciler_reviewAppsUsingTemplate(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	testNamespace := testRaNormal.Namespace
+
+	newReviewApp := func(name string) models.ReviewApp {
+		ra := testRaNormal
+		ra.ObjectMeta = metav1.ObjectMeta{Name: name, Namespace: testNamespace}
+		return ra
+	}
+
+	tests := []struct {
+		name      string
+		obj       client.Object
+		wantField string
+		listErr   error
+		want      []reconcile.Request
+	}{
+		{
+			name:      "ApplicationTemplate",
+			obj:       &dreamkastv1alpha1.ApplicationTemplate{ObjectMeta: metav1.ObjectMeta{Name: "at", Namespace: testNamespace}},
+			wantField: indexFieldApplicationTemplate,
+			want: []reconcile.Request{
+				{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: "ra-1"}},
+				{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: "ra-2"}},
+			},
+		},
+		{
+			name:      "ManifestsTemplate",
+			obj:       &dreamkastv1alpha1.ManifestsTemplate{ObjectMeta: metav1.ObjectMeta{Name: "mt", Namespace: testNamespace}},
+			wantField: indexFieldManifestsTemplates,
+			want: []reconcile.Request{
+				{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: "ra-1"}},
+				{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: "ra-2"}},
+			},
+		},
+		{
+			name:      "failed to list ReviewApps",
+			obj:       &dreamkastv1alpha1.ManifestsTemplate{ObjectMeta: metav1.ObjectMeta{Name: "mt", Namespace: testNamespace}},
+			wantField: indexFieldManifestsTemplates,
+			listErr:   fmt.Errorf("internal error"),
+			want:      nil,
+		},
+		{
+			name: "other object",
+			obj:  &dreamkastv1alpha1.JobTemplate{ObjectMeta: metav1.ObjectMeta{Name: "jt", Namespace: testNamespace}},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := mock.NewMockKubernetesRepository(mockCtrl)
+			if tt.wantField != "" {
+				key := types.NamespacedName{Namespace: tt.obj.GetNamespace(), Name: tt.obj.GetName()}.String()
+				m.EXPECT().ListReviewAppsByIndex(gomock.Any(), tt.wantField, key).
+					Return([]models.ReviewApp{newReviewApp("ra-1"), newReviewApp("ra-2")}, tt.listErr)
+			}
+			r := &ReviewAppReconciler{
+				Log:           testLogger,
+				K8sRepository: m,
+			}
+			if diff := cmp.Diff(r.reviewAppsUsingTemplate(tt.obj), tt.want); diff != "" {
+				t.Errorf("ReviewAppReconciler.reviewAppsUsingTemplate() is unexpected:\n%v", diff)
+			}
+		})
+	}
+}
+
+// fakeQueue records requests added to workqueue with their delay
+type fakeQueue struct {
+	workqueue.RateLimitingInterface
+	added map[string]time.Duration
+}
+
+func (q *fakeQueue) Add(item interface{}) {
+	q.AddAfter(item, 0)
+}
+
+func (q *fakeQueue) AddAfter(item interface{}, duration time.Duration) {
+	q.added[item.(reconcile.Request).Name] = duration
+}
+
+func TestBatchedEnqueueRequestsFromMapFunc_enqueue(t *testing.T) {
+	toRequests := func(names ...string) func(client.Object) []reconcile.Request {
+		return func(client.Object) []reconcile.Request {
+			var requests []reconcile.Request
+			for _, name := range names {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: name}})
+			}
+			return requests
+		}
+	}
+
+	tests := []struct {
+		name       string
+		toRequests func(client.Object) []reconcile.Request
+		batchSize  int
+		interval   time.Duration
+		want       map[string]time.Duration
+	}{
+		{
+			name:       "without batch",
+			toRequests: toRequests("ra-3", "ra-1", "ra-2"),
+			batchSize:  0,
+			interval:   time.Minute,
+			want:       map[string]time.Duration{"ra-1": 0, "ra-2": 0, "ra-3": 0},
+		},
+		{
+			name:       "batches of 2 in order of name",
+			toRequests: toRequests("ra-5", "ra-3", "ra-1", "ra-4", "ra-2"),
+			batchSize:  2,
+			interval:   time.Minute,
+			want: map[string]time.Duration{
+				"ra-1": 0, "ra-2": 0,
+				"ra-3": time.Minute, "ra-4": time.Minute,
+				"ra-5": 2 * time.Minute,
+			},
+		},
+		{
+			name:       "batch size larger than number of requests",
+			toRequests: toRequests("ra-2", "ra-1"),
+			batchSize:  10,
+			interval:   time.Minute,
+			want:       map[string]time.Duration{"ra-1": 0, "ra-2": 0},
+		},
+		{
+			name:       "batch size of 1",
+			toRequests: toRequests("ra-1", "ra-2", "ra-3"),
+			batchSize:  1,
+			interval:   30 * time.Second,
+			want:       map[string]time.Duration{"ra-1": 0, "ra-2": 30 * time.Second, "ra-3": time.Minute},
+		},
+		{
+			name:       "no requests",
+			toRequests: toRequests(),
+			batchSize:  2,
+			interval:   time.Minute,
+			want:       map[string]time.Duration{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			e := &batchedEnqueueRequestsFromMapFunc{
+				toRequests: tt.toRequests,
+				batchSize:  tt.batchSize,
+				interval:   tt.interval,
+			}
+			q := &fakeQueue{added: map[string]time.Duration{}}
+			e.enqueue(&dreamkastv1alpha1.ManifestsTemplate{}, q)
+			if diff := cmp.Diff(tt.want, q.added); diff != "" {
+				t.Errorf("batchedEnqueueRequestsFromMapFunc.enqueue() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReviewAppReconciler_reviewAppsOwningApplication(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	testNamespace := testRaNormal.Namespace
+	testApplication := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "argocd"}}
+
+	tests := []struct {
+		name    string
+		listed  []models.ReviewApp
+		listErr error
+		want    []reconcile.Request
+	}{
+		{
+			name:   "ReviewApp pushed the Application",
+			listed: []models.ReviewApp{testRaNormal},
+			want:   []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testRaNormal.Name}}},
+		},
+		{
+			name:   "no ReviewApp pushed the Application",
+			listed: nil,
+			want:   nil,
+		},
+		{
+			name:    "failed to list ReviewApps",
+			listErr: fmt.Errorf("internal error"),
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := mock.NewMockKubernetesRepository(mockCtrl)
+			m.EXPECT().ListReviewAppsByIndex(gomock.Any(), indexFieldArgoCDApplication, "argocd/app").
+				Return(tt.listed, tt.listErr)
+			r := &ReviewAppReconciler{
+				Log:           testLogger,
+				K8sRepository: m,
+			}
+			if diff := cmp.Diff(r.reviewAppsOwningApplication(testApplication), tt.want); diff != "" {
+				t.Errorf("ReviewAppReconciler.reviewAppsOwningApplication() is unexpected:\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestApplicationCommitHashChanged(t *testing.T) {
+	newApplication := func(commitHash string, resourceVersion string) client.Object {
+		obj := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{
+			Name: "app", Namespace: "argocd", ResourceVersion: resourceVersion,
+		}}
+		if commitHash != "" {
+			obj.Annotations = map[string]string{models.AnnotationAppCommitHashForArgoCDApplication: commitHash}
+		}
+		return obj
+	}
+
+	tests := []struct {
+		name string
+		evt  interface{}
+		want bool
+	}{
+		{
+			name: "create Application pushed by operator",
+			evt:  event.CreateEvent{Object: newApplication("abc", "1")},
+			want: true,
+		},
+		{
+			name: "create Application not pushed by operator",
+			evt:  event.CreateEvent{Object: newApplication("", "1")},
+			want: false,
+		},
+		{
+			name: "update annotation of commit hash",
+			evt:  event.UpdateEvent{ObjectOld: newApplication("abc", "1"), ObjectNew: newApplication("def", "2")},
+			want: true,
+		},
+		{
+			name: "update only status",
+			evt:  event.UpdateEvent{ObjectOld: newApplication("abc", "1"), ObjectNew: newApplication("abc", "2")},
+			want: false,
+		},
+		{
+			name: "update Application not pushed by operator",
+			evt:  event.UpdateEvent{ObjectOld: newApplication("", "1"), ObjectNew: newApplication("", "2")},
+			want: false,
+		},
+		{
+			name: "delete Application pushed by operator",
+			evt:  event.DeleteEvent{Object: newApplication("abc", "1")},
+			want: true,
+		},
+		{
+			name: "generic event of Application not pushed by operator",
+			evt:  event.GenericEvent{Object: newApplication("", "1")},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got bool
+			switch evt := tt.evt.(type) {
+			case event.CreateEvent:
+				got = applicationCommitHashChanged.Create(evt)
+			case event.UpdateEvent:
+				got = applicationCommitHashChanged.Update(evt)
+			case event.DeleteEvent:
+				got = applicationCommitHashChanged.Delete(evt)
+			case event.GenericEvent:
+				got = applicationCommitHashChanged.Generic(evt)
+			}
+			if got != tt.want {
+				t.Errorf("applicationCommitHashChanged = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
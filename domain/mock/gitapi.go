@@ -49,34 +49,49 @@ func (mr *MockGitAPIMockRecorder) CommentToPullRequest(ctx, pr, comment interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommentToPullRequest", reflect.TypeOf((*MockGitAPI)(nil).CommentToPullRequest), ctx, pr, comment)
 }
 
-// GetCommitHashes mocks base method.
-func (m *MockGitAPI) GetCommitHashes(ctx context.Context, pr models.PullRequest) ([]string, error) {
+// GetPullRequest mocks base method.
+func (m *MockGitAPI) GetPullRequest(ctx context.Context, appRepoTarget models.AppRepoTarget, prNum int) (models.PullRequest, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCommitHashes", ctx, pr)
-	ret0, _ := ret[0].([]string)
+	ret := m.ctrl.Call(m, "GetPullRequest", ctx, appRepoTarget, prNum)
+	ret0, _ := ret[0].(models.PullRequest)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCommitHashes indicates an expected call of GetCommitHashes.
-func (mr *MockGitAPIMockRecorder) GetCommitHashes(ctx, pr interface{}) *gomock.Call {
+// GetPullRequest indicates an expected call of GetPullRequest.
+func (mr *MockGitAPIMockRecorder) GetPullRequest(ctx, appRepoTarget, prNum interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommitHashes", reflect.TypeOf((*MockGitAPI)(nil).GetCommitHashes), ctx, pr)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPullRequest", reflect.TypeOf((*MockGitAPI)(nil).GetPullRequest), ctx, appRepoTarget, prNum)
 }
 
-// GetPullRequest mocks base method.
-func (m *MockGitAPI) GetPullRequest(ctx context.Context, appRepoTarget models.AppRepoTarget, prNum int) (models.PullRequest, error) {
+// HasWritePermission mocks base method.
+func (m *MockGitAPI) HasWritePermission(ctx context.Context, pr models.PullRequest, username string) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPullRequest", ctx, appRepoTarget, prNum)
-	ret0, _ := ret[0].(models.PullRequest)
+	ret := m.ctrl.Call(m, "HasWritePermission", ctx, pr, username)
+	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetPullRequest indicates an expected call of GetPullRequest.
-func (mr *MockGitAPIMockRecorder) GetPullRequest(ctx, appRepoTarget, prNum interface{}) *gomock.Call {
+// HasWritePermission indicates an expected call of HasWritePermission.
+func (mr *MockGitAPIMockRecorder) HasWritePermission(ctx, pr, username interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPullRequest", reflect.TypeOf((*MockGitAPI)(nil).GetPullRequest), ctx, appRepoTarget, prNum)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasWritePermission", reflect.TypeOf((*MockGitAPI)(nil).HasWritePermission), ctx, pr, username)
+}
+
+// ListCommentsOfPullRequest mocks base method.
+func (m *MockGitAPI) ListCommentsOfPullRequest(ctx context.Context, pr models.PullRequest, since string) (models.PullRequestComments, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCommentsOfPullRequest", ctx, pr, since)
+	ret0, _ := ret[0].(models.PullRequestComments)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCommentsOfPullRequest indicates an expected call of ListCommentsOfPullRequest.
+func (mr *MockGitAPIMockRecorder) ListCommentsOfPullRequest(ctx, pr, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCommentsOfPullRequest", reflect.TypeOf((*MockGitAPI)(nil).ListCommentsOfPullRequest), ctx, pr, since)
 }
 
 // ListOpenPullRequests mocks base method.
@@ -94,6 +109,20 @@ func (mr *MockGitAPIMockRecorder) ListOpenPullRequests(ctx, appRepoTarget interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOpenPullRequests", reflect.TypeOf((*MockGitAPI)(nil).ListOpenPullRequests), ctx, appRepoTarget)
 }
 
+// ReactToComment mocks base method.
+func (m *MockGitAPI) ReactToComment(ctx context.Context, pr models.PullRequest, commentID int64, reaction string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReactToComment", ctx, pr, commentID, reaction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReactToComment indicates an expected call of ReactToComment.
+func (mr *MockGitAPIMockRecorder) ReactToComment(ctx, pr, commentID, reaction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReactToComment", reflect.TypeOf((*MockGitAPI)(nil).ReactToComment), ctx, pr, commentID, reaction)
+}
+
 // WithCredential mocks base method.
 func (m *MockGitAPI) WithCredential(credential models.GitCredential) error {
 	m.ctrl.T.Helper()
@@ -88,6 +88,21 @@ func (mr *MockGitCommandMockRecorder) DeleteFiles(arg0, arg1 interface{}, arg2 .
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFiles", reflect.TypeOf((*MockGitCommand)(nil).DeleteFiles), varargs...)
 }
 
+// Diff mocks base method.
+func (m *MockGitCommand) Diff(ctx context.Context, gp models.InfraRepoLocalDir) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Diff", ctx, gp)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Diff indicates an expected call of Diff.
+func (mr *MockGitCommandMockRecorder) Diff(ctx, gp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Diff", reflect.TypeOf((*MockGitCommand)(nil).Diff), ctx, gp)
+}
+
 // ForceClone mocks base method.
 func (m *MockGitCommand) ForceClone(arg0 context.Context, arg1 models.InfraRepoTarget) (models.InfraRepoLocalDir, error) {
 	m.ctrl.T.Helper()
@@ -103,6 +118,21 @@ func (mr *MockGitCommandMockRecorder) ForceClone(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceClone", reflect.TypeOf((*MockGitCommand)(nil).ForceClone), arg0, arg1)
 }
 
+// GlobFiles mocks base method.
+func (m *MockGitCommand) GlobFiles(ctx context.Context, gp models.InfraRepoLocalDir, pattern string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GlobFiles", ctx, gp, pattern)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GlobFiles indicates an expected call of GlobFiles.
+func (mr *MockGitCommandMockRecorder) GlobFiles(ctx, gp, pattern interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GlobFiles", reflect.TypeOf((*MockGitCommand)(nil).GlobFiles), ctx, gp, pattern)
+}
+
 // WithCredential mocks base method.
 func (m *MockGitCommand) WithCredential(credential models.GitCredential) error {
 	m.ctrl.T.Helper()
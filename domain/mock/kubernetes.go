@@ -11,6 +11,7 @@ import (
 	models "github.com/cloudnativedaysjp/reviewapp-operator/domain/models"
 	gomock "github.com/golang/mock/gomock"
 	v1 "k8s.io/api/batch/v1"
+	v10 "k8s.io/api/core/v1"
 )
 
 // MockKubernetesRepository is a mock of KubernetesRepository interface.
@@ -127,6 +128,66 @@ func (mr *MockKubernetesRepositoryMockRecorder) GetArgoCDAppFromReviewAppStatus(
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArgoCDAppFromReviewAppStatus", reflect.TypeOf((*MockKubernetesRepository)(nil).GetArgoCDAppFromReviewAppStatus), ctx, raStatus)
 }
 
+// GetConfigMapData mocks base method.
+func (m *MockKubernetesRepository) GetConfigMapData(ctx context.Context, namespace, name string) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConfigMapData", ctx, namespace, name)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConfigMapData indicates an expected call of GetConfigMapData.
+func (mr *MockKubernetesRepositoryMockRecorder) GetConfigMapData(ctx, namespace, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigMapData", reflect.TypeOf((*MockKubernetesRepository)(nil).GetConfigMapData), ctx, namespace, name)
+}
+
+// GetJob mocks base method.
+func (m *MockKubernetesRepository) GetJob(ctx context.Context, namespace, name string) (*v1.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJob", ctx, namespace, name)
+	ret0, _ := ret[0].(*v1.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJob indicates an expected call of GetJob.
+func (mr *MockKubernetesRepositoryMockRecorder) GetJob(ctx, namespace, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJob", reflect.TypeOf((*MockKubernetesRepository)(nil).GetJob), ctx, namespace, name)
+}
+
+// GetJobLogs mocks base method.
+func (m *MockKubernetesRepository) GetJobLogs(ctx context.Context, job *v1.Job, tailLines, limitBytes int64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJobLogs", ctx, job, tailLines, limitBytes)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJobLogs indicates an expected call of GetJobLogs.
+func (mr *MockKubernetesRepositoryMockRecorder) GetJobLogs(ctx, job, tailLines, limitBytes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobLogs", reflect.TypeOf((*MockKubernetesRepository)(nil).GetJobLogs), ctx, job, tailLines, limitBytes)
+}
+
+// GetJobTemplate mocks base method.
+func (m *MockKubernetesRepository) GetJobTemplate(ctx context.Context, namespace, name string) (models.JobTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJobTemplate", ctx, namespace, name)
+	ret0, _ := ret[0].(models.JobTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJobTemplate indicates an expected call of GetJobTemplate.
+func (mr *MockKubernetesRepositoryMockRecorder) GetJobTemplate(ctx, namespace, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobTemplate", reflect.TypeOf((*MockKubernetesRepository)(nil).GetJobTemplate), ctx, namespace, name)
+}
+
 // GetLatestJobFromLabel mocks base method.
 func (m *MockKubernetesRepository) GetLatestJobFromLabel(ctx context.Context, namespace, labelKey, labelValue string) (*v1.Job, error) {
 	m.ctrl.T.Helper()
@@ -157,6 +218,21 @@ func (mr *MockKubernetesRepositoryMockRecorder) GetManifestsTemplate(ctx, m inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManifestsTemplate", reflect.TypeOf((*MockKubernetesRepository)(nil).GetManifestsTemplate), ctx, m)
 }
 
+// GetNamespace mocks base method.
+func (m *MockKubernetesRepository) GetNamespace(ctx context.Context, name string) (*v10.Namespace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNamespace", ctx, name)
+	ret0, _ := ret[0].(*v10.Namespace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNamespace indicates an expected call of GetNamespace.
+func (mr *MockKubernetesRepositoryMockRecorder) GetNamespace(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNamespace", reflect.TypeOf((*MockKubernetesRepository)(nil).GetNamespace), ctx, name)
+}
+
 // GetPreStopJobTemplate mocks base method.
 func (m *MockKubernetesRepository) GetPreStopJobTemplate(ctx context.Context, ra models.ReviewApp) (models.JobTemplate, error) {
 	m.ctrl.T.Helper()
@@ -217,6 +293,66 @@ func (mr *MockKubernetesRepositoryMockRecorder) GetSecretValue(ctx, namespace, m
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretValue", reflect.TypeOf((*MockKubernetesRepository)(nil).GetSecretValue), ctx, namespace, m)
 }
 
+// GetSecretValuesFrom mocks base method.
+func (m_2 *MockKubernetesRepository) GetSecretValuesFrom(ctx context.Context, m models.ReviewAppOrReviewAppManager) ([]string, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "GetSecretValuesFrom", ctx, m)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecretValuesFrom indicates an expected call of GetSecretValuesFrom.
+func (mr *MockKubernetesRepositoryMockRecorder) GetSecretValuesFrom(ctx, m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecretValuesFrom", reflect.TypeOf((*MockKubernetesRepository)(nil).GetSecretValuesFrom), ctx, m)
+}
+
+// GetVariablesFrom mocks base method.
+func (m_2 *MockKubernetesRepository) GetVariablesFrom(ctx context.Context, m models.ReviewAppOrReviewAppManager) (map[string]string, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "GetVariablesFrom", ctx, m)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVariablesFrom indicates an expected call of GetVariablesFrom.
+func (mr *MockKubernetesRepositoryMockRecorder) GetVariablesFrom(ctx, m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVariablesFrom", reflect.TypeOf((*MockKubernetesRepository)(nil).GetVariablesFrom), ctx, m)
+}
+
+// ListReviewApps mocks base method.
+func (m *MockKubernetesRepository) ListReviewApps(ctx context.Context, namespace string) ([]models.ReviewApp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReviewApps", ctx, namespace)
+	ret0, _ := ret[0].([]models.ReviewApp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReviewApps indicates an expected call of ListReviewApps.
+func (mr *MockKubernetesRepositoryMockRecorder) ListReviewApps(ctx, namespace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReviewApps", reflect.TypeOf((*MockKubernetesRepository)(nil).ListReviewApps), ctx, namespace)
+}
+
+// ListReviewAppsByIndex mocks base method.
+func (m *MockKubernetesRepository) ListReviewAppsByIndex(ctx context.Context, field, value string) ([]models.ReviewApp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReviewAppsByIndex", ctx, field, value)
+	ret0, _ := ret[0].([]models.ReviewApp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReviewAppsByIndex indicates an expected call of ListReviewAppsByIndex.
+func (mr *MockKubernetesRepositoryMockRecorder) ListReviewAppsByIndex(ctx, field, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReviewAppsByIndex", reflect.TypeOf((*MockKubernetesRepository)(nil).ListReviewAppsByIndex), ctx, field, value)
+}
+
 // PatchReviewAppStatus mocks base method.
 func (m *MockKubernetesRepository) PatchReviewAppStatus(ctx context.Context, ra models.ReviewApp) error {
 	m.ctrl.T.Helper()
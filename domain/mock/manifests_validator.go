@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./domain/repositories/manifests_validator.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/cloudnativedaysjp/reviewapp-operator/domain/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockManifestsValidator is a mock of ManifestsValidator interface.
+type MockManifestsValidator struct {
+	ctrl     *gomock.Controller
+	recorder *MockManifestsValidatorMockRecorder
+}
+
+// MockManifestsValidatorMockRecorder is the mock recorder for MockManifestsValidator.
+type MockManifestsValidatorMockRecorder struct {
+	mock *MockManifestsValidator
+}
+
+// NewMockManifestsValidator creates a new mock instance.
+func NewMockManifestsValidator(ctrl *gomock.Controller) *MockManifestsValidator {
+	mock := &MockManifestsValidator{ctrl: ctrl}
+	mock.recorder = &MockManifestsValidatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockManifestsValidator) EXPECT() *MockManifestsValidatorMockRecorder {
+	return m.recorder
+}
+
+// Validate mocks base method.
+func (m *MockManifestsValidator) Validate(ctx context.Context, l models.InfraRepoLocalDir, dirpath string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", ctx, l, dirpath)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockManifestsValidatorMockRecorder) Validate(ctx, l, dirpath interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockManifestsValidator)(nil).Validate), ctx, l, dirpath)
+}
@@ -22,15 +22,30 @@ func (m ApplicationTemplate) CandidateStr() string {
 	return m.Spec.CandidateTemplate
 }
 
+// VariantStr returns template of the variant. If the variant does not exist, it returns error.
+func (m ApplicationTemplate) VariantStr(variant string) (string, error) {
+	switch variant {
+	case VariantCandidate:
+		return m.CandidateStr(), nil
+	case VariantStable:
+		return m.StableStr(), nil
+	}
+	if template, ok := m.Spec.Variants[variant]; ok {
+		return template, nil
+	}
+	return "", errVariantNotFound("ApplicationTemplate", m.namespacedName(), variant)
+}
+
+func (m ApplicationTemplate) namespacedName() types.NamespacedName {
+	return types.NamespacedName{Namespace: m.Namespace, Name: m.Name}
+}
+
 func (m ApplicationTemplate) GenerateApplication(pr PullRequest, v Templator) (Application, error) {
-	var template string
-	var err error
-	if pr.IsCandidate() {
-		template = m.CandidateStr()
-	} else {
-		template = m.StableStr()
+	template, err := m.VariantStr(pr.TemplateVariant())
+	if err != nil {
+		return "", err
 	}
-	application, err := v.Templating(template)
+	application, err := v.templateFile("ApplicationTemplate", m.namespacedName(), pr.TemplateVariant(), template)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,47 @@
+//go:build !integration_test
+// +build !integration_test
+
+package models
+
+import (
+	"testing"
+
+	dreamkastv1alpha1 "github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
+	myerrors "github.com/cloudnativedaysjp/reviewapp-operator/errors"
+)
+
+func TestApplicationTemplate_VariantStr(t *testing.T) {
+	at := ApplicationTemplate{Spec: dreamkastv1alpha1.ApplicationTemplateSpec{
+		StableTemplate:    "stable",
+		CandidateTemplate: "candidate",
+		Variants:          map[string]string{"next": "next"},
+	}}
+	tests := []struct {
+		name    string
+		variant string
+		want    string
+		wantErr bool
+	}{
+		{name: "stable", variant: VariantStable, want: "stable"},
+		{name: "candidate", variant: VariantCandidate, want: "candidate"},
+		{name: "named variant", variant: "next", want: "next"},
+		{name: "variant does not exist", variant: "other", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := at.VariantStr(tt.variant)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ApplicationTemplate.VariantStr() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil && !myerrors.IsTemplatingFailed(err) {
+				t.Errorf("ApplicationTemplate.VariantStr() error = %v, want TemplatingFailed", err)
+			}
+			if got != tt.want {
+				t.Errorf("ApplicationTemplate.VariantStr() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,46 +1,146 @@
 package models
 
-import "regexp"
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+
+	dreamkastv1alpha1 "github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
+	myerrors "github.com/cloudnativedaysjp/reviewapp-operator/errors"
+)
 
 const (
 	candidateLabelName = "candidate-template"
+
+	VariantStable    = "stable"
+	VariantCandidate = "candidate"
 )
 
+// errVariantNotFound returns error that the template does not have the variant selected for PR
+func errVariantNotFound(kind string, nn types.NamespacedName, variant string) error {
+	return myerrors.NewTemplatingFailed(xerrors.Errorf("variant %s does not exist", variant), kind, nn, variant, 0)
+}
+
+// defaultVariantRules is used if VariantRules is not specified
+var defaultVariantRules = []dreamkastv1alpha1.VariantRule{
+	{Variant: VariantCandidate, Labels: []string{candidateLabelName}},
+}
+
 type PullRequest struct {
 	Organization     string
 	Repository       string
 	Branch           string
+	BaseBranch       string
 	Number           int
 	LatestCommitHash string
 	Title            string
 	Labels           []string
+	Author           string
+	URL              string
+	CreatedAt        string
+	Body             string
+	// UseCandidate is flag to use candidate templates even if PR does not match rules of candidate variant
+	UseCandidate bool
+	// Variant is name of variant of templates that is selected by VariantRules
+	Variant string
 }
 
-func NewPullRequest(organization, repository, branch string, number int, headCommitHash string, title string, labels []string) PullRequest {
-	return PullRequest{
-		Organization:     organization,
-		Repository:       repository,
-		Branch:           branch,
-		Number:           number,
-		LatestCommitHash: headCommitHash,
-		Title:            title,
-		Labels:           labels,
+var variableOverridesBlockExp = regexp.MustCompile("(?s)```reviewapp[ \t]*\r?\n(.*?)```")
+
+// VariablesInBody returns variables (format is same as spec.variables) written in
+// fenced code block with "reviewapp" info string in the description of PR.
+func (m PullRequest) VariablesInBody() ([]string, error) {
+	match := variableOverridesBlockExp.FindStringSubmatch(m.Body)
+	if match == nil {
+		return nil, nil
+	}
+	// numbers are decoded as json.Number to be written as it is (e.g. 1000000 is not written as 1e+06)
+	j, err := yaml.YAMLToJSON([]byte(match[1]))
+	if err != nil {
+		return nil, xerrors.Errorf("reviewapp block in the description of PR #%d is invalid: %w", m.Number, err)
+	}
+	var values map[string]interface{}
+	d := json.NewDecoder(bytes.NewReader(j))
+	d.UseNumber()
+	if err := d.Decode(&values); err != nil {
+		return nil, xerrors.Errorf("reviewapp block in the description of PR #%d is invalid: %w", m.Number, err)
+	}
+	var vars []string
+	for key, val := range values {
+		switch val.(type) {
+		case map[string]interface{}, []interface{}:
+			return nil, xerrors.Errorf("reviewapp block in the description of PR #%d is invalid: value of %s is not scalar", m.Number, key)
+		case nil:
+			val = ""
+		}
+		vars = append(vars, fmt.Sprintf("%s=%v", key, val))
 	}
+	// sort to keep the order of variables in ReviewApp's spec
+	sort.Strings(vars)
+	return vars, nil
 }
 
-func (m PullRequest) IsCandidate() bool {
-	isCandidate := false
-	for _, l := range m.Labels {
-		if l == candidateLabelName {
-			isCandidate = true
+// WithVariant returns PullRequest whose Variant is selected by rules
+func (m PullRequest) WithVariant(rules []dreamkastv1alpha1.VariantRule) (PullRequest, error) {
+	variant, err := m.selectVariant(rules)
+	if err != nil {
+		return PullRequest{}, err
+	}
+	m.Variant = variant
+	return m, nil
+}
+
+func (m PullRequest) selectVariant(rules []dreamkastv1alpha1.VariantRule) (string, error) {
+	if m.UseCandidate {
+		return VariantCandidate, nil
+	}
+	if len(rules) == 0 {
+		rules = defaultVariantRules
+	}
+	for _, rule := range rules {
+		for _, ruleLabel := range rule.Labels {
+			for _, l := range m.Labels {
+				if l == ruleLabel {
+					return rule.Variant, nil
+				}
+			}
+		}
+		if rule.BranchExp != "" {
+			r, err := regexp.Compile(rule.BranchExp)
+			if err != nil {
+				return "", xerrors.Errorf("branchExp of variant %s is invalid: %w", rule.Variant, err)
+			}
+			if r.MatchString(m.Branch) {
+				return rule.Variant, nil
+			}
 		}
 	}
-	return isCandidate
+	return VariantStable, nil
+}
+
+// TemplateVariant returns name of variant of templates.
+// If Variant has not been selected by WithVariant, it is selected by default rules.
+func (m PullRequest) TemplateVariant() string {
+	if m.Variant != "" {
+		return m.Variant
+	}
+	variant, _ := m.selectVariant(nil)
+	return variant
+}
+
+func (m PullRequest) IsCandidate() bool {
+	return m.TemplateVariant() == VariantCandidate
 }
 
 type PullRequests []PullRequest
 
-func (m PullRequests) ExcludeSpecificPR(ra ReviewAppOrReviewAppManager) PullRequests {
+func (m PullRequests) ExcludeSpecificPR(ra ReviewAppOrReviewAppManager) (PullRequests, error) {
 	ignoreLabels := ra.AppRepoTarget().IgnoreLabels
 	ignoreTitleExp := ra.AppRepoTarget().IgnoreTitleExp
 	removedCount := 0
@@ -56,7 +156,10 @@ func (m PullRequests) ExcludeSpecificPR(ra ReviewAppOrReviewAppManager) PullRequ
 	}
 	removedCount = 0
 	if ignoreTitleExp != "" {
-		r := regexp.MustCompile(ignoreTitleExp)
+		r, err := regexp.Compile(ignoreTitleExp)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid ignoreTitleExp: %w", err)
+		}
 		for idx, pr := range m {
 			if r.Match([]byte(pr.Title)) {
 				m = m.remove(idx - removedCount)
@@ -64,7 +167,7 @@ func (m PullRequests) ExcludeSpecificPR(ra ReviewAppOrReviewAppManager) PullRequ
 			}
 		}
 	}
-	return m
+	return m, nil
 }
 
 func (m PullRequests) remove(idx int) PullRequests {
@@ -0,0 +1,149 @@
+//go:build !integration_test
+// +build !integration_test
+
+package models
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	dreamkastv1alpha1 "github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
+)
+
+func TestPullRequest_VariablesInBody(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "no reviewapp block",
+			body: "description\n```yaml\nkey: value\n```\n",
+			want: nil,
+		},
+		{
+			name: "scalars",
+			body: "description\n```reviewapp\nreplicas: 1000000\nratio: 0.5\nenabled: true\nimage: nginx:1.21\nempty:\n```\n",
+			want: []string{
+				"empty=",
+				"enabled=true",
+				"image=nginx:1.21",
+				"ratio=0.5",
+				"replicas=1000000",
+			},
+		},
+		{
+			name: "empty block",
+			body: "```reviewapp\n```",
+			want: nil,
+		},
+		{
+			name:    "malformed YAML",
+			body:    "```reviewapp\nkey: [value\n```",
+			wantErr: true,
+		},
+		{
+			name:    "not map",
+			body:    "```reviewapp\n- value\n```",
+			wantErr: true,
+		},
+		{
+			name:    "value is not scalar",
+			body:    "```reviewapp\nkey:\n  nested: value\n```",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := PullRequest{Number: 1, Body: tt.body}.VariablesInBody()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PullRequest.VariablesInBody() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("PullRequest.VariablesInBody() is unexpected:\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestPullRequest_WithVariant(t *testing.T) {
+	rules := []dreamkastv1alpha1.VariantRule{
+		{Variant: "next", Labels: []string{"next-template"}},
+		{Variant: "experimental", BranchExp: "^exp/"},
+		{Variant: VariantCandidate, Labels: []string{"candidate-template"}, BranchExp: "^release/"},
+	}
+	tests := []struct {
+		name    string
+		pr      PullRequest
+		rules   []dreamkastv1alpha1.VariantRule
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "matched by label",
+			pr:    PullRequest{Branch: "feature", Labels: []string{"next-template"}},
+			rules: rules,
+			want:  "next",
+		},
+		{
+			name:  "matched by branch regex",
+			pr:    PullRequest{Branch: "exp/foo"},
+			rules: rules,
+			want:  "experimental",
+		},
+		{
+			name:  "first matched rule takes precedence",
+			pr:    PullRequest{Branch: "exp/foo", Labels: []string{"candidate-template", "next-template"}},
+			rules: rules,
+			want:  "next",
+		},
+		{
+			name:  "label or branch regex of the same rule",
+			pr:    PullRequest{Branch: "release/v1"},
+			rules: rules,
+			want:  VariantCandidate,
+		},
+		{
+			name:  "stable if no rule matches",
+			pr:    PullRequest{Branch: "feature", Labels: []string{"other"}},
+			rules: rules,
+			want:  VariantStable,
+		},
+		{
+			name:  "UseCandidate takes precedence over rules",
+			pr:    PullRequest{Branch: "exp/foo", UseCandidate: true},
+			rules: rules,
+			want:  VariantCandidate,
+		},
+		{
+			name: "default rules",
+			pr:   PullRequest{Branch: "feature", Labels: []string{"candidate-template"}},
+			want: VariantCandidate,
+		},
+		{
+			name:    "invalid branch regex",
+			pr:      PullRequest{Branch: "feature"},
+			rules:   []dreamkastv1alpha1.VariantRule{{Variant: "next", BranchExp: "["}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tt.pr.WithVariant(tt.rules)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PullRequest.WithVariant() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.Variant != tt.want {
+				t.Errorf("PullRequest.WithVariant() = %v, want %v", got.Variant, tt.want)
+			}
+		})
+	}
+}
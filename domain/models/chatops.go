@@ -0,0 +1,147 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	dreamkastv1alpha1 "github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
+	"github.com/cloudnativedaysjp/reviewapp-operator/utils"
+)
+
+const (
+	chatOpsCommandPrefix = "/reviewapp"
+
+	ReactionForSucceededCommand = "+1"
+	ReactionForDeniedCommand    = "-1"
+	ReactionForInvalidCommand   = "confused"
+
+	StopReasonExpired = "lifetime expired"
+	StopReasonCommand = chatOpsCommandPrefix + " stop"
+
+	// ChatOpsCommandRetry runs preDeployJobs that did not succeed again
+	ChatOpsCommandRetry = chatOpsCommandPrefix + " retry"
+)
+
+/* PullRequestComment */
+
+type PullRequestComment struct {
+	ID        int64
+	Username  string
+	Body      string
+	CreatedAt string
+}
+
+type PullRequestComments []PullRequestComment
+
+// After returns comments whose ID is greater than given ID
+func (m PullRequestComments) After(id int64) PullRequestComments {
+	var result PullRequestComments
+	for _, c := range m {
+		if c.ID > id {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+/* ChatOpsCommand */
+
+type ChatOpsCommand struct {
+	Name    string
+	Args    []string
+	Comment PullRequestComment
+}
+
+// NewChatOpsCommand parses first line of comment as "/reviewapp <command> [args...]".
+// second return value is false if comment is not a command.
+func NewChatOpsCommand(c PullRequestComment) (ChatOpsCommand, bool) {
+	line := strings.TrimSpace(strings.SplitN(strings.TrimSpace(c.Body), "\n", 2)[0])
+	fields := strings.Fields(line)
+	if len(fields) == 0 || fields[0] != chatOpsCommandPrefix {
+		return ChatOpsCommand{}, false
+	}
+	cmd := ChatOpsCommand{Comment: c}
+	if len(fields) > 1 {
+		cmd.Name = fields[1]
+		cmd.Args = fields[2:]
+	}
+	return cmd, true
+}
+
+func (m ChatOpsCommand) String() string {
+	return strings.Join(append([]string{chatOpsCommandPrefix, m.Name}, m.Args...), " ")
+}
+
+// Apply returns ReviewAppStatus changed by command
+func (m ChatOpsCommand) Apply(s ReviewAppStatus, lifetime string, f *utils.DatetimeFactory) (ReviewAppStatus, error) {
+	switch m.Name {
+	case "redeploy":
+		if s.ChatOps.Stopped {
+			return s, xerrors.Errorf("ReviewApp is stopped")
+		}
+		s.Sync.Status = dreamkastv1alpha1.SyncStatusCodeNeedToUpdateInfraRepo
+	case "stop":
+		s.ChatOps.Stopped = true
+	case "start":
+		if !s.ChatOps.Stopped {
+			return s, xerrors.Errorf("ReviewApp is not stopped")
+		}
+		s.ChatOps.Stopped = false
+		s.ChatOps.ExpiresAt = ""
+		s.Sync.Status = dreamkastv1alpha1.SyncStatusCodeNeedToUpdateInfraRepo
+		return s.UpdateExpiration(lifetime, f)
+	case "extend":
+		if len(m.Args) != 1 {
+			return s, xerrors.Errorf("usage: %s extend <duration>", chatOpsCommandPrefix)
+		}
+		d, err := ParseDuration(m.Args[0])
+		if err != nil {
+			return s, err
+		}
+		now := f.Now()
+		base := now
+		if s.ChatOps.ExpiresAt != "" {
+			expiresAt, err := utils.NewDatetime(s.ChatOps.ExpiresAt)
+			if err != nil {
+				return s, err
+			}
+			if now.Before(expiresAt, 0) {
+				base = expiresAt
+			}
+		}
+		s.ChatOps.ExpiresAt = base.Add(d).ToString()
+	case "retry":
+		// preDeployJobs are run again in the next reconciliation as if the commit of PR has not been handled
+		jobs := HookJobsStatus(s.PreDeployJobs)
+		if jobs.AppRepoCommitHash == "" || !jobs.Finished() || jobs.Succeeded() {
+			return s, xerrors.Errorf("preDeployJobs have not failed")
+		}
+		s.PreDeployJobs.AppRepoCommitHash = ""
+	case "use-candidate":
+		s.ChatOps.UseCandidate = true
+	case "use-stable":
+		s.ChatOps.UseCandidate = false
+	default:
+		return s, xerrors.Errorf("unknown command: %s", m)
+	}
+	return s, nil
+}
+
+// ParseDuration is time.ParseDuration that also accepts days (e.g. "3d")
+func ParseDuration(s string) (time.Duration, error) {
+	if strings.HasSuffix(s, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(s, "d"))
+		if err != nil || days < 0 {
+			return 0, xerrors.Errorf("invalid duration: %s", s)
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, xerrors.Errorf("invalid duration: %s", s)
+	}
+	return d, nil
+}
@@ -0,0 +1,75 @@
+//go:build !integration_test
+// +build !integration_test
+
+package models
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	dreamkastv1alpha1 "github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
+	"github.com/cloudnativedaysjp/reviewapp-operator/utils"
+)
+
+func TestChatOpsCommand_Apply_retry(t *testing.T) {
+	newStatus := func(commitHash string, phases ...dreamkastv1alpha1.HookJobPhase) ReviewAppStatus {
+		s := ReviewAppStatus{}
+		s.PreDeployJobs.AppRepoCommitHash = commitHash
+		s.PreDeployJobs.Outputs = map[string]string{"DB_NAME": "db_1"}
+		for _, phase := range phases {
+			s.PreDeployJobs.Jobs = append(s.PreDeployJobs.Jobs, dreamkastv1alpha1.HookJobStatus{Phase: phase})
+		}
+		return s
+	}
+
+	tests := []struct {
+		name    string
+		status  ReviewAppStatus
+		want    ReviewAppStatus
+		wantErr bool
+	}{
+		{
+			name:   "preDeployJobs failed",
+			status: newStatus("abc", dreamkastv1alpha1.HookJobPhaseSucceeded, dreamkastv1alpha1.HookJobPhaseFailed),
+			want:   newStatus("", dreamkastv1alpha1.HookJobPhaseSucceeded, dreamkastv1alpha1.HookJobPhaseFailed),
+		},
+		{
+			name:   "preDeployJobs timed out",
+			status: newStatus("abc", dreamkastv1alpha1.HookJobPhaseTimedOut),
+			want:   newStatus("", dreamkastv1alpha1.HookJobPhaseTimedOut),
+		},
+		{
+			name:    "preDeployJobs succeeded",
+			status:  newStatus("abc", dreamkastv1alpha1.HookJobPhaseSucceeded),
+			wantErr: true,
+		},
+		{
+			name:    "preDeployJobs are running",
+			status:  newStatus("abc", dreamkastv1alpha1.HookJobPhaseRunning, dreamkastv1alpha1.HookJobPhaseFailed),
+			wantErr: true,
+		},
+		{
+			name:    "preDeployJobs have not run",
+			status:  newStatus(""),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ChatOpsCommand{Name: "retry"}.Apply(tt.status, "", utils.NewDatetimeFactory())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ChatOpsCommand.Apply() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ChatOpsCommand.Apply() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+const digestPrefix = "sha256:"
+
+// digest returns digest of s (e.g. "sha256:2c26b46b...")
+func digest(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return digestPrefix + hex.EncodeToString(sum[:])
+}
+
+// digests returns map of key to digest of value. It returns nil when m is empty.
+func digests(m map[string]string) map[string]string {
+	if len(m) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		result[k] = digest(v)
+	}
+	return result
+}
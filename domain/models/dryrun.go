@@ -0,0 +1,51 @@
+package models
+
+import (
+	"strings"
+
+	dreamkastv1alpha1 "github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
+	"github.com/cloudnativedaysjp/reviewapp-operator/utils"
+)
+
+const (
+	// maxDryRunDiffBytes is max size of the diff recorded to status, because size of object is limited by etcd
+	maxDryRunDiffBytes = 64 * 1024
+
+	truncatedDiff = "...(truncated)\n"
+)
+
+// RecordDryRun records the diff of Infra Repository rendered in dry-run mode at now.
+// secretValues are redacted from the diff because manifests may be templated with values of Secrets.
+func (m ReviewAppStatus) RecordDryRun(diff string, l InfraRepoLocalDir, f *utils.DatetimeFactory, secretValues ...string) ReviewAppStatus {
+	m.DryRun = dreamkastv1alpha1.DryRunStatus{
+		RenderedTimestamp:   f.Now().ToString(),
+		InfraRepoCommitHash: l.LatestCommitHash(),
+		Diff:                truncateTail(redactValues(diff, secretValues), maxDryRunDiffBytes),
+	}
+	return m
+}
+
+// WasDryRunDisabled returns true if dry-run mode was disabled after manifests were rendered in it.
+// Manifests rendered in dry-run mode are cached to status but not pushed, so they must be pushed regardless of the cache.
+func (m ReviewApp) WasDryRunDisabled() bool {
+	return !m.Spec.DryRun && m.Status.DryRun.RenderedTimestamp != ""
+}
+
+// HasPushedToInfraRepo returns true if manifests of ReviewApp may remain in Infra Repository,
+// e.g. they were pushed before dry-run mode was enabled
+func (m ReviewAppStatus) HasPushedToInfraRepo() bool {
+	return m.Sync.InfraRepoLatestCommitHash != ""
+}
+
+// truncateTail drops the tail of s so that s fits in limitBytes
+func truncateTail(s string, limitBytes int) string {
+	if len(s) <= limitBytes {
+		return s
+	}
+	s = s[:limitBytes-len(truncatedDiff)]
+	// drop broken line (and broken multibyte character) at the tail
+	if idx := strings.LastIndex(s, "\n"); idx != -1 {
+		s = s[:idx+1]
+	}
+	return s + truncatedDiff
+}
@@ -0,0 +1,165 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	dreamkastv1alpha1 "github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
+	"github.com/cloudnativedaysjp/reviewapp-operator/utils"
+)
+
+const (
+	// LabelHookForJob is label key of Jobs run as hook of ReviewApp. The value is name of the hook.
+	LabelHookForJob = "dreamkast.cloudnativedays.jp/hook"
+
+	HookPreDeploy  = "preDeploy"
+	HookPostDeploy = "postDeploy"
+)
+
+// HookJobKey returns "namespace/name" of JobTemplate referred from the hook
+func HookJobKey(j dreamkastv1alpha1.HookJob) string {
+	return types.NamespacedName{Namespace: j.Namespace, Name: j.Name}.String()
+}
+
+// HookJobTimeout returns duration until the hook Job is regarded as timed out
+func HookJobTimeout(j dreamkastv1alpha1.HookJob) time.Duration {
+	if j.TimeoutSeconds <= 0 {
+		return defaultJobTimeout
+	}
+	return time.Duration(j.TimeoutSeconds) * time.Second
+}
+
+// FailedConditionOfJob returns condition "Failed" of the Job if the Job has failed
+func FailedConditionOfJob(job *batchv1.Job) *batchv1.JobCondition {
+	for i, c := range job.Status.Conditions {
+		if c.Type == batchv1.JobFailed && c.Status == corev1.ConditionTrue {
+			return &job.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+/* HookJobStatus */
+
+type HookJobStatus dreamkastv1alpha1.HookJobStatus
+
+// NewRunningHookJobStatus returns status of the hook Job created at now
+func NewRunningHookJobStatus(j dreamkastv1alpha1.HookJob, job *batchv1.Job, f *utils.DatetimeFactory) HookJobStatus {
+	now := f.Now()
+	return HookJobStatus{
+		JobTemplate:       HookJobKey(j),
+		JobName:           job.Name,
+		JobNamespace:      job.Namespace,
+		Phase:             dreamkastv1alpha1.HookJobPhaseRunning,
+		StartTimestamp:    now.ToString(),
+		DeadlineTimestamp: now.Add(HookJobTimeout(j)).ToString(),
+	}
+}
+
+// NewFailedHookJobStatus returns status of the hook Job that could not be created
+func NewFailedHookJobStatus(j dreamkastv1alpha1.HookJob, message string) HookJobStatus {
+	return HookJobStatus{
+		JobTemplate: HookJobKey(j),
+		Phase:       dreamkastv1alpha1.HookJobPhaseFailed,
+		Message:     message,
+	}
+}
+
+// Update updates phase of running hook Job from the Job. job is nil if the Job is not found.
+func (m HookJobStatus) Update(job *batchv1.Job, f *utils.DatetimeFactory) (HookJobStatus, error) {
+	if m.Phase != dreamkastv1alpha1.HookJobPhaseRunning {
+		return m, nil
+	}
+	switch {
+	case job == nil:
+		m.Phase = dreamkastv1alpha1.HookJobPhaseFailed
+		m.Message = "Job is not found"
+		return m, nil
+	case job.Status.Succeeded != 0:
+		m.Phase = dreamkastv1alpha1.HookJobPhaseSucceeded
+		return m, nil
+	}
+	if c := FailedConditionOfJob(job); c != nil {
+		m.Phase = dreamkastv1alpha1.HookJobPhaseFailed
+		m.Message = c.Message
+		return m, nil
+	}
+	deadline, err := utils.NewDatetime(m.DeadlineTimestamp)
+	if err != nil {
+		return m, err
+	}
+	if !f.Now().Before(deadline, 0) {
+		m.Phase = dreamkastv1alpha1.HookJobPhaseTimedOut
+		m.Message = "Job did not finish until deadline"
+	}
+	return m, nil
+}
+
+/* HookJobsStatus */
+
+type HookJobsStatus dreamkastv1alpha1.HookJobsStatus
+
+// HasRunForAppRepoCommit returns true if the hook Jobs have been created for the commit of App Repository
+func (m HookJobsStatus) HasRunForAppRepoCommit(appRepoCommitHash string) bool {
+	return m.AppRepoCommitHash != "" && m.AppRepoCommitHash == appRepoCommitHash
+}
+
+// HasRunForInfraRepoCommit returns true if the hook Jobs have been created for the commit of Infra Repository
+func (m HookJobsStatus) HasRunForInfraRepoCommit(infraRepoCommitHash string) bool {
+	return m.InfraRepoCommitHash != "" && m.InfraRepoCommitHash == infraRepoCommitHash
+}
+
+// Finished returns true if none of the hook Jobs is running
+func (m HookJobsStatus) Finished() bool {
+	for _, j := range m.Jobs {
+		if j.Phase == dreamkastv1alpha1.HookJobPhaseRunning {
+			return false
+		}
+	}
+	return true
+}
+
+// Succeeded returns true if all of the hook Jobs have succeeded
+func (m HookJobsStatus) Succeeded() bool {
+	for _, j := range m.Jobs {
+		if j.Phase != dreamkastv1alpha1.HookJobPhaseSucceeded {
+			return false
+		}
+	}
+	return true
+}
+
+// Markdown returns results of the hook Jobs as Markdown table to be sent to App Repository's PR
+func (m HookJobsStatus) Markdown(title string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "#### %s\n\n| JobTemplate | Job | Result |\n| --- | --- | --- |\n", title)
+	for _, j := range m.Jobs {
+		job := "-"
+		if j.JobName != "" {
+			job = types.NamespacedName{Namespace: j.JobNamespace, Name: j.JobName}.String()
+		}
+		result := string(j.Phase)
+		if j.Message != "" {
+			result = fmt.Sprintf("%s: %s", result, strings.ReplaceAll(j.Message, "\n", " "))
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s |\n", j.JobTemplate, job, result)
+	}
+	return b.String()
+}
+
+// LogsMarkdown returns logs of the hook Jobs that did not succeed as collapsible sections
+func (m HookJobsStatus) LogsMarkdown() string {
+	var sections []string
+	for _, j := range m.Jobs {
+		if j.Phase == dreamkastv1alpha1.HookJobPhaseSucceeded || j.Logs == "" {
+			continue
+		}
+		sections = append(sections, JobLogsMarkdown(fmt.Sprintf("Logs of %s/%s (%s)", j.JobNamespace, j.JobName, j.Phase), j.Logs))
+	}
+	return strings.Join(sections, "\n")
+}
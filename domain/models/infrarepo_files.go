@@ -2,6 +2,7 @@ package models
 
 import (
 	"path/filepath"
+	"sort"
 )
 
 type File struct {
@@ -25,3 +26,28 @@ func NewFilesFromManifests(ra ReviewApp, manifests Manifests, pr PullRequest, l
 func NewFileFromName(l InfraRepoLocalDir, filename string) File {
 	return File{BaseDir: l.baseDir, Filepath: filepath.Join(l.baseDir, filename)}
 }
+
+// FilePaths returns sorted paths of files to be recorded to status
+func FilePaths(files []File) []string {
+	paths := make([]string, 0, len(files))
+	for _, f := range files {
+		paths = append(paths, filepath.Clean(f.Filepath))
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// StaleInfraRepoFiles returns files that were written to Infra Repository at last but are not included in files
+func (m ReviewAppStatus) StaleInfraRepoFiles(files []File, l InfraRepoLocalDir) []File {
+	current := make(map[string]bool)
+	for _, f := range files {
+		current[filepath.Clean(f.Filepath)] = true
+	}
+	var result []File
+	for _, p := range m.InfraRepoFiles.Paths {
+		if !current[filepath.Clean(p)] {
+			result = append(result, File{BaseDir: l.baseDir, Filepath: p})
+		}
+	}
+	return result
+}
@@ -1,6 +1,9 @@
 package models
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 type InfraRepoLocalDir struct {
 	baseDir          string
@@ -41,3 +44,23 @@ func (m InfraRepoLocalDir) CommitMsgDeletion(ra ReviewApp) string {
 		ra.Status.Sync.SyncedPullRequest.LatestCommitHash,
 	)
 }
+
+// CommitMsgStop returns commit message of stopping ReviewApp. reason is e.g. returned by ReviewAppStatus.StopReason().
+func (m InfraRepoLocalDir) CommitMsgStop(ra ReviewApp, reason string) string {
+	return fmt.Sprintf(
+		"Stop (%s) by cloudnativedays/reviewapp-operator (%s/%s@%s)",
+		reason,
+		ra.Spec.AppTarget.Organization,
+		ra.Spec.AppTarget.Repository,
+		ra.Status.Sync.SyncedPullRequest.LatestCommitHash,
+	)
+}
+
+func (m InfraRepoLocalDir) CommitMsgOrphanSweep(ram ReviewAppManager, orphans []string) string {
+	return fmt.Sprintf(
+		"Automatic orphan sweep by cloudnativedays/reviewapp-operator (%s/%s)\n\n%s",
+		ram.Namespace,
+		ram.Name,
+		strings.Join(orphans, "\n"),
+	)
+}
@@ -0,0 +1,116 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	defaultJobLogsTailLines  = 20
+	defaultJobLogsLimitBytes = 4096
+
+	// maxJobLogsBytesInEvent is max size of logs in message of Event, because message of Event is limited to 1KB
+	maxJobLogsBytesInEvent = 512
+
+	redacted       = "[REDACTED]"
+	truncatedLogs  = "...(truncated)\n"
+	fenceInMessage = "```"
+)
+
+// credentialPatterns are patterns of credentials which may be written to logs of Jobs.
+// The first submatch is kept and the rest is replaced with "[REDACTED]".
+var credentialPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)((?:password|passwd|secret|token|api[_-]?key|access[_-]?key|credential)s?["']?\s*[:=]\s*["']?)[^\s"',]+`),
+	regexp.MustCompile(`(?i)(authorization:\s*(?:bearer|basic|token)\s+)\S+`),
+	regexp.MustCompile(`(://[^:/@\s]+:)[^@/\s]+(@)`),
+	regexp.MustCompile(`()\b(?:ghp|gho|ghu|ghs|ghr)_[A-Za-z0-9]{20,}\b`),
+	regexp.MustCompile(`()\bgithub_pat_[A-Za-z0-9_]{20,}\b`),
+	regexp.MustCompile(`()\bAKIA[0-9A-Z]{16}\b`),
+}
+
+// JobLogsTailLines returns number of lines read from the end of logs of each container
+func (m ReviewApp) JobLogsTailLines() int64 {
+	if m.Spec.JobLogs.TailLines <= 0 {
+		return defaultJobLogsTailLines
+	}
+	return int64(m.Spec.JobLogs.TailLines)
+}
+
+// JobLogsLimitBytes returns max size of logs of each Job
+func (m ReviewApp) JobLogsLimitBytes() int64 {
+	if m.Spec.JobLogs.LimitBytes <= 0 {
+		return defaultJobLogsLimitBytes
+	}
+	return int64(m.Spec.JobLogs.LimitBytes)
+}
+
+// NewJobLogs redacts credentials and secretValues (values of Secrets given to templates) from logs,
+// and keeps the last limitBytes of them
+func NewJobLogs(logs string, limitBytes int64, secretValues ...string) string {
+	logs = redactValues(logs, secretValues)
+	for _, p := range credentialPatterns {
+		logs = p.ReplaceAllString(logs, "${1}"+redacted+"${2}")
+	}
+	return truncateHead(logs, limitBytes)
+}
+
+// redactValues replaces values in s with "[REDACTED]" literally.
+// Values are trimmed because Secrets created from files often have trailing newline.
+// Longer values are replaced first so that values containing other values are redacted entirely.
+func redactValues(s string, values []string) string {
+	var targets []string
+	for _, v := range values {
+		if t := strings.TrimSpace(v); t != "" {
+			targets = append(targets, t)
+		}
+	}
+	if len(targets) == 0 {
+		return s
+	}
+	sort.SliceStable(targets, func(i, j int) bool {
+		return len(targets[i]) > len(targets[j])
+	})
+	oldnew := make([]string, 0, len(targets)*2)
+	for _, t := range targets {
+		oldnew = append(oldnew, t, redacted)
+	}
+	return strings.NewReplacer(oldnew...).Replace(s)
+}
+
+// JobLogsForEvent returns logs short enough to be appended to message of Event
+func JobLogsForEvent(logs string) string {
+	if logs == "" {
+		return ""
+	}
+	return "\n" + truncateHead(logs, maxJobLogsBytesInEvent)
+}
+
+// JobLogsMarkdown returns logs as collapsible section to be sent to App Repository's PR
+func JobLogsMarkdown(summary, logs string) string {
+	return fmt.Sprintf("<details>\n<summary>%s</summary>\n\n```\n%s\n```\n</details>\n",
+		summary, strings.ReplaceAll(strings.TrimRight(logs, "\n"), fenceInMessage, "'''"))
+}
+
+// truncateHead drops the head of s so that s fits in limitBytes.
+// It returns empty string if limitBytes is too small to keep any logs.
+func truncateHead(s string, limitBytes int64) string {
+	if int64(len(s)) <= limitBytes {
+		return s
+	}
+	if limitBytes <= int64(len(truncatedLogs)) {
+		return ""
+	}
+	s = s[int64(len(s))-limitBytes+int64(len(truncatedLogs)):]
+	// drop broken line at the head
+	if idx := strings.Index(s, "\n"); idx != -1 && idx+1 < len(s) {
+		s = s[idx+1:]
+	}
+	// drop broken multibyte character at the head if s has no line to drop
+	for len(s) > 0 && !utf8.RuneStart(s[0]) {
+		s = s[1:]
+	}
+	return truncatedLogs + s
+}
@@ -3,6 +3,7 @@ package models
 import (
 	"golang.org/x/xerrors"
 	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/yaml"
 
 	dreamkastv1alpha1 "github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
@@ -14,18 +15,48 @@ const (
 
 type JobTemplate dreamkastv1alpha1.JobTemplate
 
+func (m JobTemplate) StableStr() string {
+	if m.Spec.StableTemplate == "" {
+		return m.Spec.Template
+	}
+	return m.Spec.StableTemplate
+}
+
+func (m JobTemplate) CandidateStr() string {
+	if m.Spec.CandidateTemplate == "" {
+		return m.Spec.Template
+	}
+	return m.Spec.CandidateTemplate
+}
+
+// VariantStr returns template of the variant.
+// If the variant does not exist, it returns spec.template common to all variants, or error if it is not specified.
+func (m JobTemplate) VariantStr(variant string) (string, error) {
+	switch variant {
+	case VariantCandidate:
+		return m.CandidateStr(), nil
+	case VariantStable:
+		return m.StableStr(), nil
+	}
+	if template, ok := m.Spec.Variants[variant]; ok {
+		return template, nil
+	}
+	if m.Spec.Template != "" {
+		return m.Spec.Template, nil
+	}
+	return "", errVariantNotFound("JobTemplate", m.namespacedName(), variant)
+}
+
+func (m JobTemplate) namespacedName() types.NamespacedName {
+	return types.NamespacedName{Namespace: m.Namespace, Name: m.Name}
+}
+
 func (m JobTemplate) GenerateJob(ra ReviewApp, pr PullRequest, v Templator) (*batchv1.Job, error) {
-	var template string
-	var err error
-	/* TODO
-	if pr.IsCandidate() {
-		template = m.GetCandidateStr()
-	} else {
-		template = m.GetStableStr()
-	}
-	*/
-	template = m.Spec.Template
-	jobStr, err := v.Templating(template)
+	template, err := m.VariantStr(pr.TemplateVariant())
+	if err != nil {
+		return nil, err
+	}
+	jobStr, err := v.templateFile("JobTemplate", m.namespacedName(), pr.TemplateVariant(), template)
 	if err != nil {
 		return nil, err
 	}
@@ -38,3 +69,13 @@ func (m JobTemplate) GenerateJob(ra ReviewApp, pr PullRequest, v Templator) (*ba
 	job.SetLabels(map[string]string{LabelReviewAppNameForJob: ra.Name})
 	return &job, nil
 }
+
+// GenerateHookJob generates Job labeled with name of the hook
+func (m JobTemplate) GenerateHookJob(ra ReviewApp, pr PullRequest, v Templator, hook string) (*batchv1.Job, error) {
+	job, err := m.GenerateJob(ra, pr, v)
+	if err != nil {
+		return nil, err
+	}
+	job.Labels[LabelHookForJob] = hook
+	return job, nil
+}
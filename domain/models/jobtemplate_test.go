@@ -0,0 +1,47 @@
+//go:build !integration_test
+// +build !integration_test
+
+package models
+
+import (
+	"testing"
+
+	dreamkastv1alpha1 "github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
+)
+
+func TestJobTemplate_VariantStr(t *testing.T) {
+	jt := JobTemplate{Spec: dreamkastv1alpha1.JobTemplateSpec{
+		StableTemplate: "stable",
+		Variants:       map[string]string{"next": "next"},
+	}}
+	jtWithTemplate := JobTemplate{Spec: dreamkastv1alpha1.JobTemplateSpec{
+		Template: "common",
+	}}
+	tests := []struct {
+		name    string
+		jt      JobTemplate
+		variant string
+		want    string
+		wantErr bool
+	}{
+		{name: "stable", jt: jt, variant: VariantStable, want: "stable"},
+		{name: "named variant", jt: jt, variant: "next", want: "next"},
+		{name: "variant does not exist", jt: jt, variant: "other", wantErr: true},
+		{name: "common template is used for any variant", jt: jtWithTemplate, variant: "other", want: "common"},
+		{name: "common template is used for candidate", jt: jtWithTemplate, variant: VariantCandidate, want: "common"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tt.jt.VariantStr(tt.variant)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("JobTemplate.VariantStr() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("JobTemplate.VariantStr() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
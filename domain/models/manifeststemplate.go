@@ -1,7 +1,23 @@
 package models
 
 import (
+	"fmt"
+	"path/filepath"
+
+	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	kyaml "sigs.k8s.io/kustomize/kyaml/yaml"
+	"sigs.k8s.io/kustomize/kyaml/yaml/merge2"
+	"sigs.k8s.io/yaml"
+
 	dreamkastv1alpha1 "github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
+	myerrors "github.com/cloudnativedaysjp/reviewapp-operator/errors"
+)
+
+const (
+	helmChartFilename  = "Chart.yaml"
+	helmValuesFilename = "values.yaml"
 )
 
 type ManifestsTemplate dreamkastv1alpha1.ManifestsTemplate
@@ -14,40 +30,213 @@ func (m ManifestsTemplate) CandidateMap() map[string]string {
 	return m.Spec.CandidateData
 }
 
-func (m ManifestsTemplate) AppendOrUpdate(mt ManifestsTemplate) ManifestsTemplate {
-	if m.Spec.StableData == nil {
-		m.Spec.StableData = make(map[string]string)
+// VariantMap returns template of the variant.
+// If the variant does not exist, it returns error unless the template has no data (e.g. it has only helm).
+func (m ManifestsTemplate) VariantMap(variant string) (map[string]string, error) {
+	switch variant {
+	case VariantCandidate:
+		return m.CandidateMap(), nil
+	case VariantStable:
+		return m.StableMap(), nil
+	}
+	if template, ok := m.Spec.Variants[variant]; ok {
+		return template, nil
+	}
+	if len(m.Spec.StableData) == 0 && len(m.Spec.CandidateData) == 0 && len(m.Spec.Variants) == 0 {
+		return nil, nil
+	}
+	return nil, errVariantNotFound("ManifestsTemplate", m.namespacedName(), variant)
+}
+
+func (m ManifestsTemplate) namespacedName() types.NamespacedName {
+	return types.NamespacedName{Namespace: m.Namespace, Name: m.Name}
+}
+
+func (m ManifestsTemplate) NamespacedNameString() string {
+	return fmt.Sprintf("%s/%s", m.Namespace, m.Name)
+}
+
+func (m ManifestsTemplate) mergeStrategy() dreamkastv1alpha1.MergeStrategy {
+	if m.Spec.MergeStrategy == "" {
+		return dreamkastv1alpha1.MergeStrategyOverride
+	}
+	return m.Spec.MergeStrategy
+}
+
+// GenerateMergedManifests templates manifests from each ManifestsTemplate and merges them in order
+// by merge strategy of each template. It also returns which templates contributed each file.
+func GenerateMergedManifests(mts []ManifestsTemplate, pr PullRequest, v Templator, dirpath string) (Manifests, ManifestsSources, error) {
+	merged := make(Manifests)
+	sources := make(ManifestsSources)
+	for _, mt := range mts {
+		manifests, err := mt.GenerateManifests(pr, v, dirpath)
+		if err != nil {
+			return nil, nil, err
+		}
+		for filename, content := range manifests {
+			base, exists := merged[filename]
+			if !exists {
+				merged[filename] = content
+				sources[filename] = []string{mt.NamespacedNameString()}
+				continue
+			}
+			switch mt.mergeStrategy() {
+			case dreamkastv1alpha1.MergeStrategyErrorOnConflict:
+				return nil, nil, myerrors.NewTemplatingFailed(
+					xerrors.Errorf("conflicts with ManifestsTemplate %v", sources[filename]),
+					"ManifestsTemplate", mt.namespacedName(), filename, 0)
+			case dreamkastv1alpha1.MergeStrategyStrategicMerge:
+				merged[filename], err = strategicMerge(base, content)
+				if err != nil {
+					return nil, nil, myerrors.NewTemplatingFailed(
+						xerrors.Errorf("failed to merge: %w", err),
+						"ManifestsTemplate", mt.namespacedName(), filename, 0)
+				}
+				sources[filename] = append(sources[filename], mt.NamespacedNameString())
+			default:
+				merged[filename] = content
+				sources[filename] = []string{mt.NamespacedNameString()}
+			}
+		}
+	}
+	return merged, sources, nil
+}
+
+// strategicMerge merges resources in patch into resources in base.
+// Resources are matched by apiVersion, kind & metadata.name, and unmatched ones are appended.
+func strategicMerge(base, patch string) (string, error) {
+	baseNodes, err := kio.FromBytes([]byte(base))
+	if err != nil {
+		return "", xerrors.Errorf("%w", err)
 	}
-	for k, v := range mt.StableMap() {
-		m.Spec.StableData[k] = v
+	patchNodes, err := kio.FromBytes([]byte(patch))
+	if err != nil {
+		return "", xerrors.Errorf("%w", err)
 	}
-	if m.Spec.CandidateData == nil {
-		m.Spec.CandidateData = make(map[string]string)
+	for _, p := range patchNodes {
+		matched := false
+		for i, b := range baseNodes {
+			if b.GetApiVersion() == p.GetApiVersion() && b.GetKind() == p.GetKind() && b.GetName() == p.GetName() {
+				baseNodes[i], err = merge2.Merge(p, b, kyaml.MergeOptions{})
+				if err != nil {
+					return "", xerrors.Errorf("%w", err)
+				}
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			baseNodes = append(baseNodes, p)
+		}
 	}
-	for k, v := range mt.CandidateMap() {
-		m.Spec.CandidateData[k] = v
+	out, err := kio.StringAll(baseNodes)
+	if err != nil {
+		return "", xerrors.Errorf("%w", err)
 	}
-	return m
+	return out, nil
 }
 
-func (m ManifestsTemplate) GenerateManifests(pr PullRequest, v Templator) (Manifests, error) {
-	var template map[string]string
-	var err error
-	if pr.IsCandidate() {
-		template = m.CandidateMap()
-	} else {
-		template = m.StableMap()
+// GenerateManifests templates manifests. dirpath is the directory in Infra Repository that manifests are written to.
+func (m ManifestsTemplate) GenerateManifests(pr PullRequest, v Templator, dirpath string) (Manifests, error) {
+	template, err := m.VariantMap(pr.TemplateVariant())
+	if err != nil {
+		return nil, err
 	}
 	manifests := make(map[string]string)
 	for key, val := range template {
-		manifests[key], err = v.Templating(val)
+		manifests[key], err = v.templateFile("ManifestsTemplate", m.namespacedName(), key, val)
 		if err != nil {
 			return nil, err
 		}
 	}
+	if m.Spec.Helm != nil {
+		for _, filename := range []string{helmChartFilename, helmValuesFilename} {
+			if _, ok := manifests[filename]; ok {
+				return nil, myerrors.NewTemplatingFailed(
+					xerrors.Errorf("%s is generated from helm, so it must not be specified in data", filename),
+					"ManifestsTemplate", m.namespacedName(), filename, 0)
+			}
+		}
+		chart, values, err := m.generateHelmChart(pr, v, dirpath)
+		if err != nil {
+			return nil, err
+		}
+		manifests[helmChartFilename] = chart
+		manifests[helmValuesFilename] = values
+	}
 	return Manifests(manifests), nil
 }
 
+// generateHelmChart generates Chart.yaml of umbrella chart depending on the specified chart, and its values.yaml
+func (m ManifestsTemplate) generateHelmChart(pr PullRequest, v Templator, dirpath string) (string, string, error) {
+	helm := m.Spec.Helm
+	repository := helm.Repository
+	if helm.Path != "" {
+		// local chart is referred by relative path from dirpath
+		rel, err := filepath.Rel(dirpath, helm.Path)
+		if err != nil {
+			return "", "", xerrors.Errorf("%w", err)
+		}
+		repository = "file://" + rel
+	}
+	if repository == "" {
+		return "", "", xerrors.Errorf("%s: either helm.repository or helm.path must be specified", m.Name)
+	}
+	dependency := map[string]string{"name": helm.Chart, "repository": repository}
+	if helm.Version != "" {
+		dependency["version"] = helm.Version
+	}
+	chart, err := yaml.Marshal(map[string]interface{}{
+		"apiVersion":   "v2",
+		"name":         dnsLabel(v.ReviewApp.Name),
+		"version":      "0.0.0",
+		"dependencies": []map[string]string{dependency},
+	})
+	if err != nil {
+		return "", "", xerrors.Errorf("%w", err)
+	}
+
+	valuesTemplate, err := m.helmValues(pr.TemplateVariant())
+	if err != nil {
+		return "", "", err
+	}
+	valuesStr, err := v.templateFile("ManifestsTemplate", m.namespacedName(), "helm values", valuesTemplate)
+	if err != nil {
+		return "", "", err
+	}
+	var values map[string]interface{}
+	if err := yaml.Unmarshal([]byte(valuesStr), &values); err != nil {
+		return "", "", xerrors.Errorf("%s: values of helm chart is invalid: %w", m.Name, err)
+	}
+	if values == nil {
+		values = make(map[string]interface{})
+	}
+	// values of dependency chart are nested under its name
+	out, err := yaml.Marshal(map[string]interface{}{helm.Chart: values})
+	if err != nil {
+		return "", "", xerrors.Errorf("%w", err)
+	}
+	return string(chart), string(out), nil
+}
+
+// helmValues returns values of helm chart of the variant. If the variant does not exist, it returns error.
+func (m ManifestsTemplate) helmValues(variant string) (string, error) {
+	helm := m.Spec.Helm
+	switch variant {
+	case VariantCandidate:
+		return helm.CandidateValues, nil
+	case VariantStable:
+		return helm.StableValues, nil
+	}
+	if values, ok := helm.VariantValues[variant]; ok {
+		return values, nil
+	}
+	return "", errVariantNotFound("ManifestsTemplate", m.namespacedName(), variant)
+}
+
 /* Manifests  */
 
 type Manifests map[string]string
+
+// ManifestsSources is map of filename to ManifestsTemplates ("namespace/name") that contributed the file
+type ManifestsSources map[string][]string
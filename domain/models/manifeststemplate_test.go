@@ -0,0 +1,251 @@
+//go:build !integration_test
+// +build !integration_test
+
+package models
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dreamkastv1alpha1 "github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
+)
+
+func TestManifestsTemplate_GenerateManifests(t *testing.T) {
+	v := Templator{
+		ReviewApp: templateValueReviewAppInfo{Name: "sample-1"},
+		Variables: map[string]string{"replicas": "2"},
+	}
+	newHelmTemplate := func(data map[string]string) ManifestsTemplate {
+		return ManifestsTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "helm", Namespace: "default"},
+			Spec: dreamkastv1alpha1.ManifestsTemplateSpec{
+				StableData: data,
+				Helm: &dreamkastv1alpha1.ManifestsTemplateSpecHelm{
+					Chart:           "nginx",
+					Repository:      "https://charts.bitnami.com/bitnami",
+					Version:         "9.0.0",
+					StableValues:    "replicaCount: {{.Variables.replicas}}",
+					CandidateValues: "replicaCount: 1",
+					VariantValues:   map[string]string{"next": "replicaCount: 3"},
+				},
+			},
+		}
+	}
+	chart := "apiVersion: v2\ndependencies:\n- name: nginx\n  repository: https://charts.bitnami.com/bitnami\n  version: 9.0.0\nname: sample-1\nversion: 0.0.0\n"
+
+	tests := []struct {
+		name    string
+		mt      ManifestsTemplate
+		pr      PullRequest
+		want    Manifests
+		wantErr bool
+	}{
+		{
+			name: "helm chart with stable values",
+			mt:   newHelmTemplate(map[string]string{"ns.yaml": "kind: Namespace"}),
+			pr:   PullRequest{Variant: VariantStable},
+			want: Manifests{
+				"ns.yaml":     "kind: Namespace",
+				"Chart.yaml":  chart,
+				"values.yaml": "nginx:\n  replicaCount: 2\n",
+			},
+		},
+		{
+			name: "helm chart with candidate values",
+			mt:   newHelmTemplate(nil),
+			pr:   PullRequest{Variant: VariantCandidate},
+			want: Manifests{
+				"Chart.yaml":  chart,
+				"values.yaml": "nginx:\n  replicaCount: 1\n",
+			},
+		},
+		{
+			name: "helm chart with values of named variant",
+			mt:   newHelmTemplate(nil),
+			pr:   PullRequest{Variant: "next"},
+			want: Manifests{
+				"Chart.yaml":  chart,
+				"values.yaml": "nginx:\n  replicaCount: 3\n",
+			},
+		},
+		{
+			name: "local helm chart",
+			mt: func() ManifestsTemplate {
+				mt := newHelmTemplate(nil)
+				mt.Spec.Helm.Repository = ""
+				mt.Spec.Helm.Version = ""
+				mt.Spec.Helm.Path = "charts/nginx"
+				return mt
+			}(),
+			pr: PullRequest{Variant: VariantStable},
+			want: Manifests{
+				"Chart.yaml":  "apiVersion: v2\ndependencies:\n- name: nginx\n  repository: file://../../charts/nginx\nname: sample-1\nversion: 0.0.0\n",
+				"values.yaml": "nginx:\n  replicaCount: 2\n",
+			},
+		},
+		{
+			name:    "variant does not exist in data",
+			mt:      newHelmTemplate(map[string]string{"ns.yaml": "kind: Namespace"}),
+			pr:      PullRequest{Variant: "next"},
+			wantErr: true,
+		},
+		{
+			name: "variant does not exist in helm values",
+			mt: func() ManifestsTemplate {
+				mt := newHelmTemplate(nil)
+				mt.Spec.Helm.VariantValues = nil
+				return mt
+			}(),
+			pr:      PullRequest{Variant: "next"},
+			wantErr: true,
+		},
+		{
+			name:    "Chart.yaml collides with helm chart",
+			mt:      newHelmTemplate(map[string]string{"Chart.yaml": "name: other"}),
+			pr:      PullRequest{Variant: VariantStable},
+			wantErr: true,
+		},
+		{
+			name:    "values.yaml collides with helm chart",
+			mt:      newHelmTemplate(map[string]string{"values.yaml": "replicaCount: 1"}),
+			pr:      PullRequest{Variant: VariantStable},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tt.mt.GenerateManifests(tt.pr, v, "overlays/pr-1")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ManifestsTemplate.GenerateManifests() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("ManifestsTemplate.GenerateManifests() is unexpected:\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateMergedManifests(t *testing.T) {
+	v := Templator{Variables: map[string]string{"replicas": "2"}}
+	newTemplate := func(name string, strategy dreamkastv1alpha1.MergeStrategy, data map[string]string) ManifestsTemplate {
+		return ManifestsTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: dreamkastv1alpha1.ManifestsTemplateSpec{
+				StableData:    data,
+				MergeStrategy: strategy,
+			},
+		}
+	}
+	base := newTemplate("base", "", map[string]string{
+		"deployment.yaml": "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: app\nspec:\n  replicas: 1\n  template:\n    spec:\n      containers:\n      - name: app\n        image: app:latest\n",
+		"ns.yaml":         "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: base\n",
+	})
+
+	tests := []struct {
+		name        string
+		mts         []ManifestsTemplate
+		want        Manifests
+		wantSources ManifestsSources
+		wantErr     bool
+	}{
+		{
+			name: "Override replaces file of the same name",
+			mts: []ManifestsTemplate{base, newTemplate("override", dreamkastv1alpha1.MergeStrategyOverride, map[string]string{
+				"ns.yaml":     "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: override\n",
+				"config.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config\n",
+			})},
+			want: Manifests{
+				"deployment.yaml": base.Spec.StableData["deployment.yaml"],
+				"ns.yaml":         "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: override\n",
+				"config.yaml":     "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config\n",
+			},
+			wantSources: ManifestsSources{
+				"deployment.yaml": {"default/base"},
+				"ns.yaml":         {"default/override"},
+				"config.yaml":     {"default/override"},
+			},
+		},
+		{
+			name: "Override is default",
+			mts: []ManifestsTemplate{base, newTemplate("override", "", map[string]string{
+				"ns.yaml": "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: override\n",
+			})},
+			want: Manifests{
+				"deployment.yaml": base.Spec.StableData["deployment.yaml"],
+				"ns.yaml":         "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: override\n",
+			},
+			wantSources: ManifestsSources{
+				"deployment.yaml": {"default/base"},
+				"ns.yaml":         {"default/override"},
+			},
+		},
+		{
+			name: "StrategicMerge merges matched resources and appends unmatched ones",
+			mts: []ManifestsTemplate{base, newTemplate("patch", dreamkastv1alpha1.MergeStrategyStrategicMerge, map[string]string{
+				"deployment.yaml": "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: app\nspec:\n  replicas: {{.Variables.replicas}}\n" +
+					"---\napiVersion: v1\nkind: Service\nmetadata:\n  name: app\n",
+			})},
+			want: Manifests{
+				"deployment.yaml": "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: app\nspec:\n  replicas: 2\n  template:\n    spec:\n      containers:\n      - name: app\n        image: app:latest\n" +
+					"---\napiVersion: v1\nkind: Service\nmetadata:\n  name: app\n",
+				"ns.yaml": base.Spec.StableData["ns.yaml"],
+			},
+			wantSources: ManifestsSources{
+				"deployment.yaml": {"default/base", "default/patch"},
+				"ns.yaml":         {"default/base"},
+			},
+		},
+		{
+			name: "StrategicMerge of file that does not exist in former templates",
+			mts: []ManifestsTemplate{base, newTemplate("patch", dreamkastv1alpha1.MergeStrategyStrategicMerge, map[string]string{
+				"config.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config\n",
+			})},
+			want: Manifests{
+				"deployment.yaml": base.Spec.StableData["deployment.yaml"],
+				"ns.yaml":         base.Spec.StableData["ns.yaml"],
+				"config.yaml":     "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config\n",
+			},
+			wantSources: ManifestsSources{
+				"deployment.yaml": {"default/base"},
+				"ns.yaml":         {"default/base"},
+				"config.yaml":     {"default/patch"},
+			},
+		},
+		{
+			name: "StrategicMerge of invalid YAML",
+			mts: []ManifestsTemplate{base, newTemplate("patch", dreamkastv1alpha1.MergeStrategyStrategicMerge, map[string]string{
+				"ns.yaml": "kind: [Namespace\n",
+			})},
+			wantErr: true,
+		},
+		{
+			name: "ErrorOnConflict",
+			mts: []ManifestsTemplate{base, newTemplate("conflict", dreamkastv1alpha1.MergeStrategyErrorOnConflict, map[string]string{
+				"ns.yaml": "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: conflict\n",
+			})},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, gotSources, err := GenerateMergedManifests(tt.mts, PullRequest{Variant: VariantStable}, v, "overlays/pr-1")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GenerateMergedManifests() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("GenerateMergedManifests() is unexpected:\n%v", diff)
+			}
+			if diff := cmp.Diff(gotSources, tt.wantSources); diff != "" {
+				t.Errorf("sources of GenerateMergedManifests() is unexpected:\n%v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package models
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cloudnativedaysjp/reviewapp-operator/utils"
+)
+
+// defaultOrphanSweepInterval is interval of sweeping orphans when intervalSeconds is not specified
+const defaultOrphanSweepInterval = time.Hour
+
+var (
+	goTemplateAction = regexp.MustCompile(`{{.*?}}`)
+	prNumberAction   = regexp.MustCompile(`{{[^}]*\.AppRepo\.PrNumber\b[^}]*}}`)
+)
+
+// OrphanSweepInterval returns interval of sweeping orphans in Infra Repository
+func (m ReviewAppManager) OrphanSweepInterval() time.Duration {
+	if m.Spec.OrphanSweeper.IntervalSeconds <= 0 {
+		return defaultOrphanSweepInterval
+	}
+	return time.Duration(m.Spec.OrphanSweeper.IntervalSeconds) * time.Second
+}
+
+// DurationUntilNextOrphanSweep returns duration until orphans should be swept next time.
+// It returns zero or negative duration if orphans should be swept now.
+func (m ReviewAppManager) DurationUntilNextOrphanSweep(f *utils.DatetimeFactory) (time.Duration, error) {
+	if m.Status.OrphanSweep.LastSweepTimestamp == "" {
+		return 0, nil
+	}
+	lastSweep, err := utils.NewDatetime(m.Status.OrphanSweep.LastSweepTimestamp)
+	if err != nil {
+		return 0, err
+	}
+	next := lastSweep.Add(m.OrphanSweepInterval())
+	return next.Sub(f.Now()), nil
+}
+
+// InfraRepoPathPatterns returns glob patterns of paths written to Infra Repository by ReviewApps of the ReviewAppManager.
+// Go templates in argocdApp.filepath & manifests.dirpath are replaced with "*".
+// Paths not including PR number and paths whose top directory is templated are excluded,
+// because they may match files that are not managed by ReviewApps.
+func (m ReviewAppManager) InfraRepoPathPatterns() []string {
+	var patterns []string
+	for _, t := range m.infraRepoPathTemplates() {
+		patterns = append(patterns, goTemplateAction.ReplaceAllString(t, "*"))
+	}
+	return patterns
+}
+
+// infraRepoPathTemplates returns argocdApp.filepath & manifests.dirpath that are templated with PR number
+func (m ReviewAppManager) infraRepoPathTemplates() []string {
+	var templates []string
+	for _, p := range []string{m.Spec.InfraConfig.ArgoCDApp.Filepath, m.Spec.InfraConfig.Manifests.Dirpath} {
+		if !prNumberAction.MatchString(p) {
+			continue
+		}
+		t := filepath.Clean(p)
+		if filepath.IsAbs(t) || strings.HasPrefix(t, "..") {
+			continue
+		}
+		if top := strings.SplitN(t, string(filepath.Separator), 2)[0]; goTemplateAction.MatchString(top) {
+			continue
+		}
+		templates = append(templates, t)
+	}
+	return templates
+}
+
+// infraRepoPathRegexps returns regular expressions of paths that ReviewApps of the ReviewAppManager can write.
+// Go templates with PR number match only digits and other Go templates match characters except "/",
+// so that files written by hand (e.g. "apps/pr-template.yaml" for "apps/pr-{{.AppRepo.PrNumber}}.yaml") are not matched.
+func (m ReviewAppManager) infraRepoPathRegexps() []*regexp.Regexp {
+	var result []*regexp.Regexp
+	for _, t := range m.infraRepoPathTemplates() {
+		var b strings.Builder
+		b.WriteString("^")
+		last := 0
+		for _, loc := range goTemplateAction.FindAllStringIndex(t, -1) {
+			b.WriteString(regexp.QuoteMeta(t[last:loc[0]]))
+			if prNumberAction.MatchString(t[loc[0]:loc[1]]) {
+				b.WriteString("[0-9]+")
+			} else {
+				b.WriteString("[^/]*")
+			}
+			last = loc[1]
+		}
+		b.WriteString(regexp.QuoteMeta(t[last:]))
+		b.WriteString("$")
+		result = append(result, regexp.MustCompile(b.String()))
+	}
+	return result
+}
+
+// ListOrphans returns paths that can be written by ReviewApps of the ReviewAppManager
+// but are not written by any of ReviewApps targeting the same Infra Repository
+func (m ReviewAppManager) ListOrphans(paths []string, ras []ReviewApp) []string {
+	regexps := m.infraRepoPathRegexps()
+	inUse := make(map[string]bool)
+	for _, ra := range ras {
+		if ra.Spec.InfraTarget.Organization != m.Spec.InfraTarget.Organization ||
+			ra.Spec.InfraTarget.Repository != m.Spec.InfraTarget.Repository {
+			continue
+		}
+		inUse[filepath.Clean(ra.AtFilepath())] = true
+		inUse[filepath.Clean(ra.MtDirpath())] = true
+	}
+	var orphans []string
+	for _, p := range paths {
+		p = filepath.Clean(p)
+		if inUse[p] {
+			continue
+		}
+		for _, r := range regexps {
+			if r.MatchString(p) {
+				orphans = append(orphans, p)
+				break
+			}
+		}
+	}
+	sort.Strings(orphans)
+	return orphans
+}
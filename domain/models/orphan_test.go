@@ -0,0 +1,133 @@
+//go:build !integration_test
+// +build !integration_test
+
+package models
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	dreamkastv1alpha1 "github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
+)
+
+func newOrphanTestReviewAppManager(filepath, dirpath string) ReviewAppManager {
+	m := ReviewAppManager{}
+	m.Spec.InfraTarget = dreamkastv1alpha1.ReviewAppManagerSpecInfraTarget{Organization: "org", Repository: "infra"}
+	m.Spec.InfraConfig.ArgoCDApp.Filepath = filepath
+	m.Spec.InfraConfig.Manifests.Dirpath = dirpath
+	return m
+}
+
+func newOrphanTestReviewApp(repository, filepath, dirpath string) ReviewApp {
+	ra := ReviewApp{}
+	ra.Spec.InfraTarget = dreamkastv1alpha1.ReviewAppManagerSpecInfraTarget{Organization: "org", Repository: repository}
+	ra.Spec.InfraConfig.ArgoCDApp.Filepath = filepath
+	ra.Spec.InfraConfig.Manifests.Dirpath = dirpath
+	return ra
+}
+
+func TestReviewAppManager_InfraRepoPathPatterns(t *testing.T) {
+	tests := []struct {
+		name     string
+		filepath string
+		dirpath  string
+		want     []string
+	}{
+		{
+			name:     "templated with PR number",
+			filepath: "apps/pr-{{.AppRepo.PrNumber}}.yaml",
+			dirpath:  "./overlays/{{.AppRepo.Repository}}/pr-{{ .AppRepo.PrNumber }}",
+			want:     []string{"apps/pr-*.yaml", "overlays/*/pr-*"},
+		},
+		{
+			name:     "not templated with PR number",
+			filepath: "apps/{{.AppRepo.Repository}}.yaml",
+			dirpath:  "overlays/fixed",
+			want:     nil,
+		},
+		{
+			name:     "top directory is templated",
+			filepath: "{{.AppRepo.Repository}}/pr-{{.AppRepo.PrNumber}}.yaml",
+			dirpath:  "pr-{{.AppRepo.PrNumber}}",
+			want:     nil,
+		},
+		{
+			name:     "outside of repository",
+			filepath: "../apps/pr-{{.AppRepo.PrNumber}}.yaml",
+			dirpath:  "/overlays/pr-{{.AppRepo.PrNumber}}",
+			want:     nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := newOrphanTestReviewAppManager(tt.filepath, tt.dirpath)
+			got := m.InfraRepoPathPatterns()
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("InfraRepoPathPatterns() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReviewAppManager_ListOrphans(t *testing.T) {
+	tests := []struct {
+		name     string
+		filepath string
+		dirpath  string
+		paths    []string
+		ras      []ReviewApp
+		want     []string
+	}{
+		{
+			name:     "paths not written by existing ReviewApps",
+			filepath: "apps/pr-{{.AppRepo.PrNumber}}.yaml",
+			dirpath:  "overlays/pr-{{.AppRepo.PrNumber}}",
+			paths:    []string{"overlays/pr-2", "apps/pr-2.yaml", "apps/pr-1.yaml", "overlays/pr-1"},
+			ras: []ReviewApp{
+				newOrphanTestReviewApp("infra", "apps/pr-1.yaml", "./overlays/pr-1"),
+			},
+			want: []string{"apps/pr-2.yaml", "overlays/pr-2"},
+		},
+		{
+			name:     "paths not rendered with PR number are not orphans",
+			filepath: "apps/pr-{{.AppRepo.PrNumber}}.yaml",
+			dirpath:  "overlays/{{.AppRepo.Repository}}/pr-{{.AppRepo.PrNumber}}",
+			paths: []string{
+				"apps/pr-template.yaml", "apps/pr-.yaml", "apps/pr-3.yaml",
+				"overlays/app/pr-base", "overlays/app/nested/pr-3", "overlays/app/pr-3",
+			},
+			want: []string{"apps/pr-3.yaml", "overlays/app/pr-3"},
+		},
+		{
+			name:     "ReviewApps targeting other Infra Repository are ignored",
+			filepath: "apps/pr-{{.AppRepo.PrNumber}}.yaml",
+			dirpath:  "overlays/pr-{{.AppRepo.PrNumber}}",
+			paths:    []string{"apps/pr-1.yaml", "overlays/pr-1"},
+			ras: []ReviewApp{
+				newOrphanTestReviewApp("other", "apps/pr-1.yaml", "overlays/pr-1"),
+			},
+			want: []string{"apps/pr-1.yaml", "overlays/pr-1"},
+		},
+		{
+			name:     "paths not templated with PR number are never orphans",
+			filepath: "apps/{{.AppRepo.Repository}}.yaml",
+			dirpath:  "overlays/pr-{{.AppRepo.PrNumber}}",
+			paths:    []string{"apps/app.yaml", "overlays/pr-1"},
+			want:     []string{"overlays/pr-1"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := newOrphanTestReviewAppManager(tt.filepath, tt.dirpath)
+			got := m.ListOrphans(tt.paths, tt.ras)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ListOrphans() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/yaml"
@@ -23,10 +26,21 @@ type ReviewAppOrReviewAppManager interface {
 	InfraRepoTarget() InfraRepoTarget
 	InfraRepoConfig() dreamkastv1alpha1.ReviewAppManagerSpecInfraConfig
 	Variables() []string
+	VariablesFrom() []corev1.EnvFromSource
+	VariantRules() []dreamkastv1alpha1.VariantRule
+	StrictTemplating() bool
+	PreDeployOutputs() map[string]string
+	ReviewAppNamespaceName(pr PullRequest) types.NamespacedName
 }
 
 /* ReviewApp */
 
+// defaultJobTimeout is timeout of preStop & hook Jobs when timeoutSeconds is not specified
+const defaultJobTimeout = 300 * time.Second
+
+// defaultTeardownTimeout is timeout of waiting for environment to be torn down when timeoutSeconds is not specified
+const defaultTeardownTimeout = 600 * time.Second
+
 type ReviewApp dreamkastv1alpha1.ReviewApp
 
 func NewReviewApp(ra *dreamkastv1alpha1.ReviewApp) ReviewApp {
@@ -51,6 +65,22 @@ func (m ReviewApp) InfraRepoConfig() dreamkastv1alpha1.ReviewAppManagerSpecInfra
 func (m ReviewApp) Variables() []string {
 	return m.Spec.Variables
 }
+func (m ReviewApp) VariablesFrom() []corev1.EnvFromSource {
+	return m.Spec.VariablesFrom
+}
+func (m ReviewApp) VariantRules() []dreamkastv1alpha1.VariantRule {
+	return m.Spec.VariantRules
+}
+func (m ReviewApp) StrictTemplating() bool {
+	return m.Spec.StrictTemplating
+}
+func (m ReviewApp) PreDeployOutputs() map[string]string {
+	return m.Status.PreDeployJobs.Outputs
+}
+
+func (m ReviewApp) ReviewAppNamespaceName(pr PullRequest) types.NamespacedName {
+	return m.NamespaceName()
+}
 
 func (m ReviewApp) ToReviewAppCR() *dreamkastv1alpha1.ReviewApp {
 	ra := dreamkastv1alpha1.ReviewApp(m)
@@ -69,10 +99,94 @@ func (m ReviewApp) PrNum() int {
 	return m.Spec.AppPrNum
 }
 
+// VariablesFromConfigMapKeys returns "namespace/name" of ConfigMaps specified in spec.variablesFrom
+func (m ReviewApp) VariablesFromConfigMapKeys() []string {
+	var keys []string
+	for _, s := range m.Spec.VariablesFrom {
+		if s.ConfigMapRef != nil {
+			keys = append(keys, types.NamespacedName{Namespace: m.Namespace, Name: s.ConfigMapRef.Name}.String())
+		}
+	}
+	return keys
+}
+
+// VariablesFromSecretKeys returns "namespace/name" of Secrets specified in spec.variablesFrom
+func (m ReviewApp) VariablesFromSecretKeys() []string {
+	var keys []string
+	for _, s := range m.Spec.VariablesFrom {
+		if s.SecretRef != nil {
+			keys = append(keys, types.NamespacedName{Namespace: m.Namespace, Name: s.SecretRef.Name}.String())
+		}
+	}
+	return keys
+}
+
+// ApplicationTemplateKey returns "namespace/name" of ApplicationTemplate specified in spec.infraRepoConfig
+func (m ReviewApp) ApplicationTemplateKey() string {
+	at := m.Spec.InfraConfig.ArgoCDApp.Template
+	return types.NamespacedName{Namespace: at.Namespace, Name: at.Name}.String()
+}
+
+// ManifestsTemplateKeys returns "namespace/name" of ManifestsTemplates specified in spec.infraRepoConfig
+func (m ReviewApp) ManifestsTemplateKeys() []string {
+	var keys []string
+	for _, mt := range m.Spec.InfraConfig.Manifests.Templates {
+		keys = append(keys, types.NamespacedName{Namespace: mt.Namespace, Name: mt.Name}.String())
+	}
+	return keys
+}
+
+// ArgoCDApplicationKey returns "namespace/name" of Argo CD Application recorded in status.
+// It returns empty string if Application has not been pushed to Infra Repository yet.
+func (m ReviewApp) ArgoCDApplicationKey() string {
+	if m.Status.Sync.ApplicationName == "" {
+		return ""
+	}
+	return types.NamespacedName{Namespace: m.Status.Sync.ApplicationNamespace, Name: m.Status.Sync.ApplicationName}.String()
+}
+
 func (m ReviewApp) HavingPreStopJob() bool {
 	return m.Spec.PreStopJob.Namespace != "" && m.Spec.PreStopJob.Name != ""
 }
 
+// PreStopJobTimeout returns duration until the preStop Job is regarded as timed out
+func (m ReviewApp) PreStopJobTimeout() time.Duration {
+	if m.Spec.PreStopJobPolicy.TimeoutSeconds <= 0 {
+		return defaultJobTimeout
+	}
+	return time.Duration(m.Spec.PreStopJobPolicy.TimeoutSeconds) * time.Second
+}
+
+// PreStopJobFailurePolicy returns action when the preStop Job fails or times out
+func (m ReviewApp) PreStopJobFailurePolicy() dreamkastv1alpha1.PreStopJobFailurePolicy {
+	if m.Spec.PreStopJobPolicy.FailurePolicy == "" {
+		return dreamkastv1alpha1.PreStopJobFailurePolicyContinue
+	}
+	return m.Spec.PreStopJobPolicy.FailurePolicy
+}
+
+// TeardownTimeout returns duration until controller gives up waiting for environment to be torn down
+func (m ReviewApp) TeardownTimeout() time.Duration {
+	if m.Spec.TeardownVerification.TimeoutSeconds <= 0 {
+		return defaultTeardownTimeout
+	}
+	return time.Duration(m.Spec.TeardownVerification.TimeoutSeconds) * time.Second
+}
+
+// CanRetryPreStopJob returns true if the preStop Job can be created again by FailurePolicy "Retry"
+func (m ReviewApp) CanRetryPreStopJob() bool {
+	return m.PreStopJobFailurePolicy() == dreamkastv1alpha1.PreStopJobFailurePolicyRetry &&
+		m.Status.PreStop.Retries < m.Spec.PreStopJobPolicy.MaxRetries
+}
+
+// MessageOfInvalidManifests returns message that is sent to App Repository's PR when manifests are invalid
+func (m ReviewApp) MessageOfInvalidManifests(err error) string {
+	return fmt.Sprintf(
+		"reviewapp-operator did not update %s/%s because manifests of ReviewApp %s are invalid.\n\n```\n%s\n```",
+		m.Spec.InfraTarget.Organization, m.Spec.InfraTarget.Repository, m.Name, err,
+	)
+}
+
 func (m ReviewApp) HasMessageAlreadyBeenSent() bool {
 	status := m.GetStatus()
 	return m.Spec.AppConfig.Message == "" || (!m.Spec.AppConfig.SendMessageEveryTime && status.Sync.AlreadySentMessage)
@@ -86,6 +200,12 @@ func (m ReviewApp) GetStatus() ReviewAppStatus {
 
 type ReviewAppStatus dreamkastv1alpha1.ReviewAppStatus
 
+// ManifestsInvalidatedWith returns true if manifests have been already marked as invalid with the same message
+func (m ReviewAppStatus) ManifestsInvalidatedWith(message string) bool {
+	cond := meta.FindStatusCondition(m.Conditions, dreamkastv1alpha1.ReviewAppConditionManifestsValid)
+	return cond != nil && cond.Status == metav1.ConditionFalse && cond.Message == message
+}
+
 func (m ReviewAppStatus) UpdateStatusOfAppRepo(pr PullRequest) (ReviewAppStatus, bool) {
 	updated := false
 	checkUpdated := func(cond bool) {
@@ -97,9 +217,13 @@ func (m ReviewAppStatus) UpdateStatusOfAppRepo(pr PullRequest) (ReviewAppStatus,
 	m.Sync.SyncedPullRequest.Branch = pr.Branch
 	checkUpdated(m.Sync.SyncedPullRequest.LatestCommitHash != pr.LatestCommitHash)
 	m.Sync.SyncedPullRequest.LatestCommitHash = pr.LatestCommitHash
-	// PR の Title, Labels は更新されても skip
+	// PR の Title, Labels などは更新されても skip
+	m.Sync.SyncedPullRequest.BaseBranch = pr.BaseBranch
 	m.Sync.SyncedPullRequest.Title = pr.Title
 	m.Sync.SyncedPullRequest.Labels = pr.Labels
+	m.Sync.SyncedPullRequest.Author = pr.Author
+	m.Sync.SyncedPullRequest.URL = pr.URL
+	m.Sync.SyncedPullRequest.CreatedAt = pr.CreatedAt
 	return m, updated
 }
 
@@ -112,7 +236,7 @@ func (m ReviewAppStatus) UpdateStatusOfApplication(application Application) (Rev
 	if m.Sync.ApplicationName != argocdAppNamespacedName.Name || m.Sync.ApplicationNamespace != argocdAppNamespacedName.Namespace {
 		updated = true
 	}
-	if !reflect.DeepEqual(string(application), m.ManifestsCache.Application) {
+	if digest(string(application)) != m.cachedApplicationDigest() {
 		updated = true
 	}
 	m.Sync.ApplicationName = argocdAppNamespacedName.Name
@@ -122,16 +246,133 @@ func (m ReviewAppStatus) UpdateStatusOfApplication(application Application) (Rev
 
 func (m ReviewAppStatus) WasManifestsUpdated(manifests Manifests) bool {
 	updated := false
-	if !reflect.DeepEqual(map[string]string(manifests), m.ManifestsCache.Manifests) {
+	if !reflect.DeepEqual(digests(manifests), m.cachedManifestsDigests()) {
 		updated = true
 	}
 	return updated
 }
 
+// CacheManifests records digests of templated manifests to detect updates of templates.
+// Manifests themselves are not recorded, because they may contain values from Secrets.
+func (m ReviewAppStatus) CacheManifests(application Application, manifests Manifests) ReviewAppStatus {
+	m.ManifestsCache = dreamkastv1alpha1.ManifestsCache{
+		ApplicationDigest: digest(string(application)),
+		ManifestsDigests:  digests(manifests),
+	}
+	return m
+}
+
+// cachedApplicationDigest returns digest of Application cached at last.
+// ReviewApp created by older version caches Application itself, so its digest is returned.
+func (m ReviewAppStatus) cachedApplicationDigest() string {
+	if m.ManifestsCache.ApplicationDigest == "" && m.ManifestsCache.Application != "" {
+		return digest(m.ManifestsCache.Application)
+	}
+	return m.ManifestsCache.ApplicationDigest
+}
+
+// cachedManifestsDigests returns digests of Manifests cached at last.
+// ReviewApp created by older version caches Manifests themselves, so their digests are returned.
+func (m ReviewAppStatus) cachedManifestsDigests() map[string]string {
+	if len(m.ManifestsCache.ManifestsDigests) == 0 {
+		return digests(m.ManifestsCache.Manifests)
+	}
+	return m.ManifestsCache.ManifestsDigests
+}
+
+// StartPreStopJob records the preStop Job created at now
+func (m ReviewAppStatus) StartPreStopJob(job *batchv1.Job, retries int32, f *utils.DatetimeFactory) ReviewAppStatus {
+	m.PreStop = dreamkastv1alpha1.PreStopStatus{
+		Phase:          dreamkastv1alpha1.PreStopPhaseRunning,
+		JobName:        job.Name,
+		JobNamespace:   job.Namespace,
+		StartTimestamp: f.Now().ToString(),
+		Retries:        retries,
+	}
+	return m
+}
+
+// HasPreStopJobTimedOut returns true if timeout has passed since the preStop Job was created
+func (m ReviewAppStatus) HasPreStopJobTimedOut(timeout time.Duration, f *utils.DatetimeFactory) (bool, error) {
+	startedAt, err := utils.NewDatetime(m.PreStop.StartTimestamp)
+	if err != nil {
+		return false, err
+	}
+	return !f.Now().Before(startedAt, timeout), nil
+}
+
+// StartTeardown records that manifests have been deleted from Infra Repository at now
+func (m ReviewAppStatus) StartTeardown(namespace string, f *utils.DatetimeFactory) ReviewAppStatus {
+	m.Teardown = dreamkastv1alpha1.TeardownStatus{
+		StartTimestamp: f.Now().ToString(),
+		Namespace:      namespace,
+	}
+	return m
+}
+
+// HasTeardownStarted returns true if manifests have already been deleted from Infra Repository
+func (m ReviewAppStatus) HasTeardownStarted() bool {
+	return m.Teardown.StartTimestamp != ""
+}
+
+// HasTeardownTimedOut returns true if timeout has passed since manifests were deleted from Infra Repository
+func (m ReviewAppStatus) HasTeardownTimedOut(timeout time.Duration, f *utils.DatetimeFactory) (bool, error) {
+	startedAt, err := utils.NewDatetime(m.Teardown.StartTimestamp)
+	if err != nil {
+		return false, err
+	}
+	return !f.Now().Before(startedAt, timeout), nil
+}
+
 func (m ReviewAppStatus) HasApplicationBeenUpdated(hash string) bool {
 	return m.Sync.SyncedPullRequest.LatestCommitHash == hash
 }
 
+// UpdateExpiration sets expiration if lifetime is specified, and stops ReviewApp if it has expired.
+func (m ReviewAppStatus) UpdateExpiration(lifetime string, f *utils.DatetimeFactory) (ReviewAppStatus, error) {
+	if lifetime == "" || m.ChatOps.Stopped {
+		return m, nil
+	}
+	now := f.Now()
+	if m.ChatOps.ExpiresAt == "" {
+		d, err := ParseDuration(lifetime)
+		if err != nil {
+			return m, err
+		}
+		m.ChatOps.ExpiresAt = now.Add(d).ToString()
+		return m, nil
+	}
+	expiresAt, err := utils.NewDatetime(m.ChatOps.ExpiresAt)
+	if err != nil {
+		return m, err
+	}
+	if !now.Before(expiresAt, 0) {
+		m.ChatOps.Stopped = true
+	}
+	return m, nil
+}
+
+// StopReason returns why ReviewApp is stopped: expired by lifetime, or stopped by ChatOps command
+func (m ReviewAppStatus) StopReason(f *utils.DatetimeFactory) string {
+	if m.ChatOps.ExpiresAt != "" {
+		if expiresAt, err := utils.NewDatetime(m.ChatOps.ExpiresAt); err == nil && !f.Now().Before(expiresAt, 0) {
+			return StopReasonExpired
+		}
+	}
+	return StopReasonCommand
+}
+
+func (m ReviewAppStatus) NeedToSyncChatOpsCommands(period time.Duration, f *utils.DatetimeFactory) (bool, error) {
+	if m.ChatOps.SyncTimestamp == "" {
+		return true, nil
+	}
+	t, err := utils.NewDatetime(m.ChatOps.SyncTimestamp)
+	if err != nil {
+		return false, err
+	}
+	return t.Before(f.Now(), -period), nil
+}
+
 /* ReviewAppManager */
 
 type ReviewAppManager dreamkastv1alpha1.ReviewAppManager
@@ -158,6 +399,71 @@ func (m ReviewAppManager) InfraRepoConfig() dreamkastv1alpha1.ReviewAppManagerSp
 func (m ReviewAppManager) Variables() []string {
 	return m.Spec.Variables
 }
+func (m ReviewAppManager) VariablesFrom() []corev1.EnvFromSource {
+	return m.Spec.VariablesFrom
+}
+func (m ReviewAppManager) VariantRules() []dreamkastv1alpha1.VariantRule {
+	return m.Spec.VariantRules
+}
+func (m ReviewAppManager) StrictTemplating() bool {
+	return m.Spec.StrictTemplating
+}
+
+// VariableOverrides returns variables that PR overrides by its labels or its description.
+// Variables whose key is not in spec.variableOverrides.allowedKeys are excluded.
+// If the description of PR is invalid, it returns the overrides by labels with error.
+func (m ReviewAppManager) VariableOverrides(pr PullRequest) ([]string, error) {
+	conf := m.Spec.VariableOverrides
+	var vars []string
+	for _, l := range conf.Labels {
+		for _, prLabel := range pr.Labels {
+			if l.Name == prLabel {
+				vars = append(vars, l.Variables...)
+			}
+		}
+	}
+	var err error
+	if conf.PullRequestBody {
+		var varsInBody []string
+		varsInBody, err = pr.VariablesInBody()
+		vars = append(vars, varsInBody...)
+	}
+	var allowed []string
+	for _, line := range vars {
+		idx := strings.Index(line, "=")
+		if idx == -1 {
+			continue
+		}
+		for _, key := range conf.AllowedKeys {
+			if line[:idx] == key {
+				allowed = append(allowed, line)
+			}
+		}
+	}
+	return allowed, err
+}
+
+// WithVariableOverrides returns ReviewAppManager whose spec.variables is appended overrides.
+// Appended variables take precedence over the original ones.
+func (m ReviewAppManager) WithVariableOverrides(overrides []string) ReviewAppManager {
+	if len(overrides) == 0 {
+		return m
+	}
+	vars := make([]string, 0, len(m.Spec.Variables)+len(overrides))
+	vars = append(vars, m.Spec.Variables...)
+	vars = append(vars, overrides...)
+	m.Spec.Variables = vars
+	return m
+}
+
+// PreDeployOutputs returns nil because preDeploy Jobs are run for each ReviewApp
+func (m ReviewAppManager) PreDeployOutputs() map[string]string {
+	return nil
+}
+
+func (m ReviewAppManager) ReviewAppNamespaceName(pr PullRequest) types.NamespacedName {
+	return types.NamespacedName{Namespace: m.Namespace, Name: m.ReviewAppName(pr)}
+}
 
 func (m ReviewAppManager) ToReviewAppCR() *dreamkastv1alpha1.ReviewAppManager {
 	ram := dreamkastv1alpha1.ReviewAppManager(m)
@@ -171,26 +477,43 @@ func (m ReviewAppManager) GenerateReviewApp(pr PullRequest, v Templator, f *util
 			Namespace: m.Namespace,
 		},
 		Spec: dreamkastv1alpha1.ReviewAppSpec{
-			AppTarget:   m.Spec.AppTarget,
-			InfraTarget: m.Spec.InfraTarget,
-			Variables:   m.Spec.Variables,
-			PreStopJob:  m.Spec.PreStopJob,
-			AppPrNum:    pr.Number,
+			AppTarget:            m.Spec.AppTarget,
+			InfraTarget:          m.Spec.InfraTarget,
+			Variables:            m.Spec.Variables,
+			VariablesFrom:        m.Spec.VariablesFrom,
+			VariantRules:         m.Spec.VariantRules,
+			StrictTemplating:     m.Spec.StrictTemplating,
+			PreStopJob:           m.Spec.PreStopJob,
+			PreStopJobPolicy:     m.Spec.PreStopJobPolicy,
+			PostDeployJobs:       m.Spec.PostDeployJobs,
+			PreDeployJobs:        m.Spec.PreDeployJobs,
+			JobLogs:              m.Spec.JobLogs,
+			TeardownVerification: m.Spec.TeardownVerification,
+			AppPrNum:             pr.Number,
+			Suspend:              m.Spec.Suspend,
+			DryRun:               m.Spec.DryRun,
 		},
 		Status: dreamkastv1alpha1.ReviewAppStatus{
 			Sync: dreamkastv1alpha1.SyncStatus{
 				SyncedPullRequest: dreamkastv1alpha1.ReviewAppStatusSyncedPullRequest{
 					Branch:           pr.Branch,
+					BaseBranch:       pr.BaseBranch,
 					LatestCommitHash: pr.LatestCommitHash,
 					Title:            pr.Title,
 					Labels:           pr.Labels,
+					Author:           pr.Author,
+					URL:              pr.URL,
+					CreatedAt:        pr.CreatedAt,
 					SyncTimestamp:    f.Now().ToString(),
 				},
 			},
 		},
 	}
 	{ // template from ram.Spec.AppConfig to ra.Spec.AppConfig
-		out, err := yaml.Marshal(&m.Spec.AppConfig)
+		// Message is not templated here, because it is templated by ReviewApp with values known after deployed (e.g. commit hash of infra repo)
+		appConfig := m.Spec.AppConfig
+		appConfig.Message = ""
+		out, err := yaml.Marshal(&appConfig)
 		if err != nil {
 			return ReviewApp{}, err
 		}
@@ -201,6 +524,7 @@ func (m ReviewAppManager) GenerateReviewApp(pr PullRequest, v Templator, f *util
 		if err := yaml.Unmarshal([]byte(appConfigStr), &ra.Spec.AppConfig); err != nil {
 			return ReviewApp{}, err
 		}
+		ra.Spec.AppConfig.Message = m.Spec.AppConfig.Message
 	}
 	{ // template from ram.Spec.InfraConfig to ra.Spec.InfraConfig
 		out, err := yaml.Marshal(&m.Spec.InfraConfig)
@@ -241,6 +565,18 @@ func (m ReviewAppManager) IsPullRequestAlreadySynced(pr PullRequest) bool {
 
 func (m ReviewAppManager) ListOutOfSyncReviewAppName(prs []PullRequest) []string {
 	var result []string
+	for _, a := range m.ListOutOfSyncPullRequests(prs) {
+		result = append(result, m.ReviewAppName(PullRequest{
+			Organization: a.Organization,
+			Repository:   a.Repository,
+			Number:       a.Number,
+		}))
+	}
+	return result
+}
+
+func (m ReviewAppManager) ListOutOfSyncPullRequests(prs []PullRequest) []dreamkastv1alpha1.ReviewAppManagerStatusSyncedPullRequests {
+	var result []dreamkastv1alpha1.ReviewAppManagerStatusSyncedPullRequests
 loop:
 	for _, a := range m.Status.SyncedPullRequests {
 		for _, b := range prs {
@@ -248,11 +584,7 @@ loop:
 				continue loop
 			}
 		}
-		result = append(result, m.ReviewAppName(PullRequest{
-			Organization: a.Organization,
-			Repository:   a.Repository,
-			Number:       a.Number,
-		}))
+		result = append(result, a)
 	}
 	return result
 }
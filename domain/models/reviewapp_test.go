@@ -0,0 +1,116 @@
+//go:build !integration_test
+// +build !integration_test
+
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dreamkastv1alpha1 "github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
+)
+
+func TestReviewAppStatus_WasManifestsUpdated(t *testing.T) {
+	manifests := Manifests{"secret.yaml": "password: p@ssw0rd"}
+	updatedManifests := Manifests{"secret.yaml": "password: updated"}
+	cached := ReviewAppStatus{}.CacheManifests(Application("app"), manifests)
+	legacyCached := ReviewAppStatus{ManifestsCache: dreamkastv1alpha1.ManifestsCache{
+		Application: "app",
+		Manifests:   manifests,
+	}}
+
+	for _, v := range cached.ManifestsCache.ManifestsDigests {
+		if strings.Contains(v, "p@ssw0rd") {
+			t.Errorf("ReviewAppStatus.CacheManifests() records manifests as plaintext: %v", v)
+		}
+	}
+	tests := []struct {
+		name      string
+		status    ReviewAppStatus
+		manifests Manifests
+		want      bool
+	}{
+		{name: "not updated", status: cached, manifests: manifests, want: false},
+		{name: "updated", status: cached, manifests: updatedManifests, want: true},
+		{name: "file is removed", status: cached, manifests: Manifests{}, want: true},
+		{name: "not updated since cached by older version", status: legacyCached, manifests: manifests, want: false},
+		{name: "updated since cached by older version", status: legacyCached, manifests: updatedManifests, want: true},
+		{name: "nothing is cached", status: ReviewAppStatus{}, manifests: manifests, want: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.status.WasManifestsUpdated(tt.manifests); got != tt.want {
+				t.Errorf("ReviewAppStatus.WasManifestsUpdated() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReviewApp_VariablesFromKeys(t *testing.T) {
+	ra := ReviewApp{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-ra", Namespace: "test-ns"},
+		Spec: dreamkastv1alpha1.ReviewAppSpec{VariablesFrom: []corev1.EnvFromSource{
+			{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "test-cm"}}},
+			{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "test-secret"}}, Prefix: "DB_"},
+		}},
+	}
+	if diff := cmp.Diff([]string{"test-ns/test-cm"}, ra.VariablesFromConfigMapKeys()); diff != "" {
+		t.Errorf("VariablesFromConfigMapKeys() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"test-ns/test-secret"}, ra.VariablesFromSecretKeys()); diff != "" {
+		t.Errorf("VariablesFromSecretKeys() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReviewAppManager_VariableOverrides(t *testing.T) {
+	ram := ReviewAppManager{}
+	ram.Spec.VariableOverrides = dreamkastv1alpha1.ReviewAppManagerSpecVariableOverrides{
+		AllowedKeys: []string{"replicas", "image"},
+		Labels: []dreamkastv1alpha1.ReviewAppManagerSpecVariableOverridesLabel{
+			{Name: "large", Variables: []string{"replicas=3", "namespace=kube-system"}},
+		},
+		PullRequestBody: true,
+	}
+	tests := []struct {
+		name    string
+		pr      PullRequest
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "variables of label and body",
+			pr:   PullRequest{Labels: []string{"large"}, Body: "```reviewapp\nimage: nginx:1.21\n```"},
+			want: []string{"replicas=3", "image=nginx:1.21"},
+		},
+		{
+			name: "disallowed keys are ignored",
+			pr:   PullRequest{Body: "```reviewapp\nimage: nginx:1.21\nnamespace: kube-system\n```"},
+			want: []string{"image=nginx:1.21"},
+		},
+		{
+			name:    "variables of label are returned even if body is malformed",
+			pr:      PullRequest{Labels: []string{"large"}, Body: "```reviewapp\nimage: [nginx\n```"},
+			want:    []string{"replicas=3"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ram.VariableOverrides(tt.pr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReviewAppManager.VariableOverrides() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("ReviewAppManager.VariableOverrides() is unexpected:\n%v", diff)
+			}
+		})
+	}
+}
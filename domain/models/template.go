@@ -2,32 +2,54 @@ package models
 
 import (
 	"bytes"
+	"regexp"
+	"strconv"
 	"strings"
 	"text/template"
 
 	"golang.org/x/xerrors"
+	"k8s.io/apimachinery/pkg/types"
+
+	myerrors "github.com/cloudnativedaysjp/reviewapp-operator/errors"
 )
 
 type Templator struct {
 	AppRepo   templateValueAppRepoInfo
 	InfraRepo templateValueInfraRepoInfo
+	ReviewApp templateValueReviewAppInfo
 	Variables map[string]string
+
+	// strict is flag to fail templating if map (e.g. Variables) has no entry for key
+	strict bool
 }
 
 type templateValueAppRepoInfo struct {
 	Organization     string
 	Repository       string
 	Branch           string
+	BaseBranch       string
 	PrNumber         int
+	PrTitle          string
+	PrAuthor         string
+	PrLabels         []string
+	PrURL            string
+	PrCreatedAt      string
 	LatestCommitHash string
+	ShortCommitHash  string
 }
 
 type templateValueInfraRepoInfo struct {
 	Organization string
 	Repository   string
 	Branch       string
-	// TODO: #56
-	//LatestCommitHash string
+	// LatestCommitHash is hash of the commit that was pushed to infra repo at last.
+	// This value is available only in message to App Repository's PR, because manifests are included in the commit.
+	LatestCommitHash string
+}
+
+type templateValueReviewAppInfo struct {
+	Name      string
+	Namespace string
 }
 
 func NewTemplator(
@@ -44,30 +66,99 @@ func NewTemplator(
 	}
 	appTarget := m.AppRepoTarget()
 	infraTarget := m.InfraRepoTarget()
+	raNamespacedName := m.ReviewAppNamespaceName(pr)
 	return Templator{
 		templateValueAppRepoInfo{
 			Organization:     appTarget.Organization,
 			Repository:       appTarget.Repository,
 			Branch:           pr.Branch,
+			BaseBranch:       pr.BaseBranch,
 			PrNumber:         pr.Number,
+			PrTitle:          pr.Title,
+			PrAuthor:         pr.Author,
+			PrLabels:         pr.Labels,
+			PrURL:            pr.URL,
+			PrCreatedAt:      pr.CreatedAt,
 			LatestCommitHash: pr.LatestCommitHash,
+			ShortCommitHash:  shortSha(pr.LatestCommitHash),
 		},
 		templateValueInfraRepoInfo{
 			Organization: infraTarget.Organization,
 			Repository:   infraTarget.Repository,
 			Branch:       infraTarget.Branch,
 		},
+		templateValueReviewAppInfo{
+			Name:      raNamespacedName.Name,
+			Namespace: raNamespacedName.Namespace,
+		},
 		vars,
+		m.StrictTemplating(),
 	}
 }
 
 func (v Templator) WithAppRepoLatestCommitHash(sha string) *Templator {
 	v.AppRepo.LatestCommitHash = sha
+	v.AppRepo.ShortCommitHash = shortSha(sha)
+	return &v
+}
+
+// WithVariablesFrom adds variables resolved from spec.variablesFrom.
+// variables given by spec.variables take precedence.
+func (v Templator) WithVariablesFrom(vars map[string]string) *Templator {
+	merged := make(map[string]string)
+	for key, val := range vars {
+		merged[key] = val
+	}
+	for key, val := range v.Variables {
+		merged[key] = val
+	}
+	v.Variables = merged
+	return &v
+}
+
+// WithOutputs adds variables written by preDeploy Jobs.
+// They take precedence over any other variables because they are specific to the ReviewApp.
+func (v Templator) WithOutputs(outputs map[string]string) *Templator {
+	merged := make(map[string]string)
+	for key, val := range v.Variables {
+		merged[key] = val
+	}
+	for key, val := range outputs {
+		merged[key] = val
+	}
+	v.Variables = merged
+	return &v
+}
+
+func (v Templator) WithInfraRepoLatestCommitHash(sha string) *Templator {
+	v.InfraRepo.LatestCommitHash = sha
 	return &v
 }
 
 func (v Templator) Templating(text string) (string, error) {
-	tmpl, err := template.New("Templating").Parse(text)
+	return v.templating("Templating", text)
+}
+
+// templateFile templates text as file of the template object.
+// If it fails, it returns error that has name of the template object, key of the file and line number.
+func (v Templator) templateFile(kind string, nn types.NamespacedName, key, text string) (string, error) {
+	out, err := v.templating(key, text)
+	if err != nil {
+		line := 0
+		if match := regexp.MustCompile(`template: ` + regexp.QuoteMeta(key) + `:(\d+)`).FindStringSubmatch(err.Error()); match != nil {
+			line, _ = strconv.Atoi(match[1])
+		}
+		return "", myerrors.NewTemplatingFailed(err, kind, nn, key, line)
+	}
+	return out, nil
+}
+
+func (v Templator) templating(name, text string) (string, error) {
+	tmpl := template.New(name).Funcs(templateFuncMap())
+	if v.strict {
+		tmpl = tmpl.Option("missingkey=error")
+	}
+	tmpl, err := tmpl.Parse(text)
 	if err != nil {
 		return "", xerrors.Errorf("Error to parse template: %w", err)
 	}
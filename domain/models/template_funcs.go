@@ -0,0 +1,66 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig/v3"
+)
+
+const (
+	dnsLabelMaxLength = 63
+	shortShaLength    = 7
+	// length of bytes of hash used as dnsLabel for string without any valid character
+	dnsLabelFallbackHashLength = 4
+)
+
+var (
+	// sprig functions that depend on environment or produce different output on each rendering are excluded,
+	// because rendered manifests must be reproducible to detect updates of templates.
+	excludedSprigFuncs = []string{
+		"env", "expandenv", "getHostByName",
+		"now", "ago", "date_in_zone", "dateInZone",
+		"randAlphaNum", "randAlpha", "randAscii", "randNumeric", "randBytes", "randInt", "shuffle", "uuidv4",
+		"genPrivateKey", "derivePassword", "buildCustomCert", "genCA", "genCAWithKey",
+		"genSelfSignedCert", "genSelfSignedCertWithKey", "genSignedCert", "genSignedCertWithKey",
+		"encryptAES", "decryptAES", "htpasswd",
+	}
+
+	invalidDNSLabelChars = regexp.MustCompile(`[^a-z0-9-]+`)
+)
+
+func templateFuncMap() template.FuncMap {
+	funcs := sprig.TxtFuncMap()
+	for _, name := range excludedSprigFuncs {
+		delete(funcs, name)
+	}
+	funcs["dnsLabel"] = dnsLabel
+	funcs["shortSha"] = shortSha
+	return funcs
+}
+
+// dnsLabel converts string to RFC 1123 label (e.g. "Feature/Add_API" -> "feature-add-api").
+// If string has no valid character (e.g. "___", "日本語"), it returns hex of its hash not to be empty.
+func dnsLabel(s string) string {
+	label := invalidDNSLabelChars.ReplaceAllString(strings.ToLower(s), "-")
+	label = strings.Trim(label, "-")
+	if len(label) > dnsLabelMaxLength {
+		label = strings.TrimRight(label[:dnsLabelMaxLength], "-")
+	}
+	if label == "" {
+		sum := sha256.Sum256([]byte(s))
+		label = hex.EncodeToString(sum[:dnsLabelFallbackHashLength])
+	}
+	return label
+}
+
+// shortSha returns abbreviated commit hash
+func shortSha(s string) string {
+	if len(s) > shortShaLength {
+		return s[:shortShaLength]
+	}
+	return s
+}
@@ -0,0 +1,91 @@
+//go:build !integration_test
+// +build !integration_test
+
+package models
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func Test_dnsLabel(t *testing.T) {
+	validDNSLabel := regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{
+			name: "branch name",
+			s:    "Feature/Add_API",
+			want: "feature-add-api",
+		},
+		{
+			name: "leading and trailing invalid characters are trimmed",
+			s:    "--_fix_--",
+			want: "fix",
+		},
+		{
+			name: "truncated to 63 characters without trailing hyphen",
+			s:    strings.Repeat("a", 62) + "-b",
+			want: strings.Repeat("a", 62),
+		},
+		{
+			name: "only invalid characters",
+			s:    "___",
+			want: "bda25155",
+		},
+		{
+			name: "multibyte characters",
+			s:    "日本語",
+			want: "77710aed",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := dnsLabel(tt.s)
+			if got != tt.want {
+				t.Errorf("dnsLabel() = %v, want %v", got, tt.want)
+			}
+			if !validDNSLabel.MatchString(got) || len(got) > dnsLabelMaxLength {
+				t.Errorf("dnsLabel() = %v is not RFC 1123 label", got)
+			}
+		})
+	}
+}
+
+func Test_shortSha(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{
+			name: "full commit hash",
+			s:    "0123456789abcdef0123456789abcdef01234567",
+			want: "0123456",
+		},
+		{
+			name: "shorter than 7 characters",
+			s:    "0123",
+			want: "0123",
+		},
+		{
+			name: "empty",
+			s:    "",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := shortSha(tt.s); got != tt.want {
+				t.Errorf("shortSha() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/yaml"
+)
+
+// ParseTemplate parses text as Go template with functions available in templating.
+// It only checks syntax, so it does not check whether variables referenced from text exist.
+func ParseTemplate(text string) error {
+	_, err := template.New("validation").Funcs(templateFuncMap()).Parse(text)
+	return err
+}
+
+func validateTemplate(path *field.Path, text string) field.ErrorList {
+	if err := ParseTemplate(text); err != nil {
+		return field.ErrorList{field.Invalid(path, text, err.Error())}
+	}
+	return nil
+}
+
+func validateTemplates(path *field.Path, m map[string]string) field.ErrorList {
+	var errs field.ErrorList
+	for _, key := range sortedKeys(m) {
+		errs = append(errs, validateTemplate(path.Key(key), m[key])...)
+	}
+	return errs
+}
+
+func validateRegexp(path *field.Path, exp string) field.ErrorList {
+	if _, err := regexp.Compile(exp); err != nil {
+		return field.ErrorList{field.Invalid(path, exp, err.Error())}
+	}
+	return nil
+}
+
+// validateVariables checks that each variable is formatted as "KEY=value"
+func validateVariables(path *field.Path, vars []string) field.ErrorList {
+	var errs field.ErrorList
+	for i, line := range vars {
+		idx := strings.Index(line, "=")
+		if idx == -1 {
+			errs = append(errs, field.Invalid(path.Index(i), line, `must be formatted as "KEY=value"`))
+			continue
+		}
+		key := line[:idx]
+		if key == "" || strings.ContainsAny(key, " \t\r\n") {
+			errs = append(errs, field.Invalid(path.Index(i), line, "KEY must be non-empty and must not contain whitespace"))
+		}
+	}
+	return errs
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Validate validates fields that cannot be validated by OpenAPI schema of CRD.
+func (m ReviewAppManager) Validate() field.ErrorList {
+	var errs field.ErrorList
+	spec := field.NewPath("spec")
+
+	errs = append(errs, validateRegexp(spec.Child("appRepoTarget", "ignoreTitleExp"), m.Spec.AppTarget.IgnoreTitleExp)...)
+	for i, rule := range m.Spec.VariantRules {
+		errs = append(errs, validateRegexp(spec.Child("variantRules").Index(i).Child("branchExp"), rule.BranchExp)...)
+	}
+
+	errs = append(errs, validateVariables(spec.Child("variables"), m.Spec.Variables)...)
+	for i, label := range m.Spec.VariableOverrides.Labels {
+		errs = append(errs, validateVariables(spec.Child("variableOverrides", "labels").Index(i).Child("variables"), label.Variables)...)
+	}
+
+	appConfig := spec.Child("appRepoConfig")
+	errs = append(errs, validateTemplate(appConfig.Child("message"), m.Spec.AppConfig.Message)...)
+	if lifetime := m.Spec.AppConfig.ChatOps.Lifetime; lifetime != "" {
+		if _, err := ParseDuration(lifetime); err != nil {
+			errs = append(errs, field.Invalid(appConfig.Child("chatops", "lifetime"), lifetime, err.Error()))
+		}
+	}
+	for i, j := range m.Spec.PreDeployJobs {
+		errs = append(errs, validateTemplate(spec.Child("preDeployJobs").Index(i).Child("outputConfigMap"), j.OutputConfigMap)...)
+	}
+	for i, j := range m.Spec.PostDeployJobs {
+		if j.OutputConfigMap != "" {
+			errs = append(errs, field.Forbidden(spec.Child("postDeployJobs").Index(i).Child("outputConfigMap"), "only preDeployJobs can have outputs"))
+		}
+	}
+	infraConfig := spec.Child("infraRepoConfig")
+	errs = append(errs, validateTemplate(infraConfig.Child("manifests", "dirpath"), m.Spec.InfraConfig.Manifests.Dirpath)...)
+	errs = append(errs, validateTemplate(infraConfig.Child("argocdApp", "filepath"), m.Spec.InfraConfig.ArgoCDApp.Filepath)...)
+	if out, err := yaml.Marshal(&m.Spec.InfraConfig); err == nil {
+		// other fields (e.g. name of templates) are also templated as YAML
+		if err := ParseTemplate(string(out)); err != nil {
+			errs = append(errs, field.Invalid(infraConfig, m.Spec.InfraConfig, err.Error()))
+		}
+	}
+	return errs
+}
+
+// Validate validates that all templates can be parsed.
+func (m ApplicationTemplate) Validate() field.ErrorList {
+	var errs field.ErrorList
+	spec := field.NewPath("spec")
+	errs = append(errs, validateTemplate(spec.Child("stable"), m.Spec.StableTemplate)...)
+	errs = append(errs, validateTemplate(spec.Child("candidate"), m.Spec.CandidateTemplate)...)
+	errs = append(errs, validateTemplates(spec.Child("variants"), m.Spec.Variants)...)
+	return errs
+}
+
+// Validate validates that all templates can be parsed.
+func (m ManifestsTemplate) Validate() field.ErrorList {
+	var errs field.ErrorList
+	spec := field.NewPath("spec")
+	errs = append(errs, validateTemplates(spec.Child("stable"), m.Spec.StableData)...)
+	errs = append(errs, validateTemplates(spec.Child("candidate"), m.Spec.CandidateData)...)
+	variants := make([]string, 0, len(m.Spec.Variants))
+	for variant := range m.Spec.Variants {
+		variants = append(variants, variant)
+	}
+	sort.Strings(variants)
+	for _, variant := range variants {
+		errs = append(errs, validateTemplates(spec.Child("variants").Key(variant), m.Spec.Variants[variant])...)
+	}
+	if helm := m.Spec.Helm; helm != nil {
+		path := spec.Child("helm")
+		if helm.Repository == "" && helm.Path == "" {
+			errs = append(errs, field.Required(path, fmt.Sprintf("either %s or %s must be specified",
+				path.Child("repository"), path.Child("path"))))
+		}
+		errs = append(errs, validateTemplate(path.Child("stableValues"), helm.StableValues)...)
+		errs = append(errs, validateTemplate(path.Child("candidateValues"), helm.CandidateValues)...)
+		errs = append(errs, validateTemplates(path.Child("variantValues"), helm.VariantValues)...)
+		// Chart.yaml & values.yaml are generated from helm
+		paths := []*field.Path{spec.Child("stable"), spec.Child("candidate")}
+		data := []map[string]string{m.Spec.StableData, m.Spec.CandidateData}
+		for _, variant := range variants {
+			paths = append(paths, spec.Child("variants").Key(variant))
+			data = append(data, m.Spec.Variants[variant])
+		}
+		for i := range data {
+			for _, filename := range []string{helmChartFilename, helmValuesFilename} {
+				if _, ok := data[i][filename]; ok {
+					errs = append(errs, field.Forbidden(paths[i].Key(filename), "must not be specified when spec.helm is specified"))
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// Validate validates that all templates can be parsed.
+func (m JobTemplate) Validate() field.ErrorList {
+	var errs field.ErrorList
+	spec := field.NewPath("spec")
+	errs = append(errs, validateTemplate(spec.Child("template"), m.Spec.Template)...)
+	errs = append(errs, validateTemplate(spec.Child("stable"), m.Spec.StableTemplate)...)
+	errs = append(errs, validateTemplate(spec.Child("candidate"), m.Spec.CandidateTemplate)...)
+	errs = append(errs, validateTemplates(spec.Child("variants"), m.Spec.Variants)...)
+	return errs
+}
@@ -0,0 +1,231 @@
+//go:build !integration_test
+// +build !integration_test
+
+package models
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	dreamkastv1alpha1 "github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
+)
+
+// errorFields returns "<type>: <field>" of each error to compare ErrorList regardless of messages
+func errorFields(errs field.ErrorList) []string {
+	var result []string
+	for _, err := range errs {
+		result = append(result, string(err.Type)+": "+err.Field)
+	}
+	return result
+}
+
+func TestReviewAppManager_Validate(t *testing.T) {
+	newRam := func(f func(spec *dreamkastv1alpha1.ReviewAppManagerSpec)) ReviewAppManager {
+		m := ReviewAppManager{Spec: dreamkastv1alpha1.ReviewAppManagerSpec{
+			AppTarget: dreamkastv1alpha1.ReviewAppManagerSpecAppTarget{IgnoreTitleExp: "^WIP"},
+			AppConfig: dreamkastv1alpha1.ReviewAppManagerSpecAppConfig{
+				Message: "deployed {{.AppRepo.LatestCommitHash}}",
+			},
+			InfraConfig: dreamkastv1alpha1.ReviewAppManagerSpecInfraConfig{
+				Manifests: dreamkastv1alpha1.ReviewAppManagerSpecInfraManifests{
+					Templates: []dreamkastv1alpha1.NamespacedName{{Namespace: "default", Name: "mt-{{.Variables.env}}"}},
+					Dirpath:   "overlays/pr-{{.AppRepo.PrNumber}}",
+				},
+				ArgoCDApp: dreamkastv1alpha1.ReviewAppManagerSpecInfraArgoCDApp{
+					Template: dreamkastv1alpha1.NamespacedName{Namespace: "default", Name: "at"},
+					Filepath: "apps/pr-{{.AppRepo.PrNumber}}.yaml",
+				},
+			},
+			Variables: []string{"env=dev", "EMPTY="},
+		}}
+		if f != nil {
+			f(&m.Spec)
+		}
+		return m
+	}
+
+	tests := []struct {
+		name string
+		ram  ReviewAppManager
+		want []string
+	}{
+		{
+			name: "valid",
+			ram:  newRam(nil),
+		},
+		{
+			name: "invalid regular expressions",
+			ram: newRam(func(spec *dreamkastv1alpha1.ReviewAppManagerSpec) {
+				spec.AppTarget.IgnoreTitleExp = "["
+				spec.VariantRules = []dreamkastv1alpha1.VariantRule{
+					{Variant: "next", BranchExp: "^next/"},
+					{Variant: "canary", BranchExp: "(canary"},
+				}
+			}),
+			want: []string{
+				"FieldValueInvalid: spec.appRepoTarget.ignoreTitleExp",
+				"FieldValueInvalid: spec.variantRules[1].branchExp",
+			},
+		},
+		{
+			name: "invalid syntax of variables",
+			ram: newRam(func(spec *dreamkastv1alpha1.ReviewAppManagerSpec) {
+				spec.Variables = []string{"env=dev", "noequal", "=value", "MY KEY=value"}
+				spec.VariableOverrides.Labels = []dreamkastv1alpha1.ReviewAppManagerSpecVariableOverridesLabel{
+					{Name: "large", Variables: []string{"replicas"}},
+				}
+			}),
+			want: []string{
+				"FieldValueInvalid: spec.variables[1]",
+				"FieldValueInvalid: spec.variables[2]",
+				"FieldValueInvalid: spec.variables[3]",
+				"FieldValueInvalid: spec.variableOverrides.labels[0].variables[0]",
+			},
+		},
+		{
+			name: "invalid templates",
+			ram: newRam(func(spec *dreamkastv1alpha1.ReviewAppManagerSpec) {
+				spec.AppConfig.Message = "{{.AppRepo.PrNumber"
+				spec.InfraConfig.Manifests.Dirpath = "overlays/{{if}}"
+				spec.PreDeployJobs = []dreamkastv1alpha1.HookJob{
+					{Namespace: "default", Name: "migrate", OutputConfigMap: "{{.ReviewApp.Name}"},
+				}
+			}),
+			want: []string{
+				"FieldValueInvalid: spec.appRepoConfig.message",
+				"FieldValueInvalid: spec.preDeployJobs[0].outputConfigMap",
+				"FieldValueInvalid: spec.infraRepoConfig.manifests.dirpath",
+				"FieldValueInvalid: spec.infraRepoConfig",
+			},
+		},
+		{
+			name: "references to templates including invalid template",
+			ram: newRam(func(spec *dreamkastv1alpha1.ReviewAppManagerSpec) {
+				spec.InfraConfig.ArgoCDApp.Template.Name = "at-{{.Variables.env"
+			}),
+			want: []string{
+				"FieldValueInvalid: spec.infraRepoConfig",
+			},
+		},
+		{
+			name: "invalid lifetime",
+			ram: newRam(func(spec *dreamkastv1alpha1.ReviewAppManagerSpec) {
+				spec.AppConfig.ChatOps.Lifetime = "3 days"
+			}),
+			want: []string{
+				"FieldValueInvalid: spec.appRepoConfig.chatops.lifetime",
+			},
+		},
+		{
+			name: "outputs of postDeployJobs",
+			ram: newRam(func(spec *dreamkastv1alpha1.ReviewAppManagerSpec) {
+				spec.PostDeployJobs = []dreamkastv1alpha1.HookJob{
+					{Namespace: "default", Name: "notify"},
+					{Namespace: "default", Name: "smoke", OutputConfigMap: "outputs"},
+				}
+			}),
+			want: []string{
+				"FieldValueForbidden: spec.postDeployJobs[1].outputConfigMap",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := errorFields(tt.ram.Validate())
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ReviewAppManager.Validate() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTemplates_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template interface{ Validate() field.ErrorList }
+		want     []string
+	}{
+		{
+			name: "valid ApplicationTemplate",
+			template: ApplicationTemplate{Spec: dreamkastv1alpha1.ApplicationTemplateSpec{
+				StableTemplate:    "name: {{.ReviewApp.Name}}",
+				CandidateTemplate: "name: {{.ReviewApp.Name | dnsLabel}}",
+				Variants:          map[string]string{"next": "name: next"},
+			}},
+		},
+		{
+			name: "invalid ApplicationTemplate",
+			template: ApplicationTemplate{Spec: dreamkastv1alpha1.ApplicationTemplateSpec{
+				StableTemplate:    "name: {{.ReviewApp.Name}",
+				CandidateTemplate: "name: {{.ReviewApp.Name | undefinedFunc}}",
+				Variants:          map[string]string{"next": "name: next", "canary": "{{end}}"},
+			}},
+			want: []string{
+				"FieldValueInvalid: spec.stable",
+				"FieldValueInvalid: spec.candidate",
+				"FieldValueInvalid: spec.variants[canary]",
+			},
+		},
+		{
+			name: "invalid ManifestsTemplate",
+			template: ManifestsTemplate{Spec: dreamkastv1alpha1.ManifestsTemplateSpec{
+				StableData:    map[string]string{"a.yaml": "{{", "b.yaml": "kind: Namespace"},
+				CandidateData: map[string]string{"a.yaml": "kind: Namespace"},
+				Variants: map[string]dreamkastv1alpha1.ManifestsTemplateData{
+					"next": {"a.yaml": "{{.Variables.x}"},
+				},
+			}},
+			want: []string{
+				"FieldValueInvalid: spec.stable[a.yaml]",
+				"FieldValueInvalid: spec.variants[next][a.yaml]",
+			},
+		},
+		{
+			name: "ManifestsTemplate with helm",
+			template: ManifestsTemplate{Spec: dreamkastv1alpha1.ManifestsTemplateSpec{
+				StableData: map[string]string{"ns.yaml": "kind: Namespace", "values.yaml": "a: b"},
+				Variants: map[string]dreamkastv1alpha1.ManifestsTemplateData{
+					"next": {"Chart.yaml": "name: next"},
+				},
+				Helm: &dreamkastv1alpha1.ManifestsTemplateSpecHelm{
+					Chart:         "nginx",
+					StableValues:  "replicaCount: {{.Variables.replicas",
+					VariantValues: map[string]string{"next": "{{end}}"},
+				},
+			}},
+			want: []string{
+				"FieldValueRequired: spec.helm",
+				"FieldValueInvalid: spec.helm.stableValues",
+				"FieldValueInvalid: spec.helm.variantValues[next]",
+				"FieldValueForbidden: spec.stable[values.yaml]",
+				"FieldValueForbidden: spec.variants[next][Chart.yaml]",
+			},
+		},
+		{
+			name: "invalid JobTemplate",
+			template: JobTemplate{Spec: dreamkastv1alpha1.JobTemplateSpec{
+				Template:          "{{.ReviewApp.Name}",
+				CandidateTemplate: "kind: Job",
+				Variants:          map[string]string{"next": "{{if}}"},
+			}},
+			want: []string{
+				"FieldValueInvalid: spec.template",
+				"FieldValueInvalid: spec.variants[next]",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := errorFields(tt.template.Validate())
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Validate() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	myerrors "github.com/cloudnativedaysjp/reviewapp-operator/errors"
+)
+
+// NewVariablesFrom resolves variables of templates from ConfigMaps & Secrets specified by spec.variablesFrom,
+// in the same way as envFrom of containers (keys are prefixed, and later sources take precedence).
+// configMaps & secrets are objects in the namespace of m keyed by name. Optional sources not in them are ignored.
+func NewVariablesFrom(m ReviewAppOrReviewAppManager, configMaps map[string]corev1.ConfigMap, secrets map[string]corev1.Secret) (map[string]string, error) {
+	namespace := m.NamespaceName().Namespace
+	vars := make(map[string]string)
+	for _, source := range m.VariablesFrom() {
+		if ref := source.ConfigMapRef; ref != nil {
+			cm, ok := configMaps[ref.Name]
+			if !ok && !isOptional(ref.Optional) {
+				gvk := schema.GroupVersionKind{Group: "", Version: "v1", Kind: "ConfigMap"}
+				nn := types.NamespacedName{Namespace: namespace, Name: ref.Name}
+				return nil, myerrors.NewK8sObjectNotFound(xerrors.Errorf("ConfigMap %s is not found", nn), gvk, nn)
+			}
+			for key, val := range cm.Data {
+				vars[source.Prefix+key] = val
+			}
+		}
+		if ref := source.SecretRef; ref != nil {
+			secret, ok := secrets[ref.Name]
+			if !ok && !isOptional(ref.Optional) {
+				gvk := schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Secret"}
+				nn := types.NamespacedName{Namespace: namespace, Name: ref.Name}
+				return nil, myerrors.NewK8sObjectNotFound(xerrors.Errorf("Secret %s is not found", nn), gvk, nn)
+			}
+			for key, val := range secret.Data {
+				vars[source.Prefix+key] = string(val)
+			}
+		}
+	}
+	return vars, nil
+}
+
+func isOptional(optional *bool) bool {
+	return optional != nil && *optional
+}
@@ -0,0 +1,90 @@
+//go:build !integration_test
+// +build !integration_test
+
+package models
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dreamkastv1alpha1 "github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
+	myerrors "github.com/cloudnativedaysjp/reviewapp-operator/errors"
+)
+
+func TestNewVariablesFrom(t *testing.T) {
+	optional := true
+	configMaps := map[string]corev1.ConfigMap{
+		"test-cm": {Data: map[string]string{"HOST": "example.com", "PORT": "8080"}},
+	}
+	secrets := map[string]corev1.Secret{
+		"test-secret": {Data: map[string][]byte{"PASSWORD": []byte("p@ssw0rd"), "PORT": []byte("18080")}},
+	}
+	newReviewApp := func(sources ...corev1.EnvFromSource) ReviewApp {
+		return ReviewApp{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-ra", Namespace: "test-ns"},
+			Spec:       dreamkastv1alpha1.ReviewAppSpec{VariablesFrom: sources},
+		}
+	}
+	configMapRef := func(name, prefix string, optional *bool) corev1.EnvFromSource {
+		return corev1.EnvFromSource{
+			Prefix:       prefix,
+			ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: name}, Optional: optional},
+		}
+	}
+	secretRef := func(name, prefix string, optional *bool) corev1.EnvFromSource {
+		return corev1.EnvFromSource{
+			Prefix:    prefix,
+			SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: name}, Optional: optional},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		m            ReviewAppOrReviewAppManager
+		want         map[string]string
+		wantNotFound bool
+	}{
+		{
+			name: "ConfigMap and Secret with prefix",
+			m:    newReviewApp(configMapRef("test-cm", "", nil), secretRef("test-secret", "DB_", nil)),
+			want: map[string]string{"HOST": "example.com", "PORT": "8080", "DB_PASSWORD": "p@ssw0rd", "DB_PORT": "18080"},
+		},
+		{
+			name: "later source takes precedence",
+			m:    newReviewApp(configMapRef("test-cm", "", nil), secretRef("test-secret", "", nil)),
+			want: map[string]string{"HOST": "example.com", "PORT": "18080", "PASSWORD": "p@ssw0rd"},
+		},
+		{
+			name: "optional sources not found are ignored",
+			m:    newReviewApp(configMapRef("not-found", "", &optional), secretRef("not-found", "", &optional)),
+			want: map[string]string{},
+		},
+		{
+			name:         "ConfigMap not found",
+			m:            newReviewApp(configMapRef("not-found", "", nil)),
+			wantNotFound: true,
+		},
+		{
+			name:         "Secret not found",
+			m:            newReviewApp(secretRef("not-found", "", nil)),
+			wantNotFound: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := NewVariablesFrom(tt.m, configMaps, secrets)
+			if myerrors.IsNotFound(err) != tt.wantNotFound {
+				t.Errorf("NewVariablesFrom() error = %v, wantNotFound %v", err, tt.wantNotFound)
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("NewVariablesFrom() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
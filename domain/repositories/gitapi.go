@@ -11,4 +11,7 @@ type GitAPI interface {
 	ListOpenPullRequests(ctx context.Context, appRepoTarget models.AppRepoTarget) (models.PullRequests, error)
 	GetPullRequest(ctx context.Context, appRepoTarget models.AppRepoTarget, prNum int) (models.PullRequest, error)
 	CommentToPullRequest(ctx context.Context, pr models.PullRequest, comment string) error
+	ListCommentsOfPullRequest(ctx context.Context, pr models.PullRequest, since string) (models.PullRequestComments, error)
+	HasWritePermission(ctx context.Context, pr models.PullRequest, username string) (bool, error)
+	ReactToComment(ctx context.Context, pr models.PullRequest, commentID int64, reaction string) error
 }
@@ -11,5 +11,7 @@ type GitCommand interface {
 	ForceClone(context.Context, models.InfraRepoTarget) (models.InfraRepoLocalDir, error)
 	CreateFiles(context.Context, models.InfraRepoLocalDir, ...models.File) error
 	DeleteFiles(context.Context, models.InfraRepoLocalDir, ...models.File) error
+	GlobFiles(ctx context.Context, gp models.InfraRepoLocalDir, pattern string) ([]string, error)
+	Diff(ctx context.Context, gp models.InfraRepoLocalDir) (string, error)
 	CommitAndPush(ctx context.Context, gp models.InfraRepoLocalDir, message string) (*models.InfraRepoLocalDir, error)
 }
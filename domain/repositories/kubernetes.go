@@ -4,6 +4,7 @@ import (
 	"context"
 
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 
 	"github.com/cloudnativedaysjp/reviewapp-operator/domain/models"
 )
@@ -11,9 +12,12 @@ import (
 type KubernetesRepository interface {
 	GetApplicationTemplate(ctx context.Context, m models.ReviewAppOrReviewAppManager) (models.ApplicationTemplate, error)
 	GetArgoCDAppFromReviewAppStatus(ctx context.Context, raStatus models.ReviewAppStatus) (models.Application, error)
+	GetJob(ctx context.Context, namespace, name string) (*batchv1.Job, error)
+	GetJobLogs(ctx context.Context, job *batchv1.Job, tailLines, limitBytes int64) (string, error)
 	GetLatestJobFromLabel(ctx context.Context, namespace, labelKey, labelValue string) (*batchv1.Job, error)
 	CreateJob(ctx context.Context, job *batchv1.Job) error
 	GetPreStopJobTemplate(ctx context.Context, ra models.ReviewApp) (models.JobTemplate, error)
+	GetJobTemplate(ctx context.Context, namespace, name string) (models.JobTemplate, error)
 	GetManifestsTemplate(ctx context.Context, m models.ReviewAppOrReviewAppManager) ([]models.ManifestsTemplate, error)
 	GetReviewApp(ctx context.Context, namespace, name string) (models.ReviewApp, error)
 	ApplyReviewAppWithOwnerRef(ctx context.Context, ra models.ReviewApp, owner models.ReviewAppManager) error
@@ -24,4 +28,10 @@ type KubernetesRepository interface {
 	GetReviewAppManager(ctx context.Context, namespace, name string) (models.ReviewAppManager, error)
 	UpdateReviewAppManagerStatus(ctx context.Context, ram models.ReviewAppManager) error
 	GetSecretValue(ctx context.Context, namespace string, m models.AppOrInfraRepoTarget) (string, error)
+	GetVariablesFrom(ctx context.Context, m models.ReviewAppOrReviewAppManager) (map[string]string, error)
+	GetSecretValuesFrom(ctx context.Context, m models.ReviewAppOrReviewAppManager) ([]string, error)
+	GetConfigMapData(ctx context.Context, namespace, name string) (map[string]string, error)
+	GetNamespace(ctx context.Context, name string) (*corev1.Namespace, error)
+	ListReviewApps(ctx context.Context, namespace string) ([]models.ReviewApp, error)
+	ListReviewAppsByIndex(ctx context.Context, field, value string) ([]models.ReviewApp, error)
 }
@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/cloudnativedaysjp/reviewapp-operator/domain/models"
+)
+
+type ManifestsValidator interface {
+	// Validate validates manifests under dirpath in local dir of infra repo
+	Validate(ctx context.Context, l models.InfraRepoLocalDir, dirpath string) error
+}
@@ -39,11 +39,21 @@ func (s PullRequestService) Get(ctx context.Context, ra models.ReviewApp, cred m
 		}
 		// if dont need resync, return values from ReviewApp Object
 		if !t.Before(now, pullRequestResyncPeriod) {
-			return models.NewPullRequest(
-				appRepoTarget.Organization, appRepoTarget.Repository, raStatus.Sync.SyncedPullRequest.Branch,
-				ra.PrNum(), raStatus.Sync.SyncedPullRequest.LatestCommitHash,
-				raStatus.Sync.SyncedPullRequest.Title, raStatus.Sync.SyncedPullRequest.Labels,
-			), raStatus, nil
+			syncedPr := raStatus.Sync.SyncedPullRequest
+			// description of PR is not cached in status
+			return models.PullRequest{
+				Organization:     appRepoTarget.Organization,
+				Repository:       appRepoTarget.Repository,
+				Branch:           syncedPr.Branch,
+				BaseBranch:       syncedPr.BaseBranch,
+				Number:           ra.PrNum(),
+				LatestCommitHash: syncedPr.LatestCommitHash,
+				Title:            syncedPr.Title,
+				Labels:           syncedPr.Labels,
+				Author:           syncedPr.Author,
+				URL:              syncedPr.URL,
+				CreatedAt:        syncedPr.CreatedAt,
+			}, raStatus, nil
 		}
 	}
 	// otherwise, get from GitAPI repository & update timestamp
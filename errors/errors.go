@@ -61,3 +61,46 @@ func IsKeyMissing(err error) bool {
 		return false
 	}
 }
+
+/* TemplatingFailed */
+
+type TemplatingFailed struct {
+	Err               error
+	TemplateKind      string
+	TemplateName      string
+	TemplateNamespace string
+	Key               string
+	Line              int
+}
+
+func NewTemplatingFailed(err error, kind string, nn types.NamespacedName, key string, line int) TemplatingFailed {
+	return TemplatingFailed{
+		Err:               err,
+		TemplateKind:      kind,
+		TemplateName:      nn.Name,
+		TemplateNamespace: nn.Namespace,
+		Key:               key,
+		Line:              line,
+	}
+}
+
+func (e TemplatingFailed) Error() string {
+	location := e.Key
+	if e.Line > 0 {
+		location = fmt.Sprintf("%s (line %d)", e.Key, e.Line)
+	}
+	return fmt.Sprintf("failed to template %s %s/%s: %s: %v", e.TemplateKind, e.TemplateNamespace, e.TemplateName, location, e.Err)
+}
+
+func (e TemplatingFailed) Unwrap() error {
+	return e.Err
+}
+
+func IsTemplatingFailed(err error) bool {
+	switch err.(type) {
+	case TemplatingFailed:
+		return true
+	default:
+		return false
+	}
+}
@@ -34,8 +34,13 @@ type Git struct {
 
 // TODO: this impl only support https (ssh is not implemented yet)
 func NewGit(l logr.Logger, e exec.Interface) (*Git, error) {
+	return NewGitWithBaseDir(l, e, BaseDir)
+}
+
+// NewGitWithBaseDir returns Git that clones repositories under basedir.
+// It is used to avoid conflicts with other Git that clones the same repository concurrently.
+func NewGitWithBaseDir(l logr.Logger, e exec.Interface, basedir string) (*Git, error) {
 	// create basedir
-	basedir := BaseDir
 	if err := os.MkdirAll(basedir, 0755); err != nil {
 		return nil, xerrors.Errorf("%w", err)
 	}
@@ -110,6 +115,36 @@ func (g *Git) DeleteFiles(ctx context.Context, gp models.InfraRepoLocalDir, file
 	return nil
 }
 
+// GlobFiles returns paths matching the pattern relative to the root of the repository
+func (g *Git) GlobFiles(ctx context.Context, gp models.InfraRepoLocalDir, pattern string) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(gp.BaseDir(), pattern))
+	if err != nil {
+		return nil, xerrors.Errorf("%w", err)
+	}
+	var result []string
+	for _, m := range matches {
+		rel, err := filepath.Rel(gp.BaseDir(), m)
+		if err != nil {
+			return nil, xerrors.Errorf("%w", err)
+		}
+		result = append(result, rel)
+	}
+	return result, nil
+}
+
+// Diff stages all changes and returns them as unified diff against HEAD
+func (g *Git) Diff(ctx context.Context, gp models.InfraRepoLocalDir) (string, error) {
+	_, stderr, err := g.runCommand(ctx, gp.BaseDir(), "git", "add", "-A")
+	if err != nil {
+		return "", xerrors.Errorf(`Error: %v`, stderr.String())
+	}
+	stdout, stderr, err := g.runCommand(ctx, gp.BaseDir(), "git", "diff", "--cached", "--no-color")
+	if err != nil {
+		return "", xerrors.Errorf(`Error: %v`, stderr.String())
+	}
+	return stdout.String(), nil
+}
+
 func (g *Git) CommitAndPush(ctx context.Context, gp models.InfraRepoLocalDir, message string) (*models.InfraRepoLocalDir, error) {
 	// stage に更新ファイルがない場合早期リターン
 	stdout, stderr, err := g.runCommand(ctx, gp.BaseDir(), "git", "status", "-s")
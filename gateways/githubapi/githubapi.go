@@ -2,6 +2,7 @@ package githubapi
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/google/go-github/v39/github"
@@ -11,8 +12,6 @@ import (
 	"github.com/cloudnativedaysjp/reviewapp-operator/domain/models"
 )
 
-const CandidateLabelName = "candidate-template"
-
 type GitHub struct {
 	logger logr.Logger
 
@@ -57,7 +56,7 @@ func (g *GitHub) ListOpenPullRequests(ctx context.Context, appRepoTarget models.
 		for _, l := range pr.Labels {
 			labels = append(labels, *l.Name)
 		}
-		result = append(result, models.NewPullRequest(appRepoTarget.Organization, appRepoTarget.Repository, pr.Head.GetRef(), pr.GetNumber(), pr.Head.GetSHA(), pr.GetTitle(), labels))
+		result = append(result, newPullRequest(appRepoTarget, pr, labels))
 	}
 	return result, nil
 }
@@ -74,7 +73,24 @@ func (g *GitHub) GetPullRequest(ctx context.Context, appRepoTarget models.AppRep
 	for _, l := range pr.Labels {
 		labels = append(labels, *l.Name)
 	}
-	return models.NewPullRequest(appRepoTarget.Organization, appRepoTarget.Repository, pr.Head.GetRef(), prNum, pr.Head.GetSHA(), pr.GetTitle(), labels), nil
+	return newPullRequest(appRepoTarget, pr, labels), nil
+}
+
+func newPullRequest(appRepoTarget models.AppRepoTarget, pr *github.PullRequest, labels []string) models.PullRequest {
+	return models.PullRequest{
+		Organization:     appRepoTarget.Organization,
+		Repository:       appRepoTarget.Repository,
+		Branch:           pr.Head.GetRef(),
+		BaseBranch:       pr.Base.GetRef(),
+		Number:           pr.GetNumber(),
+		LatestCommitHash: pr.Head.GetSHA(),
+		Title:            pr.GetTitle(),
+		Labels:           labels,
+		Author:           pr.GetUser().GetLogin(),
+		URL:              pr.GetHTMLURL(),
+		CreatedAt:        pr.GetCreatedAt().UTC().Format(time.RFC3339),
+		Body:             pr.GetBody(),
+	}
 }
 
 func (g *GitHub) CommentToPullRequest(ctx context.Context, pr models.PullRequest, comment string) error {
@@ -96,6 +112,70 @@ func (g *GitHub) CommentToPullRequest(ctx context.Context, pr models.PullRequest
 	return nil
 }
 
+func (g *GitHub) ListCommentsOfPullRequest(ctx context.Context, pr models.PullRequest, since string) (models.PullRequestComments, error) {
+	if !g.haveClient(ctx) {
+		return nil, xerrors.Errorf("GitHub have no client")
+	}
+	opts := &github.IssueListCommentsOptions{
+		Sort:        github.String("created"),
+		Direction:   github.String("asc"),
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	if since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return nil, xerrors.Errorf("%w", err)
+		}
+		opts.Since = &t
+	}
+	var result models.PullRequestComments
+	for {
+		comments, resp, err := g.client.Issues.ListComments(ctx, pr.Organization, pr.Repository, pr.Number, opts)
+		if err != nil {
+			return nil, xerrors.Errorf("%w", err)
+		}
+		for _, c := range comments {
+			result = append(result, models.PullRequestComment{
+				ID:        c.GetID(),
+				Username:  c.GetUser().GetLogin(),
+				Body:      c.GetBody(),
+				CreatedAt: c.GetCreatedAt().UTC().Format(time.RFC3339),
+			})
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return result, nil
+}
+
+func (g *GitHub) HasWritePermission(ctx context.Context, pr models.PullRequest, username string) (bool, error) {
+	if !g.haveClient(ctx) {
+		return false, xerrors.Errorf("GitHub have no client")
+	}
+	p, _, err := g.client.Repositories.GetPermissionLevel(ctx, pr.Organization, pr.Repository, username)
+	if err != nil {
+		return false, xerrors.Errorf("%w", err)
+	}
+	switch p.GetPermission() {
+	case "admin", "write":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+func (g *GitHub) ReactToComment(ctx context.Context, pr models.PullRequest, commentID int64, reaction string) error {
+	if !g.haveClient(ctx) {
+		return xerrors.Errorf("GitHub have no client")
+	}
+	if _, _, err := g.client.Reactions.CreateIssueCommentReaction(ctx, pr.Organization, pr.Repository, commentID, reaction); err != nil {
+		return xerrors.Errorf("%w", err)
+	}
+	return nil
+}
+
 func (g *GitHub) haveClient(ctx context.Context) bool {
 	if g.client != nil {
 		if _, _, err := g.client.Users.Get(ctx, g.username); err == nil {
@@ -2,15 +2,24 @@ package kubernetes
 
 import (
 	"github.com/go-logr/logr"
+	"golang.org/x/xerrors"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type Client struct {
 	client.Client
 
+	// coreV1 is used to read logs of Pods, which cannot be read by controller-runtime's client
+	coreV1 corev1client.CoreV1Interface
 	logger logr.Logger
 }
 
-func NewClient(l logr.Logger, c client.Client) *Client {
-	return &Client{c, l}
+func NewClient(l logr.Logger, c client.Client, cfg *rest.Config) (*Client, error) {
+	coreV1, err := corev1client.NewForConfig(cfg)
+	if err != nil {
+		return nil, xerrors.Errorf("Error to initialize CoreV1 client: %w", err)
+	}
+	return &Client{c, coreV1, l}, nil
 }
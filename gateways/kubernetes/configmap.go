@@ -0,0 +1,28 @@
+package kubernetes
+
+import (
+	"context"
+	"reflect"
+
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	myerrors "github.com/cloudnativedaysjp/reviewapp-operator/errors"
+)
+
+func (c Client) GetConfigMapData(ctx context.Context, namespace, name string) (map[string]string, error) {
+	var cm corev1.ConfigMap
+	gvk := schema.GroupVersionKind{Group: "", Version: "v1", Kind: "ConfigMap"}
+	nn := types.NamespacedName{Namespace: namespace, Name: name}
+	if err := c.Get(ctx, nn, &cm); err != nil {
+		wrapedErr := xerrors.Errorf("Error to Get %s: %w", reflect.TypeOf(cm), err)
+		if apierrors.IsNotFound(err) {
+			return nil, myerrors.NewK8sObjectNotFound(wrapedErr, gvk, nn)
+		}
+		return nil, wrapedErr
+	}
+	return cm.Data, nil
+}
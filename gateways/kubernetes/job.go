@@ -2,15 +2,44 @@ package kubernetes
 
 import (
 	"context"
+	"fmt"
+	"reflect"
 	"sort"
+	"strings"
 
 	"golang.org/x/xerrors"
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	myerrors "github.com/cloudnativedaysjp/reviewapp-operator/errors"
 )
 
+var jobGVK = schema.GroupVersionKind{
+	Group:   "batch",
+	Version: "v1",
+	Kind:    "Job",
+}
+
+func (c Client) GetJob(ctx context.Context, namespace, name string) (*batchv1.Job, error) {
+	var j batchv1.Job
+	nn := types.NamespacedName{Namespace: namespace, Name: name}
+	if err := c.Get(ctx, nn, &j); err != nil {
+		wrapedErr := xerrors.Errorf("Error to Get %s: %w", reflect.TypeOf(j), err)
+		if apierrors.IsNotFound(err) {
+			return nil, myerrors.NewK8sObjectNotFound(wrapedErr, jobGVK, nn)
+		}
+		return nil, wrapedErr
+	}
+	j.SetGroupVersionKind(jobGVK)
+	return &j, nil
+}
+
 func (c Client) GetLatestJobFromLabel(ctx context.Context, namespace, labelKey, labelValue string) (*batchv1.Job, error) {
 	var jList batchv1.JobList
 	if err := c.List(ctx, &jList, &client.ListOptions{
@@ -24,11 +53,7 @@ func (c Client) GetLatestJobFromLabel(ctx context.Context, namespace, labelKey,
 		return jList.Items[i].CreationTimestamp.Before(&jList.Items[j].CreationTimestamp)
 	})
 	j := &jList.Items[0]
-	j.SetGroupVersionKind(schema.GroupVersionKind{
-		Group:   "batch",
-		Version: "v1",
-		Kind:    "Job",
-	})
+	j.SetGroupVersionKind(jobGVK)
 	return j, nil
 }
 
@@ -38,3 +63,53 @@ func (c Client) CreateJob(ctx context.Context, job *batchv1.Job) error {
 	}
 	return nil
 }
+
+// GetJobLogs returns logs of containers of the latest Pod created by the Job.
+// Each container's logs are limited to the last tailLines lines and limitBytes bytes.
+func (c Client) GetJobLogs(ctx context.Context, job *batchv1.Job, tailLines, limitBytes int64) (string, error) {
+	if job.Spec.Selector == nil {
+		return "", nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(job.Spec.Selector)
+	if err != nil {
+		return "", xerrors.Errorf("Error to parse selector of Job: %w", err)
+	}
+	// Pods are listed without cache, because caching all Pods of cluster is expensive only to read logs
+	pList, err := c.coreV1.Pods(job.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		return "", xerrors.Errorf("Error to list Pods of Job: %w", err)
+	}
+	if len(pList.Items) == 0 {
+		return "", nil
+	}
+	sort.Slice(pList.Items, func(i, j int) bool {
+		return pList.Items[i].CreationTimestamp.Before(&pList.Items[j].CreationTimestamp)
+	})
+	pod := pList.Items[len(pList.Items)-1]
+
+	var b strings.Builder
+	for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		logs, err := c.coreV1.Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+			Container:  container.Name,
+			TailLines:  &tailLines,
+			LimitBytes: &limitBytes,
+		}).DoRaw(ctx)
+		if err != nil {
+			// containers which have not started have no logs
+			if apierrors.IsBadRequest(err) {
+				continue
+			}
+			return "", xerrors.Errorf("Error to get logs of Pod %s/%s: %w", pod.Namespace, pod.Name, err)
+		}
+		if len(logs) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "==> %s <==\n%s", container.Name, logs)
+		if !strings.HasSuffix(string(logs), "\n") {
+			b.WriteString("\n")
+		}
+	}
+	return b.String(), nil
+}
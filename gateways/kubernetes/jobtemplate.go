@@ -14,10 +14,10 @@ import (
 )
 
 func (c Client) GetPreStopJobTemplate(ctx context.Context, ra models.ReviewApp) (models.JobTemplate, error) {
-	return c.getJobTemplate(ctx, ra.Spec.PreStopJob.Namespace, ra.Spec.PreStopJob.Name)
+	return c.GetJobTemplate(ctx, ra.Spec.PreStopJob.Namespace, ra.Spec.PreStopJob.Name)
 }
 
-func (c Client) getJobTemplate(ctx context.Context, namespace, name string) (models.JobTemplate, error) {
+func (c Client) GetJobTemplate(ctx context.Context, namespace, name string) (models.JobTemplate, error) {
 	var jt dreamkastv1alpha1.JobTemplate
 	nn := types.NamespacedName{Name: name, Namespace: namespace}
 	if err := c.Get(ctx, nn, &jt); err != nil {
@@ -0,0 +1,34 @@
+package kubernetes
+
+import (
+	"context"
+	"reflect"
+
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	myerrors "github.com/cloudnativedaysjp/reviewapp-operator/errors"
+)
+
+var namespaceGVK = schema.GroupVersionKind{
+	Group:   "",
+	Version: "v1",
+	Kind:    "Namespace",
+}
+
+func (c Client) GetNamespace(ctx context.Context, name string) (*corev1.Namespace, error) {
+	var ns corev1.Namespace
+	nn := types.NamespacedName{Name: name}
+	if err := c.Get(ctx, nn, &ns); err != nil {
+		wrapedErr := xerrors.Errorf("Error to Get %s: %w", reflect.TypeOf(ns), err)
+		if apierrors.IsNotFound(err) {
+			return nil, myerrors.NewK8sObjectNotFound(wrapedErr, namespaceGVK, nn)
+		}
+		return nil, wrapedErr
+	}
+	ns.SetGroupVersionKind(namespaceGVK)
+	return &ns, nil
+}
@@ -35,6 +35,20 @@ func (c Client) GetReviewApp(ctx context.Context, namespace, name string) (model
 	return models.NewReviewApp(&ra), nil
 }
 
+func (c Client) ListReviewApps(ctx context.Context, namespace string) ([]models.ReviewApp, error) {
+	var raList dreamkastv1alpha1.ReviewAppList
+	if err := c.List(ctx, &raList, &client.ListOptions{Namespace: namespace}); err != nil {
+		return nil, xerrors.Errorf("Error to List %s: %w", reflect.TypeOf(raList), err)
+	}
+	var result []models.ReviewApp
+	for _, ra := range raList.Items {
+		ra := ra
+		ra.SetGroupVersionKind(ra.GVK())
+		result = append(result, models.NewReviewApp(&ra))
+	}
+	return result, nil
+}
+
 func (c Client) ApplyReviewAppWithOwnerRef(ctx context.Context, ra models.ReviewApp, owner models.ReviewAppManager) error {
 	raApplied := &dreamkastv1alpha1.ReviewApp{
 		ObjectMeta: metav1.ObjectMeta{
@@ -140,3 +154,19 @@ func (c Client) RemoveFinalizersFromReviewApp(ctx context.Context, ra models.Rev
 	}
 	return nil
 }
+
+// ListReviewAppsByIndex lists ReviewApps in all namespaces whose indexed field has the value.
+// The index must be registered to the cache of manager in advance.
+func (c Client) ListReviewAppsByIndex(ctx context.Context, field, value string) ([]models.ReviewApp, error) {
+	var raList dreamkastv1alpha1.ReviewAppList
+	if err := c.List(ctx, &raList, client.MatchingFields{field: value}); err != nil {
+		return nil, xerrors.Errorf("Error to List %s: %w", reflect.TypeOf(raList), err)
+	}
+	var result []models.ReviewApp
+	for _, ra := range raList.Items {
+		ra := ra
+		ra.SetGroupVersionKind(ra.GVK())
+		result = append(result, models.NewReviewApp(&ra))
+	}
+	return result, nil
+}
@@ -0,0 +1,95 @@
+package kubernetes
+
+import (
+	"context"
+	"reflect"
+
+	"golang.org/x/xerrors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/cloudnativedaysjp/reviewapp-operator/domain/models"
+	myerrors "github.com/cloudnativedaysjp/reviewapp-operator/errors"
+)
+
+// GetVariablesFrom gets ConfigMaps & Secrets specified by spec.variablesFrom and resolves variables of templates from them
+func (c Client) GetVariablesFrom(ctx context.Context, m models.ReviewAppOrReviewAppManager) (map[string]string, error) {
+	namespace := m.NamespaceName().Namespace
+	configMaps := make(map[string]corev1.ConfigMap)
+	secrets := make(map[string]corev1.Secret)
+	for _, source := range m.VariablesFrom() {
+		if ref := source.ConfigMapRef; ref != nil {
+			cm, err := c.getConfigMapOfSource(ctx, namespace, ref)
+			if err != nil {
+				return nil, err
+			}
+			configMaps[ref.Name] = *cm
+		}
+		if ref := source.SecretRef; ref != nil {
+			secret, err := c.getSecretOfSource(ctx, namespace, ref)
+			if err != nil {
+				return nil, err
+			}
+			secrets[ref.Name] = *secret
+		}
+	}
+	return models.NewVariablesFrom(m, configMaps, secrets)
+}
+
+// GetSecretValuesFrom returns values of Secrets specified by spec.variablesFrom to redact them from logs
+func (c Client) GetSecretValuesFrom(ctx context.Context, m models.ReviewAppOrReviewAppManager) ([]string, error) {
+	namespace := m.NamespaceName().Namespace
+	var values []string
+	for _, source := range m.VariablesFrom() {
+		ref := source.SecretRef
+		if ref == nil {
+			continue
+		}
+		secret, err := c.getSecretOfSource(ctx, namespace, ref)
+		if err != nil {
+			return nil, err
+		}
+		for _, val := range secret.Data {
+			values = append(values, string(val))
+		}
+	}
+	return values, nil
+}
+
+// getConfigMapOfSource returns empty ConfigMap if the optional ConfigMap is not found
+func (c Client) getConfigMapOfSource(ctx context.Context, namespace string, ref *corev1.ConfigMapEnvSource) (*corev1.ConfigMap, error) {
+	var cm corev1.ConfigMap
+	gvk := schema.GroupVersionKind{Group: "", Version: "v1", Kind: "ConfigMap"}
+	nn := types.NamespacedName{Namespace: namespace, Name: ref.Name}
+	if err := c.Get(ctx, nn, &cm); err != nil {
+		wrapedErr := xerrors.Errorf("Error to Get %s: %w", reflect.TypeOf(cm), err)
+		if apierrors.IsNotFound(err) {
+			if ref.Optional != nil && *ref.Optional {
+				return &corev1.ConfigMap{}, nil
+			}
+			return nil, myerrors.NewK8sObjectNotFound(wrapedErr, gvk, nn)
+		}
+		return nil, wrapedErr
+	}
+	return &cm, nil
+}
+
+// getSecretOfSource returns empty Secret if the optional Secret is not found
+func (c Client) getSecretOfSource(ctx context.Context, namespace string, ref *corev1.SecretEnvSource) (*corev1.Secret, error) {
+	var secret corev1.Secret
+	gvk := schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Secret"}
+	nn := types.NamespacedName{Namespace: namespace, Name: ref.Name}
+	if err := c.Get(ctx, nn, &secret); err != nil {
+		wrapedErr := xerrors.Errorf("Error to Get %s: %w", reflect.TypeOf(secret), err)
+		if apierrors.IsNotFound(err) {
+			if ref.Optional != nil && *ref.Optional {
+				return &corev1.Secret{}, nil
+			}
+			return nil, myerrors.NewK8sObjectNotFound(wrapedErr, gvk, nn)
+		}
+		return nil, wrapedErr
+	}
+	return &secret, nil
+}
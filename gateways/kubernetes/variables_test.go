@@ -0,0 +1,174 @@
+//go:build !integration_test
+// +build !integration_test
+
+package kubernetes
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dreamkastv1alpha1 "github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
+	"github.com/cloudnativedaysjp/reviewapp-operator/domain/models"
+	myerrors "github.com/cloudnativedaysjp/reviewapp-operator/errors"
+)
+
+func TestClient_GetVariablesFrom(t *testing.T) {
+	testNamespace := "test-ns"
+	optional := true
+	testConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cm", Namespace: testNamespace},
+		Data:       map[string]string{"HOST": "example.com", "PORT": "8080"},
+	}
+	testSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-secret", Namespace: testNamespace},
+		Data:       map[string][]byte{"PASSWORD": []byte("p@ssw0rd"), "PORT": []byte("18080")},
+	}
+	newReviewApp := func(sources ...corev1.EnvFromSource) models.ReviewApp {
+		return models.ReviewApp{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-ra", Namespace: testNamespace},
+			Spec:       dreamkastv1alpha1.ReviewAppSpec{VariablesFrom: sources},
+		}
+	}
+	configMapRef := func(name, prefix string, optional *bool) corev1.EnvFromSource {
+		return corev1.EnvFromSource{
+			Prefix:       prefix,
+			ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: name}, Optional: optional},
+		}
+	}
+	secretRef := func(name, prefix string, optional *bool) corev1.EnvFromSource {
+		return corev1.EnvFromSource{
+			Prefix:    prefix,
+			SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: name}, Optional: optional},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		m            models.ReviewAppOrReviewAppManager
+		want         map[string]string
+		wantErr      bool
+		wantNotFound bool
+	}{
+		{
+			name: "ConfigMap and Secret with prefix",
+			m:    newReviewApp(configMapRef("test-cm", "", nil), secretRef("test-secret", "DB_", nil)),
+			want: map[string]string{
+				"HOST":        "example.com",
+				"PORT":        "8080",
+				"DB_PASSWORD": "p@ssw0rd",
+				"DB_PORT":     "18080",
+			},
+		},
+		{
+			name: "value of the last source takes precedence",
+			m:    newReviewApp(configMapRef("test-cm", "", nil), secretRef("test-secret", "", nil)),
+			want: map[string]string{
+				"HOST":     "example.com",
+				"PORT":     "18080",
+				"PASSWORD": "p@ssw0rd",
+			},
+		},
+		{
+			name: "optional source is not found",
+			m:    newReviewApp(configMapRef("missing-cm", "", &optional), secretRef("missing-secret", "", &optional)),
+			want: map[string]string{},
+		},
+		{
+			name:         "ConfigMap is not found",
+			m:            newReviewApp(configMapRef("missing-cm", "", nil)),
+			wantErr:      true,
+			wantNotFound: true,
+		},
+		{
+			name:         "Secret is not found",
+			m:            newReviewApp(secretRef("missing-secret", "", nil)),
+			wantErr:      true,
+			wantNotFound: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c := Client{Client: fake.NewClientBuilder().WithObjects(testConfigMap, testSecret).Build()}
+			got, err := c.GetVariablesFrom(context.Background(), tt.m)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Client.GetVariablesFrom() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if myerrors.IsNotFound(err) != tt.wantNotFound {
+				t.Errorf("Client.GetVariablesFrom() error = %v, wantNotFound %v", err, tt.wantNotFound)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("Client.GetVariablesFrom() is unexpected:\n%v", diff)
+			}
+		})
+	}
+}
+
+func TestClient_GetSecretValuesFrom(t *testing.T) {
+	testNamespace := "test-ns"
+	optional := true
+	testSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-secret", Namespace: testNamespace},
+		Data:       map[string][]byte{"PASSWORD": []byte("p@ssw0rd"), "TOKEN": []byte("t0ken")},
+	}
+	newReviewApp := func(sources ...corev1.EnvFromSource) models.ReviewApp {
+		return models.ReviewApp{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-ra", Namespace: testNamespace},
+			Spec:       dreamkastv1alpha1.ReviewAppSpec{VariablesFrom: sources},
+		}
+	}
+	secretRef := func(name string, optional *bool) corev1.EnvFromSource {
+		return corev1.EnvFromSource{
+			SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: name}, Optional: optional},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		m       models.ReviewAppOrReviewAppManager
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "values of Secret",
+			m: newReviewApp(secretRef("test-secret", nil), corev1.EnvFromSource{
+				ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "test-cm"}},
+			}),
+			want: []string{"p@ssw0rd", "t0ken"},
+		},
+		{
+			name: "optional Secret is not found",
+			m:    newReviewApp(secretRef("missing-secret", &optional)),
+			want: nil,
+		},
+		{
+			name:    "Secret is not found",
+			m:       newReviewApp(secretRef("missing-secret", nil)),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c := Client{Client: fake.NewClientBuilder().WithObjects(testSecret).Build()}
+			got, err := c.GetSecretValuesFrom(context.Background(), tt.m)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Client.GetSecretValuesFrom() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			sort.Strings(got)
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("Client.GetSecretValuesFrom() is unexpected:\n%v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package kustomize
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/xerrors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/kustomize/api/konfig"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/yaml"
+
+	"github.com/cloudnativedaysjp/reviewapp-operator/domain/models"
+)
+
+type Kustomize struct {
+	logger     logr.Logger
+	scheme     *runtime.Scheme
+	serializer *json.Serializer
+}
+
+func NewKustomize(l logr.Logger) *Kustomize {
+	scheme := clientgoscheme.Scheme
+	serializer := json.NewSerializerWithOptions(json.DefaultMetaFactory, scheme, scheme, json.SerializerOptions{Yaml: true, Strict: true})
+	return &Kustomize{logger: l, scheme: scheme, serializer: serializer}
+}
+
+// Validate runs kustomize build in dirpath and validates built resources by the schema of built-in kinds.
+// Resources of other kinds (e.g. CRDs) are not validated.
+// If dirpath has no kustomization file, resources in each YAML file in dirpath are validated instead.
+func (k *Kustomize) Validate(ctx context.Context, l models.InfraRepoLocalDir, dirpath string) error {
+	dir := filepath.Join(l.BaseDir(), dirpath)
+	if !hasKustomization(dir) {
+		k.logger.V(1).Info("validate each file because kustomization file does not exist", "dirpath", dirpath)
+		return k.validateFiles(dir, dirpath)
+	}
+
+	// kustomize build
+	resMap, err := krusty.MakeKustomizer(krusty.MakeDefaultOptions()).Run(filesys.MakeFsOnDisk(), dir)
+	if err != nil {
+		return xerrors.Errorf("kustomize build %s: %w", dirpath, err)
+	}
+
+	// validate by schema
+	for _, res := range resMap.Resources() {
+		gvk := res.GetGvk()
+		if !k.scheme.Recognizes(schema.GroupVersionKind{Group: gvk.Group, Version: gvk.Version, Kind: gvk.Kind}) {
+			continue
+		}
+		out, err := res.AsYAML()
+		if err != nil {
+			return xerrors.Errorf("%w", err)
+		}
+		if _, _, err := k.serializer.Decode(out, nil, nil); err != nil {
+			return xerrors.Errorf("%s %s is invalid: %w", gvk.Kind, res.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// validateFiles validates resources in each YAML file in dir by the schema of built-in kinds.
+// Documents that are not resources of built-in kinds (e.g. values.yaml of Helm chart) are not validated.
+func (k *Kustomize) validateFiles(dir, dirpath string) error {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil
+	}
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return xerrors.Errorf("%w", err)
+		}
+		if d.IsDir() || (filepath.Ext(path) != ".yaml" && filepath.Ext(path) != ".yml") {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return xerrors.Errorf("%w", err)
+		}
+		rel = filepath.Join(dirpath, rel)
+		f, err := os.Open(path)
+		if err != nil {
+			return xerrors.Errorf("%w", err)
+		}
+		defer f.Close()
+		reader := utilyaml.NewYAMLReader(bufio.NewReader(f))
+		for {
+			doc, err := reader.Read()
+			if err == io.EOF {
+				return nil
+			} else if err != nil {
+				return xerrors.Errorf("%s: %w", rel, err)
+			}
+			var typeMeta metav1.TypeMeta
+			if err := yaml.Unmarshal(doc, &typeMeta); err != nil {
+				return xerrors.Errorf("%s is invalid YAML: %w", rel, err)
+			}
+			gvk := schema.FromAPIVersionAndKind(typeMeta.APIVersion, typeMeta.Kind)
+			if typeMeta.Kind == "" || !k.scheme.Recognizes(gvk) {
+				continue
+			}
+			if _, _, err := k.serializer.Decode(doc, nil, nil); err != nil {
+				return xerrors.Errorf("%s: %s is invalid: %w", rel, gvk.Kind, err)
+			}
+		}
+	})
+}
+
+func hasKustomization(dir string) bool {
+	for _, filename := range konfig.RecognizedKustomizationFileNames() {
+		if _, err := os.Stat(filepath.Join(dir, filename)); err == nil {
+			return true
+		}
+	}
+	return false
+}
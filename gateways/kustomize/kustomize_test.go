@@ -0,0 +1,99 @@
+//go:build !integration_test
+// +build !integration_test
+
+package kustomize
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/glogr"
+
+	"github.com/cloudnativedaysjp/reviewapp-operator/domain/models"
+)
+
+func TestKustomize_Validate(t *testing.T) {
+	validDeployment := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: test
+spec:
+  replicas: 1
+`
+	invalidDeployment := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: test
+spec:
+  replicas: "one"
+`
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantErr bool
+	}{
+		{
+			name: "kustomization with valid resources",
+			files: map[string]string{
+				"kustomization.yaml": "resources:\n- deployment.yaml\n",
+				"deployment.yaml":    validDeployment,
+			},
+		},
+		{
+			name: "kustomization with invalid resources",
+			files: map[string]string{
+				"kustomization.yaml": "resources:\n- deployment.yaml\n",
+				"deployment.yaml":    invalidDeployment,
+			},
+			wantErr: true,
+		},
+		{
+			name: "no kustomization with valid resources and other YAML",
+			files: map[string]string{
+				"deployment.yaml": "---\n" + validDeployment + "---\napiVersion: example.com/v1\nkind: Unknown\nspec: {}\n",
+				"values.yaml":     "replicaCount: 1\n",
+			},
+		},
+		{
+			name: "no kustomization with invalid resources",
+			files: map[string]string{
+				"sub/deployment.yml": validDeployment + "---\n" + invalidDeployment,
+			},
+			wantErr: true,
+		},
+		{
+			name: "no kustomization with invalid YAML",
+			files: map[string]string{
+				"deployment.yaml": "kind: [Deployment\n",
+			},
+			wantErr: true,
+		},
+		{
+			name:  "no directory",
+			files: nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			baseDir := t.TempDir()
+			for filename, content := range tt.files {
+				path := filepath.Join(baseDir, "overlays", filename)
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			k := NewKustomize(glogr.New())
+			err := k.Validate(context.Background(), models.NewInfraRepoLocal(baseDir), "overlays")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Kustomize.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -32,8 +32,10 @@ import (
 	k8smetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	dreamkastv1alpha1 "github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
+	dreamkastv1beta1 "github.com/cloudnativedaysjp/reviewapp-operator/api/v1beta1"
 	"github.com/cloudnativedaysjp/reviewapp-operator/controllers"
 	"github.com/cloudnativedaysjp/reviewapp-operator/utils/metrics"
+	"github.com/cloudnativedaysjp/reviewapp-operator/webhooks"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -48,6 +50,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(dreamkastv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(dreamkastv1beta1.AddToScheme(scheme))
 	utilruntime.Must(argocd_application_v1alpha1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
@@ -56,11 +59,18 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var templateRolloutBatchSize int
+	var templateRolloutInterval time.Duration
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.IntVar(&templateRolloutBatchSize, "template-rollout-batch-size", 0,
+		"Number of ReviewApps re-rendered at once when ApplicationTemplate or ManifestsTemplate is changed. "+
+			"All ReviewApps are re-rendered at once if it is 0.")
+	flag.DurationVar(&templateRolloutInterval, "template-rollout-interval", time.Minute,
+		"Interval between batches of --template-rollout-batch-size.")
 	opts := zap.Options{
 		Development: true,
 		//StacktraceLevel: zapcore.DPanicLevel,
@@ -96,10 +106,29 @@ func main() {
 		Log:      ctrl.Log.WithName("controllers").WithName("ReviewApp"),
 		Scheme:   mgr.GetScheme(),
 		Recorder: mgr.GetEventRecorderFor("reviewapp-controler"),
+
+		TemplateRolloutBatchSize: templateRolloutBatchSize,
+		TemplateRolloutInterval:  templateRolloutInterval,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ReviewApp")
 		os.Exit(1)
 	}
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = (&webhooks.ReviewAppManagerValidator{
+			Log: ctrl.Log.WithName("webhooks").WithName("ReviewAppManager"),
+		}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ReviewAppManager")
+			os.Exit(1)
+		}
+		if err = (&webhooks.TemplateValidator{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Templates")
+			os.Exit(1)
+		}
+		if err = webhooks.SetupConversionWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Conversion")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
 	metrics.Register(k8smetrics.Registry)
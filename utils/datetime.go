@@ -41,3 +41,14 @@ func (m datetime) Before(d datetime, duration time.Duration) bool {
 	b, _ := time.Parse(time.RFC3339, string(d))
 	return a.Before(b.Add(duration))
 }
+
+func (m datetime) Add(duration time.Duration) datetime {
+	a, _ := time.Parse(time.RFC3339, string(m))
+	return datetime(a.Add(duration).Format(time.RFC3339))
+}
+
+func (m datetime) Sub(d datetime) time.Duration {
+	a, _ := time.Parse(time.RFC3339, string(m))
+	b, _ := time.Parse(time.RFC3339, string(d))
+	return a.Sub(b)
+}
@@ -0,0 +1,15 @@
+package webhooks
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	dreamkastv1alpha1 "github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
+)
+
+// SetupConversionWebhookWithManager registers conversion webhook ("/convert") between v1alpha1 (hub & storage version) and v1beta1.
+// ReviewApp is registered explicitly because it has no validating webhook, the other kinds are registered by their validators.
+func SetupConversionWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&dreamkastv1alpha1.ReviewApp{}).
+		Complete()
+}
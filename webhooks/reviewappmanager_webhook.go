@@ -0,0 +1,137 @@
+package webhooks
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/xerrors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	dreamkastv1alpha1 "github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
+	"github.com/cloudnativedaysjp/reviewapp-operator/domain/models"
+	"github.com/cloudnativedaysjp/reviewapp-operator/domain/repositories"
+	myerrors "github.com/cloudnativedaysjp/reviewapp-operator/errors"
+	"github.com/cloudnativedaysjp/reviewapp-operator/wire"
+)
+
+//+kubebuilder:webhook:path=/validate-dreamkast-cloudnativedays-jp-v1alpha1-reviewappmanager,mutating=false,failurePolicy=fail,sideEffects=None,groups=dreamkast.cloudnativedays.jp,resources=reviewappmanagers,verbs=create;update,versions=v1alpha1,name=vreviewappmanager.kb.io,admissionReviewVersions=v1
+
+// ReviewAppManagerValidator validates ReviewAppManager
+type ReviewAppManagerValidator struct {
+	Log logr.Logger
+
+	K8sRepository repositories.KubernetesRepository
+}
+
+var _ admission.CustomValidator = &ReviewAppManagerValidator{}
+
+func (v *ReviewAppManagerValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	var err error
+	v.K8sRepository, err = wire.NewKubernetesRepository(v.Log, mgr.GetClient(), mgr.GetConfig())
+	if err != nil {
+		return err
+	}
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&dreamkastv1alpha1.ReviewAppManager{}).
+		WithValidator(v).
+		Complete()
+}
+
+func (v *ReviewAppManagerValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	return v.validate(ctx, obj, true)
+}
+
+// ValidateUpdate doesn't check that referred templates exist, so that ReviewAppManager can be updated
+// (e.g. to fix the reference) even while its templates are deleted.
+func (v *ReviewAppManagerValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	return v.validate(ctx, newObj, false)
+}
+
+func (v *ReviewAppManagerValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+func (v *ReviewAppManagerValidator) validate(ctx context.Context, obj runtime.Object, checkReferences bool) error {
+	ramCR, ok := obj.(*dreamkastv1alpha1.ReviewAppManager)
+	if !ok {
+		return apierrors.NewBadRequest("expected a ReviewAppManager")
+	}
+	ram := models.ReviewAppManager(*ramCR)
+	errs := ram.Validate()
+
+	if checkReferences {
+		refs, err := v.validateReferences(ctx, ram)
+		if err != nil {
+			return err
+		}
+		errs = append(errs, refs...)
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(ramCR.GVK().GroupKind(), ramCR.Name, errs)
+}
+
+// validateReferences checks that templates referred from ReviewAppManager exist.
+// references including Go template (e.g. "{{.Variables.name}}") are skipped because they are resolved when templating.
+func (v *ReviewAppManagerValidator) validateReferences(ctx context.Context, ram models.ReviewAppManager) (field.ErrorList, error) {
+	var errs field.ErrorList
+	infraConfig := field.NewPath("spec", "infraRepoConfig")
+
+	at := ram.Spec.InfraConfig.ArgoCDApp.Template
+	if !isTemplated(at) {
+		if _, err := v.K8sRepository.GetApplicationTemplate(ctx, ram); err != nil {
+			if !myerrors.IsNotFound(err) {
+				return nil, xerrors.Errorf("%w", err)
+			}
+			errs = append(errs, field.NotFound(infraConfig.Child("argocdApp", "template"), at))
+		}
+	}
+	for i, mt := range ram.Spec.InfraConfig.Manifests.Templates {
+		if isTemplated(mt) {
+			continue
+		}
+		m := ram
+		m.Spec.InfraConfig.Manifests.Templates = []dreamkastv1alpha1.NamespacedName{mt}
+		if _, err := v.K8sRepository.GetManifestsTemplate(ctx, m); err != nil {
+			if !myerrors.IsNotFound(err) {
+				return nil, xerrors.Errorf("%w", err)
+			}
+			errs = append(errs, field.NotFound(infraConfig.Child("manifests", "templates").Index(i), mt))
+		}
+	}
+	if jt := ram.Spec.PreStopJob; jt.Name != "" && !isTemplated(jt) {
+		if _, err := v.K8sRepository.GetJobTemplate(ctx, jt.Namespace, jt.Name); err != nil {
+			if !myerrors.IsNotFound(err) {
+				return nil, xerrors.Errorf("%w", err)
+			}
+			errs = append(errs, field.NotFound(field.NewPath("spec", "preStopJob"), jt))
+		}
+	}
+	for _, hook := range []struct {
+		path *field.Path
+		jobs []dreamkastv1alpha1.HookJob
+	}{
+		{field.NewPath("spec", "preDeployJobs"), ram.Spec.PreDeployJobs},
+		{field.NewPath("spec", "postDeployJobs"), ram.Spec.PostDeployJobs},
+	} {
+		for i, jt := range hook.jobs {
+			if _, err := v.K8sRepository.GetJobTemplate(ctx, jt.Namespace, jt.Name); err != nil {
+				if !myerrors.IsNotFound(err) {
+					return nil, xerrors.Errorf("%w", err)
+				}
+				errs = append(errs, field.NotFound(hook.path.Index(i), models.HookJobKey(jt)))
+			}
+		}
+	}
+	return errs, nil
+}
+
+func isTemplated(nn dreamkastv1alpha1.NamespacedName) bool {
+	return strings.Contains(nn.Namespace, "{{") || strings.Contains(nn.Name, "{{")
+}
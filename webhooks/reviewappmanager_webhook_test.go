@@ -0,0 +1,163 @@
+//go:build !integration_test
+// +build !integration_test
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/go-logr/glogr"
+	"github.com/golang/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	dreamkastv1alpha1 "github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
+	"github.com/cloudnativedaysjp/reviewapp-operator/domain/mock"
+	"github.com/cloudnativedaysjp/reviewapp-operator/domain/models"
+	"github.com/cloudnativedaysjp/reviewapp-operator/domain/repositories"
+	myerrors "github.com/cloudnativedaysjp/reviewapp-operator/errors"
+)
+
+var (
+	testLogger = glogr.NewWithOptions(glogr.Options{LogCaller: glogr.None})
+	testCtx    = context.Background()
+)
+
+func TestReviewAppManagerValidator(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	errNotFound := myerrors.NewK8sObjectNotFound(fmt.Errorf("not found"), schema.GroupVersionKind{}, types.NamespacedName{})
+	testAt := dreamkastv1alpha1.NamespacedName{Namespace: "default", Name: "at"}
+	testMt := dreamkastv1alpha1.NamespacedName{Namespace: "default", Name: "mt"}
+	testJt := dreamkastv1alpha1.NamespacedName{Namespace: "default", Name: "jt"}
+	testRam := &dreamkastv1alpha1.ReviewAppManager{
+		ObjectMeta: metav1.ObjectMeta{Name: "ram", Namespace: "default"},
+		Spec: dreamkastv1alpha1.ReviewAppManagerSpec{
+			InfraConfig: dreamkastv1alpha1.ReviewAppManagerSpecInfraConfig{
+				Manifests: dreamkastv1alpha1.ReviewAppManagerSpecInfraManifests{
+					Templates: []dreamkastv1alpha1.NamespacedName{testMt},
+					Dirpath:   "overlays/pr-{{.AppRepo.PrNumber}}",
+				},
+				ArgoCDApp: dreamkastv1alpha1.ReviewAppManagerSpecInfraArgoCDApp{
+					Template: testAt,
+					Filepath: "apps/pr-{{.AppRepo.PrNumber}}.yaml",
+				},
+			},
+			PreStopJob:     testJt,
+			PostDeployJobs: []dreamkastv1alpha1.HookJob{{Namespace: testJt.Namespace, Name: testJt.Name}},
+		},
+	}
+	testRamTemplatedRefs := testRam.DeepCopy()
+	testRamTemplatedRefs.Spec.InfraConfig.ArgoCDApp.Template.Name = "at-{{.Variables.env}}"
+	testRamTemplatedRefs.Spec.InfraConfig.Manifests.Templates[0].Name = "mt-{{.Variables.env}}"
+	testRamTemplatedRefs.Spec.PreStopJob.Name = "jt-{{.Variables.env}}"
+	testRamTemplatedRefs.Spec.PostDeployJobs = nil
+	testRamInvalid := testRam.DeepCopy()
+	testRamInvalid.Spec.AppTarget.IgnoreTitleExp = "["
+
+	newK8sRepository := func(atErr, mtErr, jtErr error) func() repositories.KubernetesRepository {
+		return func() repositories.KubernetesRepository {
+			m := mock.NewMockKubernetesRepository(mockCtrl)
+			m.EXPECT().GetApplicationTemplate(testCtx, gomock.Any()).
+				Return(models.ApplicationTemplate{}, atErr)
+			m.EXPECT().GetManifestsTemplate(testCtx, gomock.Any()).
+				Return(nil, mtErr)
+			m.EXPECT().GetJobTemplate(testCtx, testJt.Namespace, testJt.Name).
+				Return(models.JobTemplate{}, jtErr).Times(2)
+			return m
+		}
+	}
+	noCall := func() repositories.KubernetesRepository {
+		return mock.NewMockKubernetesRepository(mockCtrl)
+	}
+
+	tests := []struct {
+		name          string
+		update        bool
+		ram           *dreamkastv1alpha1.ReviewAppManager
+		K8sRepository func() repositories.KubernetesRepository
+		wantErr       bool
+	}{
+		{
+			name:          "[create] referred templates exist",
+			ram:           testRam,
+			K8sRepository: newK8sRepository(nil, nil, nil),
+		},
+		{
+			name:          "[create] ApplicationTemplate does not exist",
+			ram:           testRam,
+			K8sRepository: newK8sRepository(errNotFound, nil, nil),
+			wantErr:       true,
+		},
+		{
+			name:          "[create] ManifestsTemplate does not exist",
+			ram:           testRam,
+			K8sRepository: newK8sRepository(nil, errNotFound, nil),
+			wantErr:       true,
+		},
+		{
+			name:          "[create] JobTemplate does not exist",
+			ram:           testRam,
+			K8sRepository: newK8sRepository(nil, nil, errNotFound),
+			wantErr:       true,
+		},
+		{
+			name: "[create] failed to get templates",
+			ram:  testRam,
+			K8sRepository: func() repositories.KubernetesRepository {
+				m := mock.NewMockKubernetesRepository(mockCtrl)
+				m.EXPECT().GetApplicationTemplate(testCtx, gomock.Any()).
+					Return(models.ApplicationTemplate{}, fmt.Errorf("internal error"))
+				return m
+			},
+			wantErr: true,
+		},
+		{
+			name:          "[create] references including Go template are not checked",
+			ram:           testRamTemplatedRefs,
+			K8sRepository: noCall,
+		},
+		{
+			name:          "[create] invalid fields",
+			ram:           testRamInvalid,
+			K8sRepository: newK8sRepository(nil, nil, nil),
+			wantErr:       true,
+		},
+		{
+			name:          "[update] references are not checked",
+			update:        true,
+			ram:           testRam,
+			K8sRepository: noCall,
+		},
+		{
+			name:          "[update] invalid fields",
+			update:        true,
+			ram:           testRamInvalid,
+			K8sRepository: noCall,
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			v := &ReviewAppManagerValidator{
+				Log:           testLogger,
+				K8sRepository: tt.K8sRepository(),
+			}
+			var err error
+			if tt.update {
+				err = v.ValidateUpdate(testCtx, testRam, tt.ram)
+			} else {
+				err = v.ValidateCreate(testCtx, tt.ram)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReviewAppManagerValidator error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
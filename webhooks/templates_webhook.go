@@ -0,0 +1,70 @@
+package webhooks
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	dreamkastv1alpha1 "github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
+	"github.com/cloudnativedaysjp/reviewapp-operator/domain/models"
+)
+
+//+kubebuilder:webhook:path=/validate-dreamkast-cloudnativedays-jp-v1alpha1-applicationtemplate,mutating=false,failurePolicy=fail,sideEffects=None,groups=dreamkast.cloudnativedays.jp,resources=applicationtemplates,verbs=create;update,versions=v1alpha1,name=vapplicationtemplate.kb.io,admissionReviewVersions=v1
+//+kubebuilder:webhook:path=/validate-dreamkast-cloudnativedays-jp-v1alpha1-manifeststemplate,mutating=false,failurePolicy=fail,sideEffects=None,groups=dreamkast.cloudnativedays.jp,resources=manifeststemplates,verbs=create;update,versions=v1alpha1,name=vmanifeststemplate.kb.io,admissionReviewVersions=v1
+//+kubebuilder:webhook:path=/validate-dreamkast-cloudnativedays-jp-v1alpha1-jobtemplate,mutating=false,failurePolicy=fail,sideEffects=None,groups=dreamkast.cloudnativedays.jp,resources=jobtemplates,verbs=create;update,versions=v1alpha1,name=vjobtemplate.kb.io,admissionReviewVersions=v1
+
+// TemplateValidator validates that ApplicationTemplate, ManifestsTemplate and JobTemplate can be parsed as Go template
+type TemplateValidator struct{}
+
+var _ admission.CustomValidator = &TemplateValidator{}
+
+func (v *TemplateValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	for _, obj := range []runtime.Object{
+		&dreamkastv1alpha1.ApplicationTemplate{},
+		&dreamkastv1alpha1.ManifestsTemplate{},
+		&dreamkastv1alpha1.JobTemplate{},
+	} {
+		if err := ctrl.NewWebhookManagedBy(mgr).
+			For(obj).
+			WithValidator(v).
+			Complete(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *TemplateValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	return v.validate(obj)
+}
+
+func (v *TemplateValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	return v.validate(newObj)
+}
+
+func (v *TemplateValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+func (v *TemplateValidator) validate(obj runtime.Object) error {
+	switch o := obj.(type) {
+	case *dreamkastv1alpha1.ApplicationTemplate:
+		if errs := models.ApplicationTemplate(*o).Validate(); len(errs) != 0 {
+			return apierrors.NewInvalid(o.GVK().GroupKind(), o.Name, errs)
+		}
+	case *dreamkastv1alpha1.ManifestsTemplate:
+		if errs := models.ManifestsTemplate(*o).Validate(); len(errs) != 0 {
+			return apierrors.NewInvalid(o.GVK().GroupKind(), o.Name, errs)
+		}
+	case *dreamkastv1alpha1.JobTemplate:
+		if errs := models.JobTemplate(*o).Validate(); len(errs) != 0 {
+			return apierrors.NewInvalid(o.GVK().GroupKind(), o.Name, errs)
+		}
+	default:
+		return apierrors.NewBadRequest("unexpected object")
+	}
+	return nil
+}
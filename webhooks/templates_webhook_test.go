@@ -0,0 +1,74 @@
+//go:build !integration_test
+// +build !integration_test
+
+package webhooks
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	dreamkastv1alpha1 "github.com/cloudnativedaysjp/reviewapp-operator/api/v1alpha1"
+)
+
+func TestTemplateValidator(t *testing.T) {
+	tests := []struct {
+		name    string
+		obj     runtime.Object
+		wantErr bool
+	}{
+		{
+			name: "valid ApplicationTemplate",
+			obj: &dreamkastv1alpha1.ApplicationTemplate{Spec: dreamkastv1alpha1.ApplicationTemplateSpec{
+				StableTemplate:    "name: {{.ReviewApp.Name}}",
+				CandidateTemplate: "name: {{.ReviewApp.Name}}",
+			}},
+		},
+		{
+			name: "invalid ApplicationTemplate",
+			obj: &dreamkastv1alpha1.ApplicationTemplate{Spec: dreamkastv1alpha1.ApplicationTemplateSpec{
+				StableTemplate: "name: {{.ReviewApp.Name}",
+			}},
+			wantErr: true,
+		},
+		{
+			name: "valid ManifestsTemplate",
+			obj: &dreamkastv1alpha1.ManifestsTemplate{Spec: dreamkastv1alpha1.ManifestsTemplateSpec{
+				StableData: map[string]string{"ns.yaml": "name: {{.ReviewApp.Name}}"},
+			}},
+		},
+		{
+			name: "ManifestsTemplate with data colliding with helm chart",
+			obj: &dreamkastv1alpha1.ManifestsTemplate{Spec: dreamkastv1alpha1.ManifestsTemplateSpec{
+				StableData: map[string]string{"Chart.yaml": "name: sample"},
+				Helm:       &dreamkastv1alpha1.ManifestsTemplateSpecHelm{Chart: "nginx", Path: "charts/nginx"},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "invalid JobTemplate",
+			obj: &dreamkastv1alpha1.JobTemplate{Spec: dreamkastv1alpha1.JobTemplateSpec{
+				Template: "{{if}}",
+			}},
+			wantErr: true,
+		},
+		{
+			name:    "unexpected object",
+			obj:     &dreamkastv1alpha1.ReviewApp{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			v := &TemplateValidator{}
+			if err := v.ValidateCreate(testCtx, tt.obj); (err != nil) != tt.wantErr {
+				t.Errorf("TemplateValidator.ValidateCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := v.ValidateUpdate(testCtx, tt.obj, tt.obj); (err != nil) != tt.wantErr {
+				t.Errorf("TemplateValidator.ValidateUpdate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ package wire
 import (
 	"github.com/go-logr/logr"
 	"github.com/google/wire"
+	"k8s.io/client-go/rest"
 	"k8s.io/utils/exec"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/cloudnativedaysjp/reviewapp-operator/gateways/gitcommand"
 	"github.com/cloudnativedaysjp/reviewapp-operator/gateways/githubapi"
 	"github.com/cloudnativedaysjp/reviewapp-operator/gateways/kubernetes"
+	"github.com/cloudnativedaysjp/reviewapp-operator/gateways/kustomize"
 )
 
 func NewGitHubAPIRepository(l logr.Logger) (*githubapi.GitHub, error) {
@@ -30,7 +32,21 @@ func NewGitCommandRepository(l logr.Logger, e exec.Interface) (*gitcommand.Git,
 	return nil, nil
 }
 
-func NewKubernetesRepository(l logr.Logger, e client.Client) (*kubernetes.Client, error) {
+func NewGitCommandRepositoryWithBaseDir(l logr.Logger, e exec.Interface, basedir string) (*gitcommand.Git, error) {
+	wire.Build(
+		gitcommand.NewGitWithBaseDir,
+	)
+	return nil, nil
+}
+
+func NewManifestsValidator(l logr.Logger) (*kustomize.Kustomize, error) {
+	wire.Build(
+		kustomize.NewKustomize,
+	)
+	return nil, nil
+}
+
+func NewKubernetesRepository(l logr.Logger, e client.Client, cfg *rest.Config) (*kubernetes.Client, error) {
 	wire.Build(
 		kubernetes.NewClient,
 	)
@@ -11,7 +11,9 @@ import (
 	"github.com/cloudnativedaysjp/reviewapp-operator/gateways/gitcommand"
 	"github.com/cloudnativedaysjp/reviewapp-operator/gateways/githubapi"
 	"github.com/cloudnativedaysjp/reviewapp-operator/gateways/kubernetes"
+	"github.com/cloudnativedaysjp/reviewapp-operator/gateways/kustomize"
 	"github.com/go-logr/logr"
+	"k8s.io/client-go/rest"
 	"k8s.io/utils/exec"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -31,8 +33,24 @@ func NewGitCommandRepository(l logr.Logger, e exec.Interface) (*gitcommand.Git,
 	return git, nil
 }
 
-func NewKubernetesRepository(l logr.Logger, e client.Client) (*kubernetes.Client, error) {
-	kubernetesClient := kubernetes.NewClient(l, e)
+func NewGitCommandRepositoryWithBaseDir(l logr.Logger, e exec.Interface, basedir string) (*gitcommand.Git, error) {
+	git, err := gitcommand.NewGitWithBaseDir(l, e, basedir)
+	if err != nil {
+		return nil, err
+	}
+	return git, nil
+}
+
+func NewManifestsValidator(l logr.Logger) (*kustomize.Kustomize, error) {
+	kustomizeKustomize := kustomize.NewKustomize(l)
+	return kustomizeKustomize, nil
+}
+
+func NewKubernetesRepository(l logr.Logger, e client.Client, cfg *rest.Config) (*kubernetes.Client, error) {
+	kubernetesClient, err := kubernetes.NewClient(l, e, cfg)
+	if err != nil {
+		return nil, err
+	}
 	return kubernetesClient, nil
 }
 